@@ -15,11 +15,10 @@ import (
 	"github.com/lunovoy/friendly/internal/repository"
 	"github.com/lunovoy/friendly/internal/server"
 	"github.com/lunovoy/friendly/internal/service"
+	"github.com/lunovoy/friendly/internal/storage"
 	"github.com/spf13/viper"
 )
 
-const uploadDir = "./images/"
-
 // @title Friendly app API
 // @version 1.0
 // @description API Server for Friendly Application
@@ -32,10 +31,6 @@ const uploadDir = "./images/"
 // @in header
 // @name Authorization
 func main() {
-	if _, err := os.Stat(uploadDir); os.IsNotExist(err) {
-		os.Mkdir(uploadDir, os.ModePerm)
-	}
-
 	logrus.SetFormatter(&logrus.TextFormatter{
 		FullTimestamp: false,
 	})
@@ -60,9 +55,25 @@ func main() {
 		logrus.Fatalf("error connecting postgres DB: %s", err.Error())
 	}
 
+	store, err := storage.New(storage.Config{
+		Backend: viper.GetString("storage.backend"),
+		Dir:     viper.GetString("storage.dir"),
+		S3: storage.S3Config{
+			Endpoint:  viper.GetString("storage.s3.endpoint"),
+			Region:    viper.GetString("storage.s3.region"),
+			Bucket:    viper.GetString("storage.s3.bucket"),
+			AccessKey: os.Getenv("S3_ACCESS_KEY"),
+			SecretKey: os.Getenv("S3_SECRET_KEY"),
+			PathStyle: viper.GetBool("storage.s3.path_style"),
+		},
+	})
+	if err != nil {
+		logrus.Fatalf("error initializing storage: %s", err.Error())
+	}
+
 	repo := repository.NewRepository(db)
-	service := service.NewService(repo)
-	handler := handler.NewHandler(service)
+	services := service.NewService(repo, store)
+	handler := handler.NewHandler(services, store)
 
 	serverPort := viper.GetString("port")
 	if serverPort == "" {
@@ -71,6 +82,15 @@ func main() {
 
 	server := server.NewAPIServer(serverPort, handler.InitRoutes())
 
+	var notifiers []service.Notifier
+	if tgToken := os.Getenv("TG_BOT_TOKEN"); tgToken != "" {
+		notifiers = append(notifiers, service.NewTelegramNotifier(tgToken, repo.TgChat))
+	}
+	dispatcher := service.NewDispatcher(repo, store, viper.GetDuration("dispatcher.interval"), viper.GetDuration("trash.retention"), notifiers...)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go dispatcher.Run(ctx)
+
 	go func() {
 		if err := server.Run(); err != nil {
 			logrus.Fatalf("error starting server: %s", err.Error())
@@ -83,6 +103,8 @@ func main() {
 
 	logrus.Println("Server shutting down...")
 
+	cancel()
+
 	if err := server.Shutdown(context.Background()); err != nil {
 		logrus.Errorf("error while shutting down server: %s", err.Error())
 	}
@@ -0,0 +1,95 @@
+// Command storage-migrate copies uploaded images and attachments between
+// storage backends, e.g. from the local dir to an S3 bucket:
+//
+//	make storage-migrate ARGS="-from local -to s3"
+//
+// Backend settings are read from configs/config.yml, S3 keys from the
+// S3_ACCESS_KEY and S3_SECRET_KEY env variables. Objects already present in
+// the target with the same size are skipped.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/joho/godotenv"
+	"github.com/lunovoy/friendly/internal/storage"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+func main() {
+	from := flag.String("from", storage.BackendLocal, "source backend, local or s3")
+	to := flag.String("to", storage.BackendS3, "target backend, local or s3")
+	prefixes := flag.String("prefix", "images/,attachments/", "comma separated key prefixes to copy")
+	overwrite := flag.Bool("overwrite", false, "copy objects already present in the target")
+	deleteSource := flag.Bool("delete", false, "delete objects from the source after they are copied")
+	dryRun := flag.Bool("dry-run", false, "only print what would be copied")
+	flag.Parse()
+
+	logrus.SetFormatter(&logrus.TextFormatter{
+		FullTimestamp: false,
+	})
+
+	if *from == *to {
+		logrus.Fatalf("source and target backends must differ")
+	}
+
+	viper.AddConfigPath("configs")
+	viper.SetConfigName("config")
+	if err := viper.ReadInConfig(); err != nil {
+		logrus.Fatalf("error initializing config: %s", err.Error())
+	}
+	if err := godotenv.Load(); err != nil && !errors.Is(err, os.ErrNotExist) {
+		logrus.Fatalf("error loading env variables: %s", err.Error())
+	}
+
+	src, err := storage.New(storageConfig(*from))
+	if err != nil {
+		logrus.Fatalf("error initializing source storage: %s", err.Error())
+	}
+	dst, err := storage.New(storageConfig(*to))
+	if err != nil {
+		logrus.Fatalf("error initializing target storage: %s", err.Error())
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
+	defer cancel()
+
+	m := migration{
+		src:          src,
+		dst:          dst,
+		overwrite:    *overwrite,
+		deleteSource: *deleteSource,
+		dryRun:       *dryRun,
+	}
+	result, err := m.run(ctx, strings.Split(*prefixes, ","))
+	if err != nil {
+		logrus.Fatalf("%s after %d copied, %d skipped, %d failed", err.Error(), result.copied, result.skipped, result.failed)
+	}
+
+	logrus.Infof("%d copied, %d skipped, %d failed", result.copied, result.skipped, result.failed)
+	if result.failed > 0 {
+		os.Exit(1)
+	}
+}
+
+func storageConfig(backend string) storage.Config {
+	return storage.Config{
+		Backend: backend,
+		Dir:     viper.GetString("storage.dir"),
+		S3: storage.S3Config{
+			Endpoint:  viper.GetString("storage.s3.endpoint"),
+			Region:    viper.GetString("storage.s3.region"),
+			Bucket:    viper.GetString("storage.s3.bucket"),
+			AccessKey: os.Getenv("S3_ACCESS_KEY"),
+			SecretKey: os.Getenv("S3_SECRET_KEY"),
+			PathStyle: viper.GetBool("storage.s3.path_style"),
+		},
+	}
+}
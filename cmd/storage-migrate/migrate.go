@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"path"
+	"strings"
+
+	"github.com/lunovoy/friendly/internal/storage"
+	"github.com/sirupsen/logrus"
+)
+
+type migration struct {
+	src, dst     storage.Storage
+	overwrite    bool
+	deleteSource bool
+	dryRun       bool
+}
+
+type migrationResult struct {
+	copied, skipped, failed int
+}
+
+// run copies objects with the prefixes, failures of single objects are
+// counted and the rest is copied anyway. Listing errors and interruption stop
+// the migration.
+func (m migration) run(ctx context.Context, prefixes []string) (migrationResult, error) {
+	var result migrationResult
+	for _, prefix := range prefixes {
+		prefix = strings.TrimSpace(prefix)
+		if prefix == "" {
+			continue
+		}
+
+		objects, err := m.src.List(ctx, prefix)
+		if err != nil {
+			return result, fmt.Errorf("error listing %s: %w", prefix, err)
+		}
+
+		for _, object := range objects {
+			if err := ctx.Err(); err != nil {
+				return result, err
+			}
+
+			if !m.overwrite {
+				existing, err := m.dst.Stat(ctx, object.Key)
+				if err == nil && existing.Size == object.Size {
+					result.skipped++
+					continue
+				}
+				if err != nil && !errors.Is(err, storage.ErrNotFound) {
+					logrus.Errorf("error checking %s in target: %s", object.Key, err.Error())
+					result.failed++
+					continue
+				}
+			}
+
+			if m.dryRun {
+				logrus.Infof("would copy %s (%d bytes)", object.Key, object.Size)
+				result.copied++
+				continue
+			}
+
+			if err := copyObject(ctx, m.src, m.dst, object.Key); err != nil {
+				logrus.Errorf("error copying %s: %s", object.Key, err.Error())
+				result.failed++
+				continue
+			}
+			if m.deleteSource {
+				if err := m.src.Delete(ctx, object.Key); err != nil {
+					logrus.Errorf("error deleting %s from source: %s", object.Key, err.Error())
+				}
+			}
+			result.copied++
+		}
+	}
+
+	return result, nil
+}
+
+func copyObject(ctx context.Context, src, dst storage.Storage, key string) error {
+	file, err := src.Get(ctx, key)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return dst.Put(ctx, key, file, mime.TypeByExtension(path.Ext(key)))
+}
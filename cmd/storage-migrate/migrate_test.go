@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/lunovoy/friendly/internal/storage"
+)
+
+func put(t *testing.T, s storage.Storage, key, data string) {
+	t.Helper()
+	if err := s.Put(context.Background(), key, strings.NewReader(data), ""); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func read(t *testing.T, s storage.Storage, key string) string {
+	t.Helper()
+	file, err := s.Get(context.Background(), key)
+	if err != nil {
+		t.Fatalf("get %s: %s", key, err)
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestMigrationRun(t *testing.T) {
+	tests := []struct {
+		name         string
+		overwrite    bool
+		deleteSource bool
+		dryRun       bool
+		want         migrationResult
+		wantTarget   map[string]string
+		wantSource   bool
+	}{
+		{
+			name:       "copies missing and changed objects",
+			want:       migrationResult{copied: 2, skipped: 1},
+			wantTarget: map[string]string{"images/a.jpg": "new a", "images/b.png": "same", "attachments/c": "c"},
+			wantSource: true,
+		},
+		{
+			name:       "overwrite copies everything",
+			overwrite:  true,
+			want:       migrationResult{copied: 3},
+			wantTarget: map[string]string{"images/a.jpg": "new a", "images/b.png": "same", "attachments/c": "c"},
+			wantSource: true,
+		},
+		{
+			name:       "dry run copies nothing",
+			dryRun:     true,
+			want:       migrationResult{copied: 2, skipped: 1},
+			wantTarget: map[string]string{"images/a.jpg": "old", "images/b.png": "same"},
+			wantSource: true,
+		},
+		{
+			name:         "delete removes copied objects from the source",
+			deleteSource: true,
+			want:         migrationResult{copied: 2, skipped: 1},
+			wantTarget:   map[string]string{"images/a.jpg": "new a", "images/b.png": "same", "attachments/c": "c"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src, dst := storage.NewLocal(t.TempDir()), storage.NewLocal(t.TempDir())
+			put(t, src, "images/a.jpg", "new a")
+			put(t, src, "images/b.png", "same")
+			put(t, src, "attachments/c", "c")
+			put(t, src, "other/d", "not migrated")
+			put(t, dst, "images/a.jpg", "old")
+			put(t, dst, "images/b.png", "same")
+
+			m := migration{src: src, dst: dst, overwrite: tt.overwrite, deleteSource: tt.deleteSource, dryRun: tt.dryRun}
+			result, err := m.run(context.Background(), []string{"images/", " attachments/", ""})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result != tt.want {
+				t.Errorf("result = %+v, want %+v", result, tt.want)
+			}
+
+			for key, data := range tt.wantTarget {
+				if got := read(t, dst, key); got != data {
+					t.Errorf("target %s = %q, want %q", key, got, data)
+				}
+			}
+			if _, err := dst.Stat(context.Background(), "other/d"); !errors.Is(err, storage.ErrNotFound) {
+				t.Errorf("object out of the prefixes is copied")
+			}
+			_, err = src.Stat(context.Background(), "attachments/c")
+			if tt.wantSource != (err == nil) {
+				t.Errorf("source kept = %v, want %v", err == nil, tt.wantSource)
+			}
+		})
+	}
+}
+
+func TestMigrationRunCancelled(t *testing.T) {
+	src, dst := storage.NewLocal(t.TempDir()), storage.NewLocal(t.TempDir())
+	put(t, src, "images/a.jpg", "a")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	m := migration{src: src, dst: dst}
+	if _, err := m.run(ctx, []string{"images/"}); !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want context.Canceled", err)
+	}
+}
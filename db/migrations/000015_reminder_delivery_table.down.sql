@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "reminder_delivery";
\ No newline at end of file
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS "reminder_delivery" (
+    "id" UUID DEFAULT uuid_generate_v4() PRIMARY KEY,
+    "reminder_id" UUID not null,
+    "event_id" UUID not null,
+    "occurrence_date" timestamp with time zone not null,
+    "notify_at" timestamp with time zone not null,
+    "status" varchar(20) not null DEFAULT 'pending',
+    "snoozed_until" timestamp with time zone,
+    "sent_at" timestamp with time zone,
+    "updated_at" timestamp with time zone not null DEFAULT now(),
+    "user_id" UUID not null,
+    UNIQUE ("reminder_id", "occurrence_date"),
+    FOREIGN KEY ("reminder_id") REFERENCES "reminder" ("id") ON DELETE CASCADE,
+    FOREIGN KEY ("event_id") REFERENCES "event" ("id") ON DELETE CASCADE,
+    FOREIGN KEY ("user_id") REFERENCES "user" ("id") ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS "reminder_delivery_status_notify_at_idx" ON "reminder_delivery" ("status", "notify_at");
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "digest_setting";
\ No newline at end of file
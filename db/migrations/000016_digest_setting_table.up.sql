@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS "digest_setting" (
+    "id" UUID DEFAULT uuid_generate_v4() PRIMARY KEY,
+    "is_active" boolean DEFAULT false not null,
+    "frequency" varchar(10) DEFAULT 'daily' not null,
+    "time_of_day" varchar(5) DEFAULT '09:00' not null,
+    "weekday" integer DEFAULT 1 not null,
+    "timezone" varchar(64) DEFAULT 'UTC' not null,
+    "days_ahead" integer DEFAULT 7 not null,
+    "last_sent_at" timestamp with time zone,
+    "user_id" UUID unique not null,
+    FOREIGN KEY ("user_id") REFERENCES "user" ("id") ON DELETE CASCADE
+);
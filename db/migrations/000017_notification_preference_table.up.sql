@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS "notification_preference" (
+    "id" UUID DEFAULT uuid_generate_v4() PRIMARY KEY,
+    "channels" text[] DEFAULT '{telegram}' not null,
+    "quiet_hours_start" varchar(5) DEFAULT '' not null,
+    "quiet_hours_end" varchar(5) DEFAULT '' not null,
+    "timezone" varchar(64) DEFAULT 'UTC' not null,
+    "birthdays" boolean DEFAULT true not null,
+    "events" boolean DEFAULT true not null,
+    "digests" boolean DEFAULT true not null,
+    "user_id" UUID unique not null,
+    FOREIGN KEY ("user_id") REFERENCES "user" ("id") ON DELETE CASCADE
+);
@@ -0,0 +1,14 @@
+DROP INDEX IF EXISTS "reminder_delivery_friend_occurrence_idx";
+
+DELETE FROM "reminder_delivery" WHERE "reminder_id" IS NULL OR "event_id" IS NULL;
+
+ALTER TABLE IF EXISTS "reminder_delivery"
+    DROP COLUMN IF EXISTS "friend_id",
+    DROP COLUMN IF EXISTS "offset_minutes",
+    DROP COLUMN IF EXISTS "kind",
+    ALTER COLUMN "event_id" SET NOT NULL,
+    ALTER COLUMN "reminder_id" SET NOT NULL;
+
+ALTER TABLE IF EXISTS "notification_preference" DROP COLUMN IF EXISTS "birthday_reminder_minutes";
+
+ALTER TABLE IF EXISTS "friend" DROP COLUMN IF EXISTS "track_birthday";
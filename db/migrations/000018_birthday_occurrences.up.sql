@@ -0,0 +1,28 @@
+ALTER TABLE IF EXISTS "friend" ADD COLUMN IF NOT EXISTS "track_birthday" boolean DEFAULT true not null;
+
+ALTER TABLE IF EXISTS "notification_preference" ADD COLUMN IF NOT EXISTS "birthday_reminder_minutes" integer[] DEFAULT '{0}' not null;
+
+ALTER TABLE IF EXISTS "reminder_delivery"
+    ALTER COLUMN "reminder_id" DROP NOT NULL,
+    ALTER COLUMN "event_id" DROP NOT NULL,
+    ADD COLUMN IF NOT EXISTS "kind" varchar(20) DEFAULT 'event' not null,
+    ADD COLUMN IF NOT EXISTS "offset_minutes" integer DEFAULT 0 not null,
+    ADD COLUMN IF NOT EXISTS "friend_id" UUID REFERENCES "friend" ("id") ON DELETE CASCADE;
+
+CREATE UNIQUE INDEX IF NOT EXISTS "reminder_delivery_friend_occurrence_idx" ON "reminder_delivery" ("kind", "friend_id", "occurrence_date", "offset_minutes") WHERE "friend_id" IS NOT NULL;
+
+-- Birthday events used to be created for every friend with a dob when the
+-- friend was added, they are generated from friend.dob now. Only events
+-- matching what that code produced are removed: the exact title of the one
+-- linked friend, on the friend's dob and lasting five minutes.
+DELETE FROM "event" e
+USING "friends_events" fe, "friend" f
+WHERE fe.event_id = e.id
+    AND f.id = fe.friend_id
+    AND f.user_id = e.user_id
+    AND f.dob IS NOT NULL
+    AND e.frequency = 'annually'
+    AND e.title = 'День рождение: ' || f.first_name || ' ' || COALESCE(f.last_name, '')
+    AND to_char(e.start_date, 'MM-DD HH24:MI') = to_char(f.dob, 'MM-DD HH24:MI')
+    AND e.end_date = e.start_date + interval '5 minutes'
+    AND NOT EXISTS(SELECT 1 FROM "friends_events" other WHERE other.event_id = e.id AND other.friend_id <> f.id);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS "reminder_delivery_friend_date_occurrence_idx";
+
+ALTER TABLE IF EXISTS "reminder_delivery" DROP COLUMN IF EXISTS "friend_date_id";
+
+DROP TABLE IF EXISTS "friend_date";
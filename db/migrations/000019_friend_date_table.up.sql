@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS "friend_date" (
+    "id" UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    "label" varchar(100) not null,
+    "kind" varchar(30) DEFAULT 'other' not null,
+    "month" smallint not null CHECK ("month" BETWEEN 1 AND 12),
+    "day" smallint not null CHECK ("day" BETWEEN 1 AND 31),
+    "year" integer,
+    "recurrence" varchar(20) DEFAULT 'annually' not null,
+    "reminder_minutes" integer[] DEFAULT '{0}' not null,
+    "friend_id" UUID REFERENCES "friend" ("id") ON DELETE CASCADE not null,
+    "user_id" UUID REFERENCES "user" ("id") ON DELETE CASCADE not null
+);
+
+CREATE INDEX IF NOT EXISTS "friend_date_friend_id_idx" ON "friend_date" ("friend_id");
+
+ALTER TABLE IF EXISTS "reminder_delivery" ADD COLUMN IF NOT EXISTS "friend_date_id" UUID REFERENCES "friend_date" ("id") ON DELETE CASCADE;
+
+CREATE UNIQUE INDEX IF NOT EXISTS "reminder_delivery_friend_date_occurrence_idx" ON "reminder_delivery" ("friend_date_id", "occurrence_date", "offset_minutes") WHERE "friend_date_id" IS NOT NULL;
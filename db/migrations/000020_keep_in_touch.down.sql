@@ -0,0 +1,9 @@
+DELETE FROM "reminder_delivery" WHERE "kind" = 'keep_in_touch';
+
+ALTER TABLE IF EXISTS "notification_preference" DROP COLUMN IF EXISTS "keep_in_touch";
+
+ALTER TABLE IF EXISTS "friendlist" DROP COLUMN IF EXISTS "contact_interval_days";
+
+ALTER TABLE IF EXISTS "friend"
+    DROP COLUMN IF EXISTS "last_contacted_at",
+    DROP COLUMN IF EXISTS "contact_interval_days";
@@ -0,0 +1,7 @@
+ALTER TABLE IF EXISTS "friend"
+    ADD COLUMN IF NOT EXISTS "contact_interval_days" integer,
+    ADD COLUMN IF NOT EXISTS "last_contacted_at" timestamptz;
+
+ALTER TABLE IF EXISTS "friendlist" ADD COLUMN IF NOT EXISTS "contact_interval_days" integer;
+
+ALTER TABLE IF EXISTS "notification_preference" ADD COLUMN IF NOT EXISTS "keep_in_touch" boolean DEFAULT true not null;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "friend_change";
+
+DROP TABLE IF EXISTS "interaction";
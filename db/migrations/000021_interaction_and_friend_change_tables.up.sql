@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS "interaction" (
+    "id" UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    "kind" varchar(20) not null,
+    "occurred_at" timestamp with time zone not null,
+    "channel" varchar(50) DEFAULT '' not null,
+    "text" text DEFAULT '' not null,
+    "event_id" UUID REFERENCES "event" ("id") ON DELETE SET NULL,
+    "friend_id" UUID REFERENCES "friend" ("id") ON DELETE CASCADE not null,
+    "user_id" UUID REFERENCES "user" ("id") ON DELETE CASCADE not null
+);
+
+CREATE INDEX IF NOT EXISTS "interaction_friend_id_occurred_at_idx" ON "interaction" ("friend_id", "occurred_at");
+
+CREATE TABLE IF NOT EXISTS "friend_change" (
+    "id" UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    "entity" varchar(30) not null,
+    "field" varchar(50) not null,
+    "old_value" text DEFAULT '' not null,
+    "new_value" text DEFAULT '' not null,
+    "changed_at" timestamp with time zone DEFAULT now() not null,
+    "friend_id" UUID REFERENCES "friend" ("id") ON DELETE CASCADE not null,
+    "user_id" UUID REFERENCES "user" ("id") ON DELETE CASCADE not null
+);
+
+CREATE INDEX IF NOT EXISTS "friend_change_friend_id_changed_at_idx" ON "friend_change" ("friend_id", "changed_at");
@@ -0,0 +1,9 @@
+ALTER TABLE IF EXISTS "friends_additional_info_fields" DROP CONSTRAINT IF EXISTS "friends_additional_info_fields_field_friend_key";
+
+ALTER TABLE IF EXISTS "additional_info_field_text" DROP CONSTRAINT IF EXISTS "additional_info_field_text_field_friend_key";
+
+ALTER TABLE IF EXISTS "additional_info_field_text" ALTER COLUMN "content" TYPE varchar(255) USING left("content", 255);
+
+ALTER TABLE IF EXISTS "additional_info_field"
+    DROP COLUMN IF EXISTS "options",
+    DROP COLUMN IF EXISTS "type";
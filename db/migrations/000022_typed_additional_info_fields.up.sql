@@ -0,0 +1,21 @@
+ALTER TABLE IF EXISTS "additional_info_field"
+    ADD COLUMN IF NOT EXISTS "type" varchar(20) DEFAULT 'text' not null,
+    ADD COLUMN IF NOT EXISTS "options" text[] DEFAULT '{}' not null;
+
+ALTER TABLE IF EXISTS "additional_info_field_text" ALTER COLUMN "content" TYPE text;
+
+DELETE FROM "additional_info_field_text" a
+USING "additional_info_field_text" b
+WHERE a.additional_info_field_id = b.additional_info_field_id AND a.friend_id = b.friend_id AND a.id < b.id;
+
+ALTER TABLE IF EXISTS "additional_info_field_text" ADD CONSTRAINT "additional_info_field_text_field_friend_key" UNIQUE ("additional_info_field_id", "friend_id");
+
+DELETE FROM "friends_additional_info_fields" a
+USING "friends_additional_info_fields" b
+WHERE a.additional_info_field_id = b.additional_info_field_id AND a.friend_id = b.friend_id AND a.id < b.id;
+
+ALTER TABLE IF EXISTS "friends_additional_info_fields" ADD CONSTRAINT "friends_additional_info_fields_field_friend_key" UNIQUE ("additional_info_field_id", "friend_id");
+
+INSERT INTO "friends_additional_info_fields" ("friend_id", "additional_info_field_id")
+SELECT "friend_id", "additional_info_field_id" FROM "additional_info_field_text"
+ON CONFLICT DO NOTHING;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "friendlists_additional_info_fields";
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS "friendlists_additional_info_fields" (
+    "id" UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    "position" integer DEFAULT 0 not null,
+    "friendlist_id" UUID REFERENCES "friendlist" ("id") ON DELETE CASCADE not null,
+    "additional_info_field_id" UUID REFERENCES "additional_info_field" ("id") ON DELETE CASCADE not null,
+    UNIQUE ("friendlist_id", "additional_info_field_id")
+);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS "contact_point" (
+    "id" UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    "kind" varchar(20) not null,
+    "label" varchar(100) DEFAULT '' not null,
+    "value" varchar(500) not null,
+    "is_primary" boolean DEFAULT false not null,
+    "friend_id" UUID REFERENCES "friend" ("id") ON DELETE CASCADE not null,
+    "user_id" UUID REFERENCES "user" ("id") ON DELETE CASCADE not null
+);
+
+CREATE INDEX IF NOT EXISTS "contact_point_friend_id_idx" ON "contact_point" ("friend_id");
+
+CREATE UNIQUE INDEX IF NOT EXISTS "contact_point_primary_idx" ON "contact_point" ("friend_id", "kind") WHERE "is_primary";
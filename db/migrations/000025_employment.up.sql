@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS "employment" (
+    "id" UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    "company" varchar(100) DEFAULT '' not null,
+    "position" varchar(100) DEFAULT '' not null,
+    "profession" varchar(100) DEFAULT '' not null,
+    "city" varchar(100) DEFAULT '' not null,
+    "started_at" date,
+    "ended_at" date,
+    "friend_id" UUID REFERENCES "friend" ("id") ON DELETE CASCADE not null,
+    "user_id" UUID REFERENCES "user" ("id") ON DELETE CASCADE not null,
+    CHECK ("ended_at" IS NULL OR "started_at" IS NULL OR "ended_at" >= "started_at")
+);
+
+CREATE INDEX IF NOT EXISTS "employment_friend_id_idx" ON "employment" ("friend_id");
+
+INSERT INTO "employment" ("company", "position", "profession", "city", "friend_id", "user_id")
+SELECT COALESCE(w."company", ''), COALESCE(w."position", ''), COALESCE(w."profession", ''), COALESCE(w."city", ''), w."friend_id", f."user_id"
+FROM "work_info" w
+INNER JOIN "friend" f ON f."id" = w."friend_id"
+WHERE COALESCE(w."company", '') <> '' OR COALESCE(w."position", '') <> '' OR COALESCE(w."profession", '') <> '';
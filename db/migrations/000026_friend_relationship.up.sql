@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS "friend_relationship" (
+    "id" UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    "kind" varchar(30) not null,
+    "friend_id" UUID REFERENCES "friend" ("id") ON DELETE CASCADE not null,
+    "related_friend_id" UUID REFERENCES "friend" ("id") ON DELETE CASCADE not null,
+    "user_id" UUID REFERENCES "user" ("id") ON DELETE CASCADE not null,
+    CHECK ("friend_id" <> "related_friend_id"),
+    UNIQUE ("kind", "friend_id", "related_friend_id")
+);
+
+CREATE INDEX IF NOT EXISTS "friend_relationship_friend_id_idx" ON "friend_relationship" ("friend_id");
+CREATE INDEX IF NOT EXISTS "friend_relationship_related_friend_id_idx" ON "friend_relationship" ("related_friend_id");
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS "interaction_search_idx";
+DROP INDEX IF EXISTS "event_search_idx";
+
+DROP FUNCTION IF EXISTS "search_vector"(text);
@@ -0,0 +1,8 @@
+CREATE EXTENSION IF NOT EXISTS "pg_trgm";
+
+-- russian configuration stems cyrillic words with russian_stem and latin ones with english_stem
+CREATE OR REPLACE FUNCTION "search_vector"("body" text) RETURNS tsvector
+    LANGUAGE sql IMMUTABLE AS $$ SELECT to_tsvector('russian'::regconfig, COALESCE("body", '')) $$;
+
+CREATE INDEX IF NOT EXISTS "event_search_idx" ON "event" USING GIN (search_vector("title" || ' ' || COALESCE("description", '')));
+CREATE INDEX IF NOT EXISTS "interaction_search_idx" ON "interaction" USING GIN (search_vector("text"));
@@ -0,0 +1 @@
+ALTER TABLE IF EXISTS "friendlist" DROP COLUMN IF EXISTS "rule";
@@ -0,0 +1 @@
+ALTER TABLE IF EXISTS "friendlist" ADD COLUMN IF NOT EXISTS "rule" text;
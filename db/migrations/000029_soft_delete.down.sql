@@ -0,0 +1,9 @@
+DELETE FROM "friend" WHERE "deleted_at" IS NOT NULL;
+DELETE FROM "event" WHERE "deleted_at" IS NOT NULL;
+DELETE FROM "friendlist" WHERE "deleted_at" IS NOT NULL;
+DELETE FROM "tag" WHERE "deleted_at" IS NOT NULL;
+
+ALTER TABLE IF EXISTS "friend" DROP COLUMN IF EXISTS "deleted_at";
+ALTER TABLE IF EXISTS "event" DROP COLUMN IF EXISTS "deleted_at";
+ALTER TABLE IF EXISTS "friendlist" DROP COLUMN IF EXISTS "deleted_at";
+ALTER TABLE IF EXISTS "tag" DROP COLUMN IF EXISTS "deleted_at";
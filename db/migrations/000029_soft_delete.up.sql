@@ -0,0 +1,9 @@
+ALTER TABLE IF EXISTS "friend" ADD COLUMN IF NOT EXISTS "deleted_at" timestamp with time zone;
+ALTER TABLE IF EXISTS "event" ADD COLUMN IF NOT EXISTS "deleted_at" timestamp with time zone;
+ALTER TABLE IF EXISTS "friendlist" ADD COLUMN IF NOT EXISTS "deleted_at" timestamp with time zone;
+ALTER TABLE IF EXISTS "tag" ADD COLUMN IF NOT EXISTS "deleted_at" timestamp with time zone;
+
+CREATE INDEX IF NOT EXISTS "friend_deleted_at_idx" ON "friend" ("deleted_at") WHERE "deleted_at" IS NOT NULL;
+CREATE INDEX IF NOT EXISTS "event_deleted_at_idx" ON "event" ("deleted_at") WHERE "deleted_at" IS NOT NULL;
+CREATE INDEX IF NOT EXISTS "friendlist_deleted_at_idx" ON "friendlist" ("deleted_at") WHERE "deleted_at" IS NOT NULL;
+CREATE INDEX IF NOT EXISTS "tag_deleted_at_idx" ON "tag" ("deleted_at") WHERE "deleted_at" IS NOT NULL;
@@ -0,0 +1 @@
+ALTER TABLE IF EXISTS "friend_change" DROP COLUMN IF EXISTS "changed_by";
@@ -0,0 +1,3 @@
+ALTER TABLE IF EXISTS "friend_change" ADD COLUMN IF NOT EXISTS "changed_by" UUID REFERENCES "user" ("id") ON DELETE SET NULL;
+
+UPDATE "friend_change" SET "changed_by" = "user_id" WHERE "changed_by" IS NULL;
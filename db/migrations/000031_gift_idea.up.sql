@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS "gift_idea" (
+    "id" UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    "title" varchar(255) not null,
+    "link" varchar(2000) DEFAULT '' not null,
+    "price" numeric(12, 2),
+    "status" varchar(20) DEFAULT 'idea' not null,
+    "occasion" varchar(20) DEFAULT '' not null,
+    "occasion_date" date,
+    "event_id" UUID REFERENCES "event" ("id") ON DELETE SET NULL,
+    "created_at" timestamp with time zone DEFAULT now() not null,
+    "friend_id" UUID REFERENCES "friend" ("id") ON DELETE CASCADE not null,
+    "user_id" UUID REFERENCES "user" ("id") ON DELETE CASCADE not null
+);
+
+CREATE INDEX IF NOT EXISTS "gift_idea_friend_id_idx" ON "gift_idea" ("friend_id");
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "note_mention";
+
+DROP TABLE IF EXISTS "note";
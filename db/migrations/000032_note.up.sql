@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS "note" (
+    "id" UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    "body" text not null,
+    "friend_id" UUID REFERENCES "friend" ("id") ON DELETE CASCADE,
+    "event_id" UUID REFERENCES "event" ("id") ON DELETE CASCADE,
+    "friendlist_id" UUID REFERENCES "friendlist" ("id") ON DELETE CASCADE,
+    "created_at" timestamp with time zone DEFAULT now() not null,
+    "updated_at" timestamp with time zone DEFAULT now() not null,
+    "user_id" UUID REFERENCES "user" ("id") ON DELETE CASCADE not null,
+    CHECK (num_nonnulls("friend_id", "event_id", "friendlist_id") = 1)
+);
+
+CREATE INDEX IF NOT EXISTS "note_friend_id_idx" ON "note" ("friend_id");
+CREATE INDEX IF NOT EXISTS "note_event_id_idx" ON "note" ("event_id");
+CREATE INDEX IF NOT EXISTS "note_friendlist_id_idx" ON "note" ("friendlist_id");
+
+CREATE TABLE IF NOT EXISTS "note_mention" (
+    "id" UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    "note_id" UUID REFERENCES "note" ("id") ON DELETE CASCADE not null,
+    "friend_id" UUID REFERENCES "friend" ("id") ON DELETE CASCADE not null,
+    UNIQUE ("note_id", "friend_id")
+);
+
+CREATE INDEX IF NOT EXISTS "note_mention_friend_id_idx" ON "note_mention" ("friend_id");
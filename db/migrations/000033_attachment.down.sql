@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "attachment";
+
+ALTER TABLE "user" DROP COLUMN IF EXISTS "storage_quota";
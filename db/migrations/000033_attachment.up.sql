@@ -0,0 +1,17 @@
+ALTER TABLE "user" ADD COLUMN IF NOT EXISTS "storage_quota" bigint DEFAULT 104857600 not null;
+
+CREATE TABLE IF NOT EXISTS "attachment" (
+    "id" UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    "file_name" varchar(255) not null,
+    "mime_type" varchar(255) not null,
+    "size" bigint not null CHECK ("size" >= 0),
+    "friend_id" UUID REFERENCES "friend" ("id") ON DELETE CASCADE,
+    "event_id" UUID REFERENCES "event" ("id") ON DELETE CASCADE,
+    "created_at" timestamp with time zone DEFAULT now() not null,
+    "user_id" UUID REFERENCES "user" ("id") ON DELETE CASCADE not null,
+    CHECK (num_nonnulls("friend_id", "event_id") = 1)
+);
+
+CREATE INDEX IF NOT EXISTS "attachment_friend_id_idx" ON "attachment" ("friend_id");
+CREATE INDEX IF NOT EXISTS "attachment_event_id_idx" ON "attachment" ("event_id");
+CREATE INDEX IF NOT EXISTS "attachment_user_id_idx" ON "attachment" ("user_id");
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS "photo" (
+    "id" UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    "image_id" UUID not null,
+    "caption" text DEFAULT '' not null,
+    "taken_at" timestamp with time zone,
+    "position" integer DEFAULT 0 not null,
+    "friend_id" UUID REFERENCES "friend" ("id") ON DELETE CASCADE not null,
+    "event_id" UUID REFERENCES "event" ("id") ON DELETE SET NULL,
+    "created_at" timestamp with time zone DEFAULT now() not null,
+    "user_id" UUID REFERENCES "user" ("id") ON DELETE CASCADE not null,
+    UNIQUE ("friend_id", "image_id")
+);
+
+CREATE INDEX IF NOT EXISTS "photo_event_id_idx" ON "photo" ("event_id");
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "friendlist_share";
+
+DROP TABLE IF EXISTS "image";
@@ -0,0 +1,29 @@
+CREATE TABLE IF NOT EXISTS "image" (
+    "id" UUID PRIMARY KEY,
+    "mime_type" varchar(255) DEFAULT '' not null,
+    "width" integer DEFAULT 0 not null,
+    "height" integer DEFAULT 0 not null,
+    "size" bigint DEFAULT 0 not null,
+    "created_at" timestamp with time zone DEFAULT now() not null,
+    "user_id" UUID REFERENCES "user" ("id") ON DELETE CASCADE not null
+);
+
+CREATE INDEX IF NOT EXISTS "image_user_id_idx" ON "image" ("user_id");
+
+-- Images uploaded before are owned by whoever uses them, metadata stays unknown
+INSERT INTO "image" ("id", "user_id")
+SELECT "image_id", "id" FROM "user" WHERE "image_id" IS NOT NULL AND "image_id" <> '00000000-0000-0000-0000-000000000000'
+UNION SELECT "image_id", "user_id" FROM "friendlist" WHERE "image_id" IS NOT NULL AND "image_id" <> '00000000-0000-0000-0000-000000000000'
+UNION SELECT "image_id", "user_id" FROM "friend" WHERE "image_id" IS NOT NULL AND "image_id" <> '00000000-0000-0000-0000-000000000000'
+UNION SELECT "image_id", "user_id" FROM "photo"
+ON CONFLICT ("id") DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS "friendlist_share" (
+    "id" UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    "friendlist_id" UUID REFERENCES "friendlist" ("id") ON DELETE CASCADE not null,
+    "user_id" UUID REFERENCES "user" ("id") ON DELETE CASCADE not null,
+    "created_at" timestamp with time zone DEFAULT now() not null,
+    UNIQUE ("friendlist_id", "user_id")
+);
+
+CREATE INDEX IF NOT EXISTS "friendlist_share_user_id_idx" ON "friendlist_share" ("user_id");
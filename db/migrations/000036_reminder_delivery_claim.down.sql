@@ -0,0 +1,3 @@
+ALTER TABLE IF EXISTS "reminder_delivery"
+    DROP COLUMN IF EXISTS "claimed_until",
+    DROP COLUMN IF EXISTS "attempts";
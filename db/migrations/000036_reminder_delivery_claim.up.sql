@@ -0,0 +1,3 @@
+ALTER TABLE IF EXISTS "reminder_delivery"
+    ADD COLUMN IF NOT EXISTS "attempts" integer DEFAULT 0 not null,
+    ADD COLUMN IF NOT EXISTS "claimed_until" timestamp with time zone;
@@ -0,0 +1,12 @@
+DROP INDEX IF EXISTS "interaction_trgm_idx";
+DROP INDEX IF EXISTS "event_trgm_idx";
+DROP INDEX IF EXISTS "contact_point_trgm_idx";
+DROP INDEX IF EXISTS "contact_point_search_idx";
+DROP INDEX IF EXISTS "additional_info_field_text_trgm_idx";
+DROP INDEX IF EXISTS "additional_info_field_text_search_idx";
+DROP INDEX IF EXISTS "tag_trgm_idx";
+DROP INDEX IF EXISTS "tag_search_idx";
+DROP INDEX IF EXISTS "work_info_trgm_idx";
+DROP INDEX IF EXISTS "work_info_search_idx";
+DROP INDEX IF EXISTS "friend_trgm_idx";
+DROP INDEX IF EXISTS "friend_search_idx";
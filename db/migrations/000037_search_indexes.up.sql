@@ -0,0 +1,20 @@
+-- every source of the search has a lexeme and a trigram index on the same
+-- expression as in the search query
+CREATE INDEX IF NOT EXISTS "friend_search_idx" ON "friend" USING GIN (search_vector("first_name" || ' ' || COALESCE("last_name", '')));
+CREATE INDEX IF NOT EXISTS "friend_trgm_idx" ON "friend" USING GIN (("first_name" || ' ' || COALESCE("last_name", '')) gin_trgm_ops);
+
+CREATE INDEX IF NOT EXISTS "work_info_search_idx" ON "work_info" USING GIN (search_vector(COALESCE("company", '') || ' ' || COALESCE("position", '') || ' ' || COALESCE("profession", '') || ' ' || COALESCE("city", '') || ' ' || COALESCE("country", '')));
+CREATE INDEX IF NOT EXISTS "work_info_trgm_idx" ON "work_info" USING GIN ((COALESCE("company", '') || ' ' || COALESCE("position", '') || ' ' || COALESCE("profession", '') || ' ' || COALESCE("city", '') || ' ' || COALESCE("country", '')) gin_trgm_ops);
+
+CREATE INDEX IF NOT EXISTS "tag_search_idx" ON "tag" USING GIN (search_vector("title"));
+CREATE INDEX IF NOT EXISTS "tag_trgm_idx" ON "tag" USING GIN ("title" gin_trgm_ops);
+
+CREATE INDEX IF NOT EXISTS "additional_info_field_text_search_idx" ON "additional_info_field_text" USING GIN (search_vector("content"));
+CREATE INDEX IF NOT EXISTS "additional_info_field_text_trgm_idx" ON "additional_info_field_text" USING GIN ("content" gin_trgm_ops);
+
+CREATE INDEX IF NOT EXISTS "contact_point_search_idx" ON "contact_point" USING GIN (search_vector("label" || ' ' || "value"));
+CREATE INDEX IF NOT EXISTS "contact_point_trgm_idx" ON "contact_point" USING GIN (("label" || ' ' || "value") gin_trgm_ops);
+
+CREATE INDEX IF NOT EXISTS "event_trgm_idx" ON "event" USING GIN ("title" gin_trgm_ops);
+
+CREATE INDEX IF NOT EXISTS "interaction_trgm_idx" ON "interaction" USING GIN ("text" gin_trgm_ops);
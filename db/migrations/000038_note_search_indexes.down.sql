@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS "note_trgm_idx";
+DROP INDEX IF EXISTS "note_search_idx";
@@ -0,0 +1,2 @@
+CREATE INDEX IF NOT EXISTS "note_search_idx" ON "note" USING GIN (search_vector("body"));
+CREATE INDEX IF NOT EXISTS "note_trgm_idx" ON "note" USING GIN ("body" gin_trgm_ops);
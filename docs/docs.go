@@ -15,9 +15,14 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/auth/sign-in": {
-            "post": {
-                "description": "login",
+        "/api/additional-field": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get all custom fields",
                 "consumes": [
                     "application/json"
                 ],
@@ -25,26 +30,15 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
-                ],
-                "summary": "SignIn",
-                "operationId": "login",
-                "parameters": [
-                    {
-                        "description": "credentials",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/internal_handler.signInPayload"
-                        }
-                    }
+                    "additional-field"
                 ],
+                "summary": "Get All Additional Info Fields",
+                "operationId": "get-all-additional-fields",
                 "responses": {
                     "200": {
-                        "description": "token",
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/internal_handler.getAllAdditionalInfoFieldsResponse"
                         }
                     },
                     "400": {
@@ -72,11 +66,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/auth/sign-up": {
+            },
             "post": {
-                "description": "create account",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "create custom field (text, number, date, url, single_select, multi_select)",
                 "consumes": [
                     "application/json"
                 ],
@@ -84,26 +81,26 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "additional-field"
                 ],
-                "summary": "SignUp",
-                "operationId": "create-account",
+                "summary": "Create Additional Info Field",
+                "operationId": "create-additional-field",
                 "parameters": [
                     {
-                        "description": "account info",
+                        "description": "Field info",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.User"
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.AdditionalInfoField"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "integer"
+                            "type": "object"
                         }
                     },
                     "400": {
@@ -133,14 +130,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/event": {
+        "/api/additional-field/{id}": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get all events",
+                "description": "get custom field by id",
                 "consumes": [
                     "application/json"
                 ],
@@ -148,15 +145,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "event"
+                    "additional-field"
+                ],
+                "summary": "Get Additional Info Field By Id",
+                "operationId": "get-additional-field-by-id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Field id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get All Events",
-                "operationId": "get-all-events",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_handler.getAllEventsResponse"
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.AdditionalInfoField"
                         }
                     },
                     "400": {
@@ -185,13 +191,13 @@ const docTemplate = `{
                     }
                 }
             },
-            "post": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "create event",
+                "description": "update title or options of custom field, the type can't be changed",
                 "consumes": [
                     "application/json"
                 ],
@@ -199,26 +205,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "event"
+                    "additional-field"
                 ],
-                "summary": "Create Event",
-                "operationId": "create-event",
+                "summary": "Update Additional Info Field",
+                "operationId": "update-additional-field",
                 "parameters": [
                     {
-                        "description": "Event info with friends and reminders",
+                        "type": "string",
+                        "description": "Field id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Field info",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.EventWithFriendIDsAndReminders"
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.AdditionalInfoFieldUpdate"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/internal_handler.statusResponse"
                         }
                     },
                     "400": {
@@ -246,16 +259,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/event/friend/{friend_id}": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get event by friend id",
+                "description": "delete custom field with its values",
                 "consumes": [
                     "application/json"
                 ],
@@ -263,15 +274,15 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "event"
+                    "additional-field"
                 ],
-                "summary": "Get Event By Friend ID",
-                "operationId": "get-event-by-friend-id",
+                "summary": "Delete Additional Info Field",
+                "operationId": "delete-additional-field",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Friend id",
-                        "name": "friend_id",
+                        "description": "Field id",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -280,7 +291,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_handler.getAllEventsResponse"
+                            "$ref": "#/definitions/internal_handler.statusResponse"
                         }
                     },
                     "400": {
@@ -310,14 +321,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/event/friends": {
+        "/api/attachment": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get all events with friends",
+                "description": "get attachments metadata, the latest first",
                 "consumes": [
                     "application/json"
                 ],
@@ -325,15 +336,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "event"
+                    "attachment"
+                ],
+                "summary": "Get All Attachments",
+                "operationId": "get-all-attachments",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Attachments of friend",
+                        "name": "friend_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Attachments of event",
+                        "name": "event_id",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get All Events With Friends",
-                "operationId": "get-all-events-with-friends",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_handler.getAllEventsWithFriendsResponse"
+                            "$ref": "#/definitions/internal_handler.getAllAttachmentsResponse"
                         }
                     },
                     "400": {
@@ -361,32 +386,51 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/event/full": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get all events full info",
+                "description": "upload document attached to a friend or an event, mime type is detected from the content",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "event"
+                    "attachment"
+                ],
+                "summary": "Upload Attachment",
+                "operationId": "upload-attachment",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "File to upload",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Friend id",
+                        "name": "friend_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event id",
+                        "name": "event_id",
+                        "in": "formData"
+                    }
                 ],
-                "summary": "Get All Events Full Info",
-                "operationId": "get-all-events-full-info",
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/internal_handler.getAllEventsFullInfo"
+                            "type": "object"
                         }
                     },
                     "400": {
@@ -401,6 +445,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/internal_handler.errorResponse"
                         }
                     },
+                    "413": {
+                        "description": "File is too large or storage quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -416,14 +466,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/event/{id}": {
+        "/api/attachment/usage": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get event by id",
+                "description": "get size of stored attachments and storage quota in bytes",
                 "consumes": [
                     "application/json"
                 ],
@@ -431,24 +481,15 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "event"
-                ],
-                "summary": "Get Event By Id",
-                "operationId": "get-event-by-id",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Event id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "attachment"
                 ],
+                "summary": "Get Storage Usage",
+                "operationId": "get-storage-usage",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Event"
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.StorageUsage"
                         }
                     },
                     "400": {
@@ -476,14 +517,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/api/attachment/{id}": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "update event",
+                "description": "get attachment metadata by id",
                 "consumes": [
                     "application/json"
                 ],
@@ -491,33 +534,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "event"
+                    "attachment"
                 ],
-                "summary": "Update Event",
-                "operationId": "update-event",
+                "summary": "Get Attachment By Id",
+                "operationId": "get-attachment-by-id",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Event id",
+                        "description": "Attachment id",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Event info with reminders",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.EventFullUpdate"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_handler.statusResponse"
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Attachment"
                         }
                     },
                     "400": {
@@ -552,7 +586,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "delete event",
+                "description": "delete attachment with its file",
                 "consumes": [
                     "application/json"
                 ],
@@ -560,14 +594,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "event"
+                    "attachment"
                 ],
-                "summary": "Delete Event",
-                "operationId": "delete-event",
+                "summary": "Delete Attachment",
+                "operationId": "delete-attachment",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Event id",
+                        "description": "Attachment id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -607,51 +641,39 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/event/{id}/friends": {
-            "post": {
+        "/api/attachment/{id}/download": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "add friend to event",
+                "description": "download attachment file",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/octet-stream"
                 ],
                 "tags": [
-                    "event"
+                    "attachment"
                 ],
-                "summary": "Add Friends To Event",
-                "operationId": "add-friends-to-event",
+                "summary": "Download Attachment",
+                "operationId": "download-attachment",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Event id",
+                        "description": "Attachment id",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Friends id",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.FriendID"
-                            }
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object"
+                            "type": "file"
                         }
                     },
                     "400": {
@@ -681,14 +703,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/event/{id}/full": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "get event by id with friends and reminders",
+        "/api/auth/sign-in": {
+            "post": {
+                "description": "login",
                 "consumes": [
                     "application/json"
                 ],
@@ -696,24 +713,26 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "event"
+                    "auth"
                 ],
-                "summary": "Get Event By Id With Friends And Reminders",
-                "operationId": "get-event-by-id-with-friends-and-reminders",
+                "summary": "SignIn",
+                "operationId": "login",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Event id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "credentials",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.signInPayload"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "token",
                         "schema": {
-                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.EventWithFriendsAndReminders"
+                            "type": "string"
                         }
                     },
                     "400": {
@@ -743,14 +762,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/friend": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "get all friends",
+        "/api/auth/sign-up": {
+            "post": {
+                "description": "create account",
                 "consumes": [
                     "application/json"
                 ],
@@ -758,15 +772,26 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "friend"
+                    "auth"
+                ],
+                "summary": "SignUp",
+                "operationId": "create-account",
+                "parameters": [
+                    {
+                        "description": "account info",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.User"
+                        }
+                    }
                 ],
-                "summary": "Get All Friends",
-                "operationId": "get-all-friends",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_handler.getAllFriendsResponse"
+                            "type": "integer"
                         }
                     },
                     "400": {
@@ -794,14 +819,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/api/calendar": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "create friend",
+                "description": "get event, birthday and important date occurrences within a date range",
                 "consumes": [
                     "application/json"
                 ],
@@ -809,26 +836,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "friend"
+                    "calendar"
                 ],
-                "summary": "Create Friend",
-                "operationId": "create-friend",
+                "summary": "Get Calendar",
+                "operationId": "get-calendar",
                 "parameters": [
                     {
-                        "description": "Friend info",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.UpdateFriendWorkInfoTagsInput"
-                        }
+                        "type": "string",
+                        "description": "Range start, RFC3339 or YYYY-MM-DD (default now)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Range end, RFC3339 or YYYY-MM-DD (default from + 30 days)",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "object"
+                            "$ref": "#/definitions/internal_handler.getCalendarResponse"
                         }
                     },
                     "400": {
@@ -858,14 +888,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/friend/{id}": {
+        "/api/event": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get friend by id",
+                "description": "get page of events, filtered and sorted",
                 "consumes": [
                     "application/json"
                 ],
@@ -873,24 +903,71 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "friend"
+                    "event"
                 ],
-                "summary": "Get Friend By ID",
-                "operationId": "get-friend-by-id",
+                "summary": "Get All Events",
+                "operationId": "get-all-events",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Friend id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Events with friend",
+                        "name": "friend_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Active or inactive events",
+                        "name": "is_active",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Events with frequency",
+                        "name": "frequency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date not before, RFC3339 or YYYY-MM-DD",
+                        "name": "start_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date not after, RFC3339 or YYYY-MM-DD",
+                        "name": "start_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 1-200 (default 50)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort key: start_date (default), title",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "asc (default) or desc",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.FriendWorkInfoTags"
+                            "$ref": "#/definitions/internal_handler.getAllEventsResponse"
                         }
                     },
                     "400": {
@@ -919,13 +996,13 @@ const docTemplate = `{
                     }
                 }
             },
-            "put": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "update friend",
+                "description": "create event",
                 "consumes": [
                     "application/json"
                 ],
@@ -933,33 +1010,26 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "friend"
+                    "event"
                 ],
-                "summary": "Update Friend",
-                "operationId": "update-friend",
+                "summary": "Create Event",
+                "operationId": "create-event",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Friend id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Friend info",
+                        "description": "Event info with friends and reminders",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.UpdateFriendWorkInfoInput"
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.EventWithFriendIDsAndReminders"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/internal_handler.statusResponse"
+                            "type": "string"
                         }
                     },
                     "400": {
@@ -987,14 +1057,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/event/friend/{friend_id}": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "delete friend",
+                "description": "get event by friend id",
                 "consumes": [
                     "application/json"
                 ],
@@ -1002,15 +1074,15 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "friend"
+                    "event"
                 ],
-                "summary": "Delete Friend",
-                "operationId": "delete-friend",
+                "summary": "Get Event By Friend ID",
+                "operationId": "get-event-by-friend-id",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "Friend id",
-                        "name": "id",
+                        "name": "friend_id",
                         "in": "path",
                         "required": true
                     }
@@ -1019,7 +1091,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_handler.statusResponse"
+                            "$ref": "#/definitions/internal_handler.getAllEventsResponse"
                         }
                     },
                     "400": {
@@ -1049,14 +1121,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/friend/{id}/tag": {
-            "post": {
+        "/api/event/friends": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "add tag to friend",
+                "description": "get all events with friends",
                 "consumes": [
                     "application/json"
                 ],
@@ -1064,33 +1136,15 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "friend"
-                ],
-                "summary": "Add Tag To Friend",
-                "operationId": "add-tag-to-friend",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Friend id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Add Tag",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.AdditionTag"
-                        }
-                    }
+                    "event"
                 ],
+                "summary": "Get All Events With Friends",
+                "operationId": "get-all-events-with-friends",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_handler.statusResponse"
+                            "$ref": "#/definitions/internal_handler.getAllEventsWithFriendsResponse"
                         }
                     },
                     "400": {
@@ -1120,14 +1174,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/friend/{id}/tag/{tag_id}": {
-            "delete": {
+        "/api/event/full": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "delete tag from friend",
+                "description": "get all events full info",
                 "consumes": [
                     "application/json"
                 ],
@@ -1135,31 +1189,15 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "friend"
-                ],
-                "summary": "Delete Tag From Friend",
-                "operationId": "delete-tag-from-friend",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Friend id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Tag id",
-                        "name": "tag_id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "event"
                 ],
+                "summary": "Get All Events Full Info",
+                "operationId": "get-all-events-full-info",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_handler.statusResponse"
+                            "$ref": "#/definitions/internal_handler.getAllEventsFullInfo"
                         }
                     },
                     "400": {
@@ -1189,14 +1227,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/friendlist": {
+        "/api/event/{id}": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get all friendlists",
+                "description": "get event by id",
                 "consumes": [
                     "application/json"
                 ],
@@ -1204,15 +1242,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "friendlist"
+                    "event"
+                ],
+                "summary": "Get Event By Id",
+                "operationId": "get-event-by-id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get All Friendlists",
-                "operationId": "get-all-friendlists",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_handler.getAllFriendlistsResponse"
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Event"
                         }
                     },
                     "400": {
@@ -1241,13 +1288,13 @@ const docTemplate = `{
                     }
                 }
             },
-            "post": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "create friendlist",
+                "description": "update event",
                 "consumes": [
                     "application/json"
                 ],
@@ -1255,26 +1302,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "friendlist"
+                    "event"
                 ],
-                "summary": "Create Friendlist",
-                "operationId": "create-friendlist",
+                "summary": "Update Event",
+                "operationId": "update-event",
                 "parameters": [
                     {
-                        "description": "Friendlist info",
+                        "type": "string",
+                        "description": "Event id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Event info with reminders",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.FriendlistWithTagsUpdate"
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.EventFullUpdate"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/internal_handler.statusResponse"
                         }
                     },
                     "400": {
@@ -1302,16 +1356,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/friendlist/friend": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get all friendlists with friends",
+                "description": "move event to trash, it can be restored until purged",
                 "consumes": [
                     "application/json"
                 ],
@@ -1319,15 +1371,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "friendlist"
+                    "event"
+                ],
+                "summary": "Delete Event",
+                "operationId": "delete-event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get All Friendlists With Friends",
-                "operationId": "get-all-friendlists-with-friends",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_handler.getAllFriendlistsWithFriendsResponse"
+                            "$ref": "#/definitions/internal_handler.statusResponse"
                         }
                     },
                     "400": {
@@ -1357,14 +1418,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/friendlist/full": {
-            "get": {
+        "/api/event/{id}/friends": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get all friendlists full",
+                "description": "add friend to event",
                 "consumes": [
                     "application/json"
                 ],
@@ -1372,15 +1433,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "friendlist"
+                    "event"
+                ],
+                "summary": "Add Friends To Event",
+                "operationId": "add-friends-to-event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Friends id",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.FriendID"
+                            }
+                        }
+                    }
                 ],
-                "summary": "Get All Friendlists Full",
-                "operationId": "get-all-friendlists-full",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_handler.getAllFriendlistsFullResponse"
+                            "type": "object"
                         }
                     },
                     "400": {
@@ -1410,14 +1492,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/friendlist/tag": {
+        "/api/event/{id}/full": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get all friendlists with tags",
+                "description": "get event by id with friends and reminders",
                 "consumes": [
                     "application/json"
                 ],
@@ -1425,15 +1507,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "friendlist"
+                    "event"
+                ],
+                "summary": "Get Event By Id With Friends And Reminders",
+                "operationId": "get-event-by-id-with-friends-and-reminders",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get All Friendlists With Tags",
-                "operationId": "get-all-friendlists-with-tags",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_handler.getAllFriendlistsResponse"
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.EventWithFriendsAndReminders"
                         }
                     },
                     "400": {
@@ -1463,14 +1554,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/friendlist/{id}": {
+        "/api/event/{id}/photos": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get friendlist by id",
+                "description": "get photos of friends attached to the event, in the order they were taken",
                 "consumes": [
                     "application/json"
                 ],
@@ -1478,14 +1569,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "friendlist"
+                    "event"
                 ],
-                "summary": "Get Friendlist By Id",
-                "operationId": "get-friendlist-by-id",
+                "summary": "Get Event Photos",
+                "operationId": "get-event-photos",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Friendlist id",
+                        "description": "Event id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1495,7 +1586,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Friendlist"
+                            "$ref": "#/definitions/internal_handler.getAllPhotosResponse"
                         }
                     },
                     "400": {
@@ -1523,14 +1614,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/api/friend": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "update friendlist",
+                "description": "get page of friends, filtered and sorted",
                 "consumes": [
                     "application/json"
                 ],
@@ -1538,33 +1631,83 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "friendlist"
+                    "friend"
                 ],
-                "summary": "Update Friendlist",
-                "operationId": "update-friendlist",
+                "summary": "Get All Friends",
+                "operationId": "get-all-friends",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Friendlist id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Friends with tag",
+                        "name": "tag_id",
+                        "in": "query"
                     },
                     {
-                        "description": "Friendlist info",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.UpdateFriendlist"
-                        }
+                        "type": "string",
+                        "description": "Friends of friendlist",
+                        "name": "friendlist_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "City of work info, case insensitive",
+                        "name": "city",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Company of work info, case insensitive",
+                        "name": "company",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Month of birth, 1-12",
+                        "name": "dob_month",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last contacted not before, RFC3339 or YYYY-MM-DD",
+                        "name": "contacted_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last contacted not after, RFC3339 or YYYY-MM-DD",
+                        "name": "contacted_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 1-200 (default 50)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort key: first_name (default), last_name, dob, last_contacted_at",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "asc (default) or desc",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_handler.statusResponse"
+                            "$ref": "#/definitions/internal_handler.getAllFriendsResponse"
                         }
                     },
                     "400": {
@@ -1593,13 +1736,13 @@ const docTemplate = `{
                     }
                 }
             },
-            "delete": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "delete friendlist",
+                "description": "create friend",
                 "consumes": [
                     "application/json"
                 ],
@@ -1607,24 +1750,26 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "friendlist"
+                    "friend"
                 ],
-                "summary": "Delete Friendlist",
-                "operationId": "delete-friendlist",
+                "summary": "Create Friend",
+                "operationId": "create-friend",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Friendlist id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Friend info",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.UpdateFriendWorkInfoTagsInput"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/internal_handler.statusResponse"
+                            "type": "object"
                         }
                     },
                     "400": {
@@ -1654,14 +1799,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/friendlist/{id}/friend": {
+        "/api/friend/duplicates": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get friendlist by id with friends",
+                "description": "get pairs of friends which look like the same person, scored by name, dob, shared contact points and company",
                 "consumes": [
                     "application/json"
                 ],
@@ -1669,24 +1814,15 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "friendlist"
-                ],
-                "summary": "Get Friendlist By ID With Friends",
-                "operationId": "get-friendlist-by-id-with-friends",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Friendlist id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "friend"
                 ],
+                "summary": "Get Duplicate Friends",
+                "operationId": "get-duplicate-friends",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.FriendlistWithFriends"
+                            "$ref": "#/definitions/internal_handler.getDuplicateFriendsResponse"
                         }
                     },
                     "400": {
@@ -1714,14 +1850,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/api/friend/graph": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "add friend to friendlist",
+                "description": "get friends as nodes and relationships as edges for visualization",
                 "consumes": [
                     "application/json"
                 ],
@@ -1729,33 +1867,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "friendlist"
+                    "friend"
                 ],
-                "summary": "Add Friend To Friendlist",
-                "operationId": "add-friend-to-friendlist",
+                "summary": "Get Friends Graph",
+                "operationId": "get-friends-graph",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Friendlist id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Keep friends of friendlist",
+                        "name": "friendlist_id",
+                        "in": "query"
                     },
                     {
-                        "description": "Add Friend",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.AdditionFriendToFriendlist"
-                        }
+                        "type": "string",
+                        "description": "Keep friends with tag",
+                        "name": "tag_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_handler.statusResponse"
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Graph"
                         }
                     },
                     "400": {
@@ -1785,14 +1919,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/friendlist/{id}/friend/{friend_id}": {
-            "delete": {
+        "/api/friend/merge": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "delete friend from friendlist",
+                "description": "merge source friend into target and delete source, choices pick source values for conflicting fields",
                 "consumes": [
                     "application/json"
                 ],
@@ -1800,24 +1934,19 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "friendlist"
+                    "friend"
                 ],
-                "summary": "Delete Friend From Friendlist",
-                "operationId": "delete-friend-from-friendlist",
+                "summary": "Merge Friends",
+                "operationId": "merge-friends",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Friendlist id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Friend id",
-                        "name": "friend_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Friends to merge",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.FriendMerge"
+                        }
                     }
                 ],
                 "responses": {
@@ -1854,14 +1983,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/friendlist/{id}/full": {
+        "/api/friend/overdue": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get friendlist full by id",
+                "description": "get friends whose keep-in-touch cadence has elapsed, the latest first",
                 "consumes": [
                     "application/json"
                 ],
@@ -1869,24 +1998,15 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "friendlist"
-                ],
-                "summary": "Get Friendlist Full By ID",
-                "operationId": "get-friendlist-full-by-id",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Friendlist id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "friend"
                 ],
+                "summary": "Get Overdue Friends",
+                "operationId": "get-overdue-friends",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.FriendlistFull"
+                            "$ref": "#/definitions/internal_handler.getOverdueFriendsResponse"
                         }
                     },
                     "400": {
@@ -1916,14 +2036,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/friendlist/{id}/tag": {
+        "/api/friend/{id}": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get friendlist by id with tags",
+                "description": "get friend by id",
                 "consumes": [
                     "application/json"
                 ],
@@ -1931,14 +2051,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "friendlist"
+                    "friend"
                 ],
-                "summary": "Get Friendlist By ID With Tags",
-                "operationId": "get-friendlist-by-id-with-tags",
+                "summary": "Get Friend By ID",
+                "operationId": "get-friend-by-id",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Friendlist id",
+                        "description": "Friend id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1948,7 +2068,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.FriendlistWithTags"
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.FriendWorkInfoTags"
                         }
                     },
                     "400": {
@@ -1977,13 +2097,13 @@ const docTemplate = `{
                     }
                 }
             },
-            "post": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "add tag to friendlist",
+                "description": "update friend",
                 "consumes": [
                     "application/json"
                 ],
@@ -1991,25 +2111,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "friendlist"
+                    "friend"
                 ],
-                "summary": "Add Tag To Friendlist",
-                "operationId": "add-tag-to-friendlist",
+                "summary": "Update Friend",
+                "operationId": "update-friend",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Friendlist id",
+                        "description": "Friend id",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Add Tag",
+                        "description": "Friend info",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.AdditionTag"
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.UpdateFriendWorkInfoInput"
                         }
                     }
                 ],
@@ -2045,16 +2165,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/friendlist/{id}/tag/{tag_id}": {
+            },
             "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "delete tag from friendlist",
+                "description": "move friend to trash, it can be restored until purged",
                 "consumes": [
                     "application/json"
                 ],
@@ -2062,24 +2180,88 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "friendlist"
+                    "friend"
                 ],
-                "summary": "Delete Tag From Friendlist",
-                "operationId": "delete-tag-from-friendlist",
+                "summary": "Delete Friend",
+                "operationId": "delete-friend",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Friendlist id",
+                        "description": "Friend id",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.statusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
                     },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/friend/{id}/contacted": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "mark friend as contacted, now when time is not set",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friend"
+                ],
+                "summary": "Mark Friend Contacted",
+                "operationId": "mark-friend-contacted",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "Tag id",
-                        "name": "tag_id",
+                        "description": "Friend id",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Contact time",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.FriendContacted"
+                        }
                     }
                 ],
                 "responses": {
@@ -2116,40 +2298,39 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/image": {
-            "post": {
+        "/api/friend/{id}/contacts": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "upload image",
+                "description": "get all contact points of friend",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "image"
+                    "friend"
                 ],
-                "summary": "Upload Image",
-                "operationId": "upload-image",
+                "summary": "Get All Contact Points",
+                "operationId": "get-all-contact-points",
                 "parameters": [
                     {
-                        "type": "file",
-                        "description": "Image file to upload",
-                        "name": "image",
-                        "in": "formData",
+                        "type": "string",
+                        "description": "Friend id",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Successfully uploaded image",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/internal_handler.getAllContactPointsResponse"
                         }
                     },
                     "400": {
@@ -2158,14 +2339,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/internal_handler.errorResponse"
                         }
                     },
-                    "415": {
-                        "description": "Invalid file type. Only JPG, PNG, HEIC files are allowed.",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/internal_handler.errorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/internal_handler.errorResponse"
                         }
@@ -2177,16 +2358,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/image/{id}": {
-            "delete": {
+            },
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "delete image by ID",
+                "description": "create contact point of friend (phone, email, telegram, whatsapp, signal, social, address), the first one of a kind becomes primary",
                 "consumes": [
                     "application/json"
                 ],
@@ -2194,37 +2373,49 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "image"
+                    "friend"
                 ],
-                "summary": "Delete Image",
-                "operationId": "delete-image",
+                "summary": "Create Contact Point",
+                "operationId": "create-contact-point",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Image ID",
+                        "description": "Friend id",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Contact point info",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.ContactPoint"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Image deleted successfully",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
                         }
                     },
                     "404": {
-                        "description": "Image not found",
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/internal_handler.errorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/internal_handler.errorResponse"
                         }
@@ -2238,38 +2429,37 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/image/{id}/{res}": {
+        "/api/friend/{id}/contacts/{contact_id}": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get image by ID and resolution",
+                "description": "get contact point of friend by id",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "image/jpeg"
+                    "application/json"
                 ],
                 "tags": [
-                    "image"
+                    "friend"
                 ],
-                "summary": "Get Image",
-                "operationId": "get-image",
+                "summary": "Get Contact Point By Id",
+                "operationId": "get-contact-point-by-id",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Image ID",
+                        "description": "Friend id",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "format": "int64",
-                        "description": "Resolution",
-                        "name": "res",
+                        "type": "string",
+                        "description": "Contact point id",
+                        "name": "contact_id",
                         "in": "path",
                         "required": true
                     }
@@ -2278,7 +2468,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.ContactPoint"
                         }
                     },
                     "400": {
@@ -2288,13 +2478,13 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Image not found",
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/internal_handler.errorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/internal_handler.errorResponse"
                         }
@@ -2306,16 +2496,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/profile": {
+            },
             "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "update user profile",
+                "description": "update contact point of friend, making it primary resets the other primary of the kind",
                 "consumes": [
                     "application/json"
                 ],
@@ -2323,42 +2511,56 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "profile"
+                    "friend"
                 ],
-                "summary": "Update Profile",
-                "operationId": "update-profile",
+                "summary": "Update Contact Point",
+                "operationId": "update-contact-point",
                 "parameters": [
                     {
-                        "description": "User update payload",
+                        "type": "string",
+                        "description": "Friend id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Contact point id",
+                        "name": "contact_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Contact point info",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.UserUpdate"
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.ContactPointUpdate"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully updated profile",
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/internal_handler.statusResponse"
                         }
                     },
                     "400": {
-                        "description": "Bad request",
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/internal_handler.errorResponse"
                         }
                     },
                     "404": {
-                        "description": "User not found",
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/internal_handler.errorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/internal_handler.errorResponse"
                         }
@@ -2370,16 +2572,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/profile/": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get profile",
+                "description": "delete contact point of friend",
                 "consumes": [
                     "application/json"
                 ],
@@ -2387,15 +2587,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "profile"
+                    "friend"
+                ],
+                "summary": "Delete Contact Point",
+                "operationId": "delete-contact-point",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Friend id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Contact point id",
+                        "name": "contact_id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get Profile",
-                "operationId": "get-profile",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.User"
+                            "$ref": "#/definitions/internal_handler.statusResponse"
                         }
                     },
                     "400": {
@@ -2425,14 +2641,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/reminder": {
+        "/api/friend/{id}/dates": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get all reminders",
+                "description": "get all important dates of friend",
                 "consumes": [
                     "application/json"
                 ],
@@ -2440,15 +2656,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "reminder"
+                    "friend"
+                ],
+                "summary": "Get All Friend Dates",
+                "operationId": "get-all-friend-dates",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Friend id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get All Reminders",
-                "operationId": "get-all-reminders",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_handler.getAllRemindersResponse"
+                            "$ref": "#/definitions/internal_handler.getAllFriendDatesResponse"
                         }
                     },
                     "400": {
@@ -2483,7 +2708,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "create reminder",
+                "description": "create important date of friend (anniversary, name day, etc.)",
                 "consumes": [
                     "application/json"
                 ],
@@ -2491,18 +2716,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "reminder"
+                    "friend"
                 ],
-                "summary": "Create Reminder",
-                "operationId": "create-reminder",
+                "summary": "Create Friend Date",
+                "operationId": "create-friend-date",
                 "parameters": [
                     {
-                        "description": "Reminder info",
+                        "type": "string",
+                        "description": "Friend id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Date info",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Reminder"
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.FriendDate"
                         }
                     }
                 ],
@@ -2510,7 +2742,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "type": "string"
+                            "type": "object"
                         }
                     },
                     "400": {
@@ -2540,14 +2772,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/reminder/event/{event_id}": {
+        "/api/friend/{id}/dates/{date_id}": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get all reminders by event id",
+                "description": "get important date of friend by id",
                 "consumes": [
                     "application/json"
                 ],
@@ -2555,15 +2787,22 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "reminder"
+                    "friend"
                 ],
-                "summary": "Get All Reminders By Event Id",
-                "operationId": "get-all-reminders-by-event-id",
+                "summary": "Get Friend Date By Id",
+                "operationId": "get-friend-date-by-id",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Event id",
-                        "name": "event_id",
+                        "description": "Friend id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Date id",
+                        "name": "date_id",
                         "in": "path",
                         "required": true
                     }
@@ -2572,7 +2811,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_handler.getAllRemindersResponse"
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.FriendDate"
                         }
                     },
                     "400": {
@@ -2600,16 +2839,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/reminder/{id}": {
-            "get": {
+            },
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get reminder by id",
+                "description": "update important date of friend, year 0 removes the year",
                 "consumes": [
                     "application/json"
                 ],
@@ -2617,24 +2854,40 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "reminder"
+                    "friend"
                 ],
-                "summary": "Get Reminder By Id",
-                "operationId": "get-reminder-by-id",
+                "summary": "Update Friend Date",
+                "operationId": "update-friend-date",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Reminder id",
+                        "description": "Friend id",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Date id",
+                        "name": "date_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Date info",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.FriendDateUpdate"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Reminder"
+                            "$ref": "#/definitions/internal_handler.statusResponse"
                         }
                     },
                     "400": {
@@ -2663,13 +2916,13 @@ const docTemplate = `{
                     }
                 }
             },
-            "put": {
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "update reminder",
+                "description": "delete important date of friend",
                 "consumes": [
                     "application/json"
                 ],
@@ -2677,17 +2930,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "reminder"
+                    "friend"
                 ],
-                "summary": "Update Reminder",
-                "operationId": "update-reminder",
+                "summary": "Delete Friend Date",
+                "operationId": "delete-friend-date",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Reminder id",
+                        "description": "Friend id",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Date id",
+                        "name": "date_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -2722,14 +2982,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/friend/{id}/employment": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "delete reminder",
+                "description": "get employment history of friend, the latest first",
                 "consumes": [
                     "application/json"
                 ],
@@ -2737,14 +2999,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "reminder"
+                    "friend"
                 ],
-                "summary": "Delete Reminder",
-                "operationId": "delete-reminder",
+                "summary": "Get All Employments",
+                "operationId": "get-all-employments",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Reminder id",
+                        "description": "Friend id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2754,7 +3016,76 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_handler.statusResponse"
+                            "$ref": "#/definitions/internal_handler.getAllEmploymentsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "create employment record of friend, the current one is shown in work_info",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friend"
+                ],
+                "summary": "Create Employment",
+                "operationId": "create-employment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Friend id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Employment info",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Employment"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object"
                         }
                     },
                     "400": {
@@ -2784,14 +3115,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/tag": {
+        "/api/friend/{id}/employment/{employment_id}": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get all tags",
+                "description": "get employment record of friend by id",
                 "consumes": [
                     "application/json"
                 ],
@@ -2799,15 +3130,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tag"
+                    "friend"
+                ],
+                "summary": "Get Employment By Id",
+                "operationId": "get-employment-by-id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Friend id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Employment id",
+                        "name": "employment_id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get All Tags",
-                "operationId": "get-all-tags",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_handler.getAllTagsResponse"
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Employment"
                         }
                     },
                     "400": {
@@ -2836,13 +3183,13 @@ const docTemplate = `{
                     }
                 }
             },
-            "post": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "create tag",
+                "description": "update employment record of friend, current true removes the end date",
                 "consumes": [
                     "application/json"
                 ],
@@ -2850,26 +3197,40 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tag"
+                    "friend"
                 ],
-                "summary": "Create Tag",
-                "operationId": "create-tag",
+                "summary": "Update Employment",
+                "operationId": "update-employment",
                 "parameters": [
                     {
-                        "description": "Tag info",
+                        "type": "string",
+                        "description": "Friend id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Employment id",
+                        "name": "employment_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Employment info",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Tag"
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.EmploymentUpdate"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/internal_handler.statusResponse"
                         }
                     },
                     "400": {
@@ -2897,16 +3258,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/tag/{id}": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get tag by id",
+                "description": "delete employment record of friend",
                 "consumes": [
                     "application/json"
                 ],
@@ -2914,24 +3273,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tag"
+                    "friend"
                 ],
-                "summary": "Get Tag By Id",
-                "operationId": "get-tag-by-id",
+                "summary": "Delete Employment",
+                "operationId": "delete-employment",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Tag id",
+                        "description": "Friend id",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Employment id",
+                        "name": "employment_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Tag"
+                            "$ref": "#/definitions/internal_handler.statusResponse"
                         }
                     },
                     "400": {
@@ -2959,14 +3325,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/api/friend/{id}/field": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "update tag",
+                "description": "get custom fields of friend with values",
                 "consumes": [
                     "application/json"
                 ],
@@ -2974,33 +3342,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tag"
+                    "friend"
                 ],
-                "summary": "Update Tag",
-                "operationId": "update-tag",
+                "summary": "Get Friend Additional Info Fields",
+                "operationId": "get-friend-additional-fields",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Tag id",
+                        "description": "Friend id",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Tag info",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Tag"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_handler.statusResponse"
+                            "$ref": "#/definitions/internal_handler.getFriendAdditionalInfoFieldsResponse"
                         }
                     },
                     "400": {
@@ -3028,14 +3387,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/friend/{id}/field/{field_id}": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "delete tag",
+                "description": "set value of custom field for friend, null clears the value",
                 "consumes": [
                     "application/json"
                 ],
@@ -3043,17 +3404,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tag"
+                    "friend"
                 ],
-                "summary": "Delete Tag",
-                "operationId": "delete-tag",
+                "summary": "Set Friend Additional Info Field Value",
+                "operationId": "set-friend-additional-field",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Tag id",
+                        "description": "Friend id",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field id",
+                        "name": "field_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Value",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.AdditionalInfoFieldValueInput"
+                        }
                     }
                 ],
                 "responses": {
@@ -3088,57 +3465,6623 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        }
-    },
-    "definitions": {
-        "github_com_lunovoy_friendly_internal_models.AdditionFriendToFriendlist": {
-            "type": "object",
-            "required": [
-                "friend_id"
-            ],
-            "properties": {
-                "friend_id": {
-                    "type": "string"
-                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "detach custom field from friend with its value",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friend"
+                ],
+                "summary": "Delete Additional Info Field From Friend",
+                "operationId": "delete-friend-additional-field",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Friend id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field id",
+                        "name": "field_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.statusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/friend/{id}/gifts": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get all gift ideas for friend, the latest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friend"
+                ],
+                "summary": "Get All Gift Ideas",
+                "operationId": "get-all-gift-ideas",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Friend id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.getAllGiftIdeasResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "create gift idea for friend, optionally for a birthday or an event occurrence",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friend"
+                ],
+                "summary": "Create Gift Idea",
+                "operationId": "create-gift-idea",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Friend id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Gift idea info",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.GiftIdea"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/friend/{id}/gifts/{gift_id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get gift idea for friend by id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friend"
+                ],
+                "summary": "Get Gift Idea By Id",
+                "operationId": "get-gift-idea-by-id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Friend id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Gift idea id",
+                        "name": "gift_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.GiftIdea"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "update gift idea for friend, negative price removes the price, empty occasion removes the occasion",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friend"
+                ],
+                "summary": "Update Gift Idea",
+                "operationId": "update-gift-idea",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Friend id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Gift idea id",
+                        "name": "gift_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Gift idea info",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.GiftIdeaUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.statusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "delete gift idea for friend",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friend"
+                ],
+                "summary": "Delete Gift Idea",
+                "operationId": "delete-gift-idea",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Friend id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Gift idea id",
+                        "name": "gift_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.statusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/friend/{id}/history": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get changes of friend profile, work info, tags and custom fields with their author, the latest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friend"
+                ],
+                "summary": "Get Friend History",
+                "operationId": "get-friend-history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Friend id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.getFriendHistoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/friend/{id}/history/revert": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "bring friend profile, work info, tags and custom fields back to the version at the given time",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friend"
+                ],
+                "summary": "Revert Friend",
+                "operationId": "revert-friend",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Friend id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Version time",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.FriendRevert"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.statusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/friend/{id}/history/version": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get friend as it was at the given time",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friend"
+                ],
+                "summary": "Get Friend Version",
+                "operationId": "get-friend-version",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Friend id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Point in time, RFC3339 or YYYY-MM-DD",
+                        "name": "at",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.FriendWorkInfoTags"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/friend/{id}/interactions": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get all interactions with friend, the latest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friend"
+                ],
+                "summary": "Get All Interactions",
+                "operationId": "get-all-interactions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Friend id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.getAllInteractionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "log interaction with friend (call, message, meeting, note)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friend"
+                ],
+                "summary": "Create Interaction",
+                "operationId": "create-interaction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Friend id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Interaction info",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Interaction"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/friend/{id}/interactions/{interaction_id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get interaction with friend by id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friend"
+                ],
+                "summary": "Get Interaction By Id",
+                "operationId": "get-interaction-by-id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Friend id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Interaction id",
+                        "name": "interaction_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Interaction"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "update interaction with friend",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friend"
+                ],
+                "summary": "Update Interaction",
+                "operationId": "update-interaction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Friend id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Interaction id",
+                        "name": "interaction_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Interaction info",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.InteractionUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.statusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "delete interaction with friend",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friend"
+                ],
+                "summary": "Delete Interaction",
+                "operationId": "delete-interaction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Friend id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Interaction id",
+                        "name": "interaction_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.statusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/friend/{id}/photos": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get friend gallery in the set order, the avatar is marked with is_avatar",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friend"
+                ],
+                "summary": "Get All Photos",
+                "operationId": "get-all-photos",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Friend id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.getAllPhotosResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "add image uploaded with /api/image to friend gallery, taken_at is read from EXIF when not set",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friend"
+                ],
+                "summary": "Create Photo",
+                "operationId": "create-photo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Friend id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Photo info",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Photo"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/friend/{id}/photos/order": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "set order of friend gallery, all photos must be listed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friend"
+                ],
+                "summary": "Reorder Photos",
+                "operationId": "reorder-photos",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Friend id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Photo ids in the new order",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.PhotoOrder"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.statusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/friend/{id}/photos/{photo_id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "update photo caption, date or event",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friend"
+                ],
+                "summary": "Update Photo",
+                "operationId": "update-photo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Friend id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Photo id",
+                        "name": "photo_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Photo info",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.PhotoUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.statusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "remove photo from friend gallery, removing the avatar clears friend image, the image is deleted once nothing references it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friend"
+                ],
+                "summary": "Delete Photo",
+                "operationId": "delete-photo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Friend id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Photo id",
+                        "name": "photo_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.statusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/friend/{id}/photos/{photo_id}/avatar": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "make photo the friend avatar, friend image is set to the photo image",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friend"
+                ],
+                "summary": "Set Avatar Photo",
+                "operationId": "set-avatar-photo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Friend id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Photo id",
+                        "name": "photo_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.statusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/friend/{id}/relationships": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get all relationships of friend from both sides",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friend"
+                ],
+                "summary": "Get All Relationships",
+                "operationId": "get-all-relationships",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Friend id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.getAllRelationshipsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "create relationship of friend with another friend (spouse, parent, child, sibling, colleague, introduced_by), directed kinds read as \"friend is kind of related friend\"",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friend"
+                ],
+                "summary": "Create Relationship",
+                "operationId": "create-relationship",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Friend id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Relationship info",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Relationship"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/friend/{id}/relationships/{relationship_id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get relationship of friend by id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friend"
+                ],
+                "summary": "Get Relationship By Id",
+                "operationId": "get-relationship-by-id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Friend id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Relationship id",
+                        "name": "relationship_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Relationship"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "update kind of relationship as seen from friend",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friend"
+                ],
+                "summary": "Update Relationship",
+                "operationId": "update-relationship",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Friend id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Relationship id",
+                        "name": "relationship_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Relationship info",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.RelationshipUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.statusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "delete relationship of friend",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friend"
+                ],
+                "summary": "Delete Relationship",
+                "operationId": "delete-relationship",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Friend id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Relationship id",
+                        "name": "relationship_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.statusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/friend/{id}/tag": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "add tag to friend",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friend"
+                ],
+                "summary": "Add Tag To Friend",
+                "operationId": "add-tag-to-friend",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Friend id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Add Tag",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.AdditionTag"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.statusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/friend/{id}/tag/{tag_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "delete tag from friend",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friend"
+                ],
+                "summary": "Delete Tag From Friend",
+                "operationId": "delete-tag-from-friend",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Friend id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tag id",
+                        "name": "tag_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.statusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/friend/{id}/timeline": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get interactions, past event occurrences, profile and career changes and notes about or mentioning friend, the latest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friend"
+                ],
+                "summary": "Get Friend Timeline",
+                "operationId": "get-friend-timeline",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Friend id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Range start, RFC3339 or YYYY-MM-DD (default a year before to)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Range end, RFC3339 or YYYY-MM-DD (default now)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.getTimelineResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/friend/{id}/vcard": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "export friend with contact points as vCard 3.0",
+                "produces": [
+                    "text/vcard"
+                ],
+                "tags": [
+                    "friend"
+                ],
+                "summary": "Export Friend vCard",
+                "operationId": "export-friend-vcard",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Friend id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/friendlist": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get page of friendlists, filtered and sorted",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friendlist"
+                ],
+                "summary": "Get All Friendlists",
+                "operationId": "get-all-friendlists",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Friendlists with tag",
+                        "name": "tag_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Friendlists with friend",
+                        "name": "friend_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 1-200 (default 50)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort key: title (default)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "asc (default) or desc",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.getAllFriendlistsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "create friendlist, with a rule like \"tag in (work) and city = Moscow and birthday within 30 days\" it is smart and its friends follow the rule",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friendlist"
+                ],
+                "summary": "Create Friendlist",
+                "operationId": "create-friendlist",
+                "parameters": [
+                    {
+                        "description": "Friendlist info",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.FriendlistWithTagsUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/friendlist/friend": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get all friendlists with friends",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friendlist"
+                ],
+                "summary": "Get All Friendlists With Friends",
+                "operationId": "get-all-friendlists-with-friends",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.getAllFriendlistsWithFriendsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/friendlist/full": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get all friendlists full",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friendlist"
+                ],
+                "summary": "Get All Friendlists Full",
+                "operationId": "get-all-friendlists-full",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.getAllFriendlistsFullResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/friendlist/tag": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get all friendlists with tags",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friendlist"
+                ],
+                "summary": "Get All Friendlists With Tags",
+                "operationId": "get-all-friendlists-with-tags",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.getAllFriendlistsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/friendlist/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get friendlist by id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friendlist"
+                ],
+                "summary": "Get Friendlist By Id",
+                "operationId": "get-friendlist-by-id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Friendlist id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Friendlist"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "update friendlist",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friendlist"
+                ],
+                "summary": "Update Friendlist",
+                "operationId": "update-friendlist",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Friendlist id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Friendlist info",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.UpdateFriendlist"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.statusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "move friendlist to trash, it can be restored until purged",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friendlist"
+                ],
+                "summary": "Delete Friendlist",
+                "operationId": "delete-friendlist",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Friendlist id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.statusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/friendlist/{id}/field": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get custom fields of friendlist template",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friendlist"
+                ],
+                "summary": "Get Friendlist Fields",
+                "operationId": "get-friendlist-fields",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Friendlist id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.getAllAdditionalInfoFieldsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "add custom field to friendlist template, friends of the friendlist get the field",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friendlist"
+                ],
+                "summary": "Add Field To Friendlist",
+                "operationId": "add-field-to-friendlist",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Friendlist id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Add Field",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.AdditionFieldToFriendlist"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.statusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/friendlist/{id}/field/{field_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "delete custom field from friendlist template, values of friends are kept",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friendlist"
+                ],
+                "summary": "Delete Field From Friendlist",
+                "operationId": "delete-field-from-friendlist",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Friendlist id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field id",
+                        "name": "field_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.statusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/friendlist/{id}/friend": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get friendlist by id with friends",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friendlist"
+                ],
+                "summary": "Get Friendlist By ID With Friends",
+                "operationId": "get-friendlist-by-id-with-friends",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Friendlist id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.FriendlistWithFriends"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "add friend to friendlist, not allowed for smart friendlists",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friendlist"
+                ],
+                "summary": "Add Friend To Friendlist",
+                "operationId": "add-friend-to-friendlist",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Friendlist id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Add Friend",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.AdditionFriendToFriendlist"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.statusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/friendlist/{id}/friend/{friend_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "delete friend from friendlist",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friendlist"
+                ],
+                "summary": "Delete Friend From Friendlist",
+                "operationId": "delete-friend-from-friendlist",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Friendlist id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Friend id",
+                        "name": "friend_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.statusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/friendlist/{id}/full": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get friendlist full by id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friendlist"
+                ],
+                "summary": "Get Friendlist Full By ID",
+                "operationId": "get-friendlist-full-by-id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Friendlist id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.FriendlistFull"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/friendlist/{id}/share": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get users the friendlist is shared with",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friendlist"
+                ],
+                "summary": "Get Friendlist Shares",
+                "operationId": "get-friendlist-shares",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Friendlist id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.getFriendlistSharesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "share friendlist with another user by mail, the user can read images of the friendlist and its friends",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friendlist"
+                ],
+                "summary": "Share Friendlist",
+                "operationId": "share-friendlist",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Friendlist id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "User to share with",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.FriendlistShareInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/friendlist/{id}/share/{user_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "stop sharing friendlist with the user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friendlist"
+                ],
+                "summary": "Unshare Friendlist",
+                "operationId": "unshare-friendlist",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Friendlist id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User id",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.statusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/friendlist/{id}/tag": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get friendlist by id with tags",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friendlist"
+                ],
+                "summary": "Get Friendlist By ID With Tags",
+                "operationId": "get-friendlist-by-id-with-tags",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Friendlist id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.FriendlistWithTags"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "add tag to friendlist",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friendlist"
+                ],
+                "summary": "Add Tag To Friendlist",
+                "operationId": "add-tag-to-friendlist",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Friendlist id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Add Tag",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.AdditionTag"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.statusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/friendlist/{id}/tag/{tag_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "delete tag from friendlist",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friendlist"
+                ],
+                "summary": "Delete Tag From Friendlist",
+                "operationId": "delete-tag-from-friendlist",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Friendlist id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tag id",
+                        "name": "tag_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.statusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/image": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "upload image owned by the user, the type is detected from the content",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "image"
+                ],
+                "summary": "Upload Image",
+                "operationId": "upload-image",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Image file to upload",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Successfully uploaded image",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Invalid file type. Only JPG, PNG, HEIC files are allowed.",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/image/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "delete image by ID, only the owner can delete it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "image"
+                ],
+                "summary": "Delete Image",
+                "operationId": "delete-image",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Image ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Image deleted successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Image not found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/image/{id}/{res}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get image by ID and resolution, the image is available to its owner and to users a friendlist with it is shared with.\nResolution is snapped to 64, 128, 256, 512 or 1024, resized variants are cached and served with ETag",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "image/jpeg"
+                ],
+                "tags": [
+                    "image"
+                ],
+                "summary": "Get Image",
+                "operationId": "get-image",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Image ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Resolution",
+                        "name": "res",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the cached variant",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "304": {
+                        "description": "Cached variant is up to date",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Image not found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/note": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get notes with mentioned friends, the latest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "note"
+                ],
+                "summary": "Get All Notes",
+                "operationId": "get-all-notes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Notes attached to friend",
+                        "name": "friend_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Notes attached to event",
+                        "name": "event_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Notes attached to friendlist",
+                        "name": "friendlist_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Notes mentioning friend",
+                        "name": "mentioned_friend_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.getAllNotesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "create markdown note attached to a friend, an event or a friendlist, friends are mentioned as @[label](friend id)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "note"
+                ],
+                "summary": "Create Note",
+                "operationId": "create-note",
+                "parameters": [
+                    {
+                        "description": "Note info",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Note"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/note/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get note by id with mentioned friends",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "note"
+                ],
+                "summary": "Get Note By Id",
+                "operationId": "get-note-by-id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Note id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Note"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "update note body, mentions are rebuilt from it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "note"
+                ],
+                "summary": "Update Note",
+                "operationId": "update-note",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Note id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Note info",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.NoteUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.statusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "delete note",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "note"
+                ],
+                "summary": "Delete Note",
+                "operationId": "delete-note",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Note id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.statusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/profile": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "update user profile",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "profile"
+                ],
+                "summary": "Update Profile",
+                "operationId": "update-profile",
+                "parameters": [
+                    {
+                        "description": "User update payload",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.UserUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully updated profile",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.statusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/profile/": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get profile",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "profile"
+                ],
+                "summary": "Get Profile",
+                "operationId": "get-profile",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/profile/digest": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get digest setting",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "profile"
+                ],
+                "summary": "Get Digest Setting",
+                "operationId": "get-digest-setting",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.DigestSetting"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "update digest setting",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "profile"
+                ],
+                "summary": "Update Digest Setting",
+                "operationId": "update-digest-setting",
+                "parameters": [
+                    {
+                        "description": "Digest setting",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.DigestSettingUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.statusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/profile/digest/preview": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "compose digest as it would be sent now",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "profile"
+                ],
+                "summary": "Preview Digest",
+                "operationId": "preview-digest",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Digest"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/profile/notifications": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get notification preferences",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "profile"
+                ],
+                "summary": "Get Notification Preferences",
+                "operationId": "get-notification-preferences",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.NotificationPreference"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "update enabled channels, quiet hours and notification categories",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "profile"
+                ],
+                "summary": "Update Notification Preferences",
+                "operationId": "update-notification-preferences",
+                "parameters": [
+                    {
+                        "description": "Notification preferences",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.NotificationPreferenceUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.statusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/reminder": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get all reminders",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reminder"
+                ],
+                "summary": "Get All Reminders",
+                "operationId": "get-all-reminders",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.getAllRemindersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "create reminder",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reminder"
+                ],
+                "summary": "Create Reminder",
+                "operationId": "create-reminder",
+                "parameters": [
+                    {
+                        "description": "Reminder info",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Reminder"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/reminder/delivery": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get all fired reminder deliveries, optionally filtered by status",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reminder"
+                ],
+                "summary": "Get All Reminder Deliveries",
+                "operationId": "get-all-reminder-deliveries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Delivery status (pending, sent, acknowledged, snoozed, dismissed, failed)",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.getAllReminderDeliveriesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/reminder/delivery/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get reminder delivery by id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reminder"
+                ],
+                "summary": "Get Reminder Delivery By Id",
+                "operationId": "get-reminder-delivery-by-id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Delivery id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.ReminderDelivery"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/reminder/delivery/{id}/acknowledge": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "acknowledge fired reminder",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reminder"
+                ],
+                "summary": "Acknowledge Reminder Delivery",
+                "operationId": "acknowledge-reminder-delivery",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Delivery id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.statusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/reminder/delivery/{id}/dismiss": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "dismiss fired reminder",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reminder"
+                ],
+                "summary": "Dismiss Reminder Delivery",
+                "operationId": "dismiss-reminder-delivery",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Delivery id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.statusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/reminder/delivery/{id}/snooze": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "snooze fired reminder for some minutes or until the given time",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reminder"
+                ],
+                "summary": "Snooze Reminder Delivery",
+                "operationId": "snooze-reminder-delivery",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Delivery id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Snooze info",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.ReminderDeliverySnooze"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.statusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/reminder/event/{event_id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get all reminders by event id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reminder"
+                ],
+                "summary": "Get All Reminders By Event Id",
+                "operationId": "get-all-reminders-by-event-id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event id",
+                        "name": "event_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.getAllRemindersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/reminder/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get reminder by id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reminder"
+                ],
+                "summary": "Get Reminder By Id",
+                "operationId": "get-reminder-by-id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Reminder id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Reminder"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "update reminder",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reminder"
+                ],
+                "summary": "Update Reminder",
+                "operationId": "update-reminder",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Reminder id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.statusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "delete reminder",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reminder"
+                ],
+                "summary": "Delete Reminder",
+                "operationId": "delete-reminder",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Reminder id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.statusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/search": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "full-text and fuzzy search across friends, events, interactions and notes, matches in snippets are wrapped in \u003cb\u003e\u003c/b\u003e",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "search"
+                ],
+                "summary": "Search",
+                "operationId": "search",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search query, supports quotes, or and -",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Max results, 1-100 (default 20)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.getSearchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tag": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get page of tags sorted by title",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tag"
+                ],
+                "summary": "Get All Tags",
+                "operationId": "get-all-tags",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page size, 1-200 (default 50)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort key: title (default)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "asc (default) or desc",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.getAllTagsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "create tag",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tag"
+                ],
+                "summary": "Create Tag",
+                "operationId": "create-tag",
+                "parameters": [
+                    {
+                        "description": "Tag info",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Tag"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tag/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get tag by id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tag"
+                ],
+                "summary": "Get Tag By Id",
+                "operationId": "get-tag-by-id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Tag"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "update tag",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tag"
+                ],
+                "summary": "Update Tag",
+                "operationId": "update-tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tag info",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Tag"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.statusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "move tag to trash, it can be restored until purged",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tag"
+                ],
+                "summary": "Delete Tag",
+                "operationId": "delete-tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.statusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/trash": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get deleted friends, events, friendlists and tags, the latest deleted first; they are purged after the retention",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trash"
+                ],
+                "summary": "Get Trash",
+                "operationId": "get-trash",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.getTrashResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/trash/{kind}/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "restore deleted friend, event, friendlist or tag with its tags, friendlists and events",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trash"
+                ],
+                "summary": "Restore From Trash",
+                "operationId": "restore-from-trash",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "friend, event, friendlist or tag",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.statusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.errorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "github_com_lunovoy_friendly_internal_models.AdditionFieldToFriendlist": {
+            "type": "object",
+            "required": [
+                "field_id"
+            ],
+            "properties": {
+                "field_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_lunovoy_friendly_internal_models.AdditionFriendToFriendlist": {
+            "type": "object",
+            "required": [
+                "friend_id"
+            ],
+            "properties": {
+                "friend_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_lunovoy_friendly_internal_models.AdditionTag": {
+            "type": "object",
+            "required": [
+                "tag_id"
+            ],
+            "properties": {
+                "tag_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_lunovoy_friendly_internal_models.AdditionalInfoField": {
+            "type": "object",
+            "required": [
+                "title"
+            ],
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_lunovoy_friendly_internal_models.AdditionalInfoFieldUpdate": {
+            "type": "object",
+            "properties": {
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_lunovoy_friendly_internal_models.AdditionalInfoFieldValueInput": {
+            "type": "object",
+            "properties": {
+                "value": {}
+            }
+        },
+        "github_com_lunovoy_friendly_internal_models.Attachment": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "event_id": {
+                    "$ref": "#/definitions/uuid.NullUUID"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "friend_id": {
+                    "$ref": "#/definitions/uuid.NullUUID"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "mime_type": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_lunovoy_friendly_internal_models.ContactPoint": {
+            "type": "object",
+            "properties": {
+                "friend_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_primary": {
+                    "type": "boolean"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_lunovoy_friendly_internal_models.ContactPointUpdate": {
+            "type": "object",
+            "properties": {
+                "is_primary": {
+                    "type": "boolean"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_lunovoy_friendly_internal_models.Digest": {
+            "type": "object",
+            "properties": {
+                "birthdays": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.DigestBirthday"
+                    }
+                },
+                "dates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.DigestFriendDate"
+                    }
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.DigestEvent"
+                    }
+                },
+                "from": {
+                    "type": "string"
+                },
+                "overdue": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.OverdueFriend"
+                    }
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_lunovoy_friendly_internal_models.DigestBirthday": {
+            "type": "object",
+            "properties": {
+                "age_turning": {
+                    "type": "integer"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "friend": {
+                    "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Friend"
+                },
+                "gift_ideas": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.GiftIdea"
+                    }
+                }
+            }
+        },
+        "github_com_lunovoy_friendly_internal_models.DigestEvent": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string"
+                },
+                "event": {
+                    "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Event"
+                },
+                "gift_ideas": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.GiftIdea"
+                    }
+                }
+            }
+        },
+        "github_com_lunovoy_friendly_internal_models.DigestFriendDate": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string"
+                },
+                "friend": {
+                    "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Friend"
+                },
+                "friend_date": {
+                    "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.FriendDate"
+                },
+                "years": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_lunovoy_friendly_internal_models.DigestSetting": {
+            "type": "object",
+            "properties": {
+                "days_ahead": {
+                    "type": "integer"
+                },
+                "frequency": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "last_sent_at": {
+                    "$ref": "#/definitions/sql.NullTime"
+                },
+                "time_of_day": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "weekday": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_lunovoy_friendly_internal_models.DigestSettingUpdate": {
+            "type": "object",
+            "properties": {
+                "days_ahead": {
+                    "type": "integer"
+                },
+                "frequency": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "time_of_day": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "type": "string"
+                },
+                "weekday": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_lunovoy_friendly_internal_models.DuplicateCandidate": {
+            "type": "object",
+            "properties": {
+                "duplicate": {
+                    "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Friend"
+                },
+                "friend": {
+                    "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Friend"
+                },
+                "reasons": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "score": {
+                    "type": "number"
+                }
+            }
+        },
+        "github_com_lunovoy_friendly_internal_models.Employment": {
+            "type": "object",
+            "properties": {
+                "city": {
+                    "type": "string"
+                },
+                "company": {
+                    "type": "string"
+                },
+                "ended_at": {
+                    "type": "string"
+                },
+                "friend_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_current": {
+                    "type": "boolean"
+                },
+                "position": {
+                    "type": "string"
+                },
+                "profession": {
+                    "type": "string"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_lunovoy_friendly_internal_models.EmploymentUpdate": {
+            "type": "object",
+            "properties": {
+                "city": {
+                    "type": "string"
+                },
+                "company": {
+                    "type": "string"
+                },
+                "current": {
+                    "description": "true removes the end date",
+                    "type": "boolean"
+                },
+                "ended_at": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "string"
+                },
+                "profession": {
+                    "type": "string"
+                },
+                "started_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_lunovoy_friendly_internal_models.Event": {
+            "type": "object",
+            "required": [
+                "title"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "end_date": {
+                    "$ref": "#/definitions/sql.NullTime"
+                },
+                "frequency": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "start_date": {
+                    "$ref": "#/definitions/sql.NullTime"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_lunovoy_friendly_internal_models.EventFullUpdate": {
+            "type": "object",
+            "properties": {
+                "event": {
+                    "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.EventUpdate"
+                },
+                "friend_ids": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.FriendID"
+                    }
+                },
+                "reminders": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.ReminderWithIDUpdate"
+                    }
+                }
+            }
+        },
+        "github_com_lunovoy_friendly_internal_models.EventUpdate": {
+            "type": "object",
+            "required": [
+                "title"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "end_date": {
+                    "$ref": "#/definitions/sql.NullTime"
+                },
+                "frequency": {
+                    "type": "string"
+                },
+                "start_date": {
+                    "$ref": "#/definitions/sql.NullTime"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_lunovoy_friendly_internal_models.EventWithFriendIDsAndReminders": {
+            "type": "object",
+            "properties": {
+                "event": {
+                    "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Event"
+                },
+                "friend_ids": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.FriendID"
+                    }
+                },
+                "reminders": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Reminder"
+                    }
+                }
+            }
+        },
+        "github_com_lunovoy_friendly_internal_models.EventWithFriends": {
+            "type": "object",
+            "properties": {
+                "event": {
+                    "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Event"
+                },
+                "friends": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Friend"
+                    }
+                }
+            }
+        },
+        "github_com_lunovoy_friendly_internal_models.EventWithFriendsAndReminders": {
+            "type": "object",
+            "properties": {
+                "event": {
+                    "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Event"
+                },
+                "friends": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Friend"
+                    }
+                },
+                "reminders": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Reminder"
+                    }
+                }
+            }
+        },
+        "github_com_lunovoy_friendly_internal_models.Friend": {
+            "type": "object",
+            "properties": {
+                "contact_interval_days": {
+                    "type": "integer"
+                },
+                "dob": {
+                    "$ref": "#/definitions/sql.NullTime"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "image_id": {
+                    "type": "string"
+                },
+                "last_contacted_at": {
+                    "$ref": "#/definitions/sql.NullTime"
+                },
+                "last_name": {
+                    "type": "string"
+                },
+                "track_birthday": {
+                    "type": "boolean"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_lunovoy_friendly_internal_models.FriendAdditionalInfoField": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.AdditionalInfoField"
+                },
+                "value": {}
+            }
+        },
+        "github_com_lunovoy_friendly_internal_models.FriendChange": {
+            "type": "object",
+            "properties": {
+                "changed_at": {
+                    "type": "string"
+                },
+                "changed_by": {
+                    "$ref": "#/definitions/uuid.NullUUID"
+                },
+                "entity": {
+                    "type": "string"
+                },
+                "field": {
+                    "type": "string"
+                },
+                "friend_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "new_value": {
+                    "type": "string"
+                },
+                "old_value": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_lunovoy_friendly_internal_models.FriendContacted": {
+            "type": "object",
+            "properties": {
+                "at": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_lunovoy_friendly_internal_models.FriendDate": {
+            "type": "object",
+            "properties": {
+                "day": {
+                    "type": "integer"
+                },
+                "friend_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "month": {
+                    "type": "integer"
+                },
+                "recurrence": {
+                    "type": "string"
+                },
+                "reminder_minutes": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_lunovoy_friendly_internal_models.FriendDateUpdate": {
+            "type": "object",
+            "properties": {
+                "day": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "month": {
+                    "type": "integer"
+                },
+                "recurrence": {
+                    "type": "string"
+                },
+                "reminder_minutes": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "year": {
+                    "description": "0 removes the year",
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_lunovoy_friendly_internal_models.FriendID": {
+            "type": "object",
+            "properties": {
+                "friend_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_lunovoy_friendly_internal_models.FriendMerge": {
+            "type": "object",
+            "required": [
+                "source_id",
+                "target_id"
+            ],
+            "properties": {
+                "choices": {
+                    "description": "Choices pick whose value is kept when both friends have one, keys are\nfriend and work info fields or custom field ids. Target wins by default.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "source_id": {
+                    "type": "string"
+                },
+                "target_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_lunovoy_friendly_internal_models.FriendRevert": {
+            "type": "object",
+            "required": [
+                "at"
+            ],
+            "properties": {
+                "at": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_lunovoy_friendly_internal_models.FriendWorkInfoTags": {
+            "type": "object",
+            "properties": {
+                "additional_fields": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.FriendAdditionalInfoField"
+                    }
+                },
+                "contact_points": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.ContactPoint"
+                    }
+                },
+                "friend": {
+                    "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Friend"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Tag"
+                    }
+                },
+                "work_info": {
+                    "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.WorkInfo"
+                }
+            }
+        },
+        "github_com_lunovoy_friendly_internal_models.Friendlist": {
+            "type": "object",
+            "required": [
+                "title"
+            ],
+            "properties": {
+                "color": {
+                    "type": "string"
+                },
+                "contact_interval_days": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "image_id": {
+                    "type": "string"
+                },
+                "rule": {
+                    "description": "Rule makes the friendlist smart, its friends are the ones matching\nthe rule instead of added by hand",
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_lunovoy_friendly_internal_models.FriendlistFull": {
+            "type": "object",
+            "properties": {
+                "fields": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.AdditionalInfoField"
+                    }
+                },
+                "friendlist": {
+                    "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.FriendlistWithTags"
+                },
+                "friends": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.FriendWorkInfoTags"
+                    }
+                }
             }
         },
-        "github_com_lunovoy_friendly_internal_models.AdditionTag": {
+        "github_com_lunovoy_friendly_internal_models.FriendlistShare": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "friendlist_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "mail": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_lunovoy_friendly_internal_models.FriendlistShareInput": {
             "type": "object",
             "required": [
-                "tag_id"
+                "mail"
+            ],
+            "properties": {
+                "mail": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_lunovoy_friendly_internal_models.FriendlistWithFriends": {
+            "type": "object",
+            "properties": {
+                "friendlist": {
+                    "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Friendlist"
+                },
+                "friends": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Friend"
+                    }
+                }
+            }
+        },
+        "github_com_lunovoy_friendly_internal_models.FriendlistWithTags": {
+            "type": "object",
+            "properties": {
+                "friendlist": {
+                    "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Friendlist"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Tag"
+                    }
+                }
+            }
+        },
+        "github_com_lunovoy_friendly_internal_models.FriendlistWithTagsUpdate": {
+            "type": "object",
+            "properties": {
+                "friendlist": {
+                    "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.UpdateFriendlist"
+                },
+                "tag_ids": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.AdditionTag"
+                    }
+                }
+            }
+        },
+        "github_com_lunovoy_friendly_internal_models.GiftIdea": {
+            "type": "object",
+            "required": [
+                "title"
+            ],
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "event_id": {
+                    "$ref": "#/definitions/uuid.NullUUID"
+                },
+                "friend_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "link": {
+                    "type": "string"
+                },
+                "occasion": {
+                    "type": "string"
+                },
+                "occasion_date": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_lunovoy_friendly_internal_models.GiftIdeaUpdate": {
+            "type": "object",
+            "properties": {
+                "event_id": {
+                    "type": "string"
+                },
+                "link": {
+                    "type": "string"
+                },
+                "occasion": {
+                    "description": "empty removes the occasion",
+                    "type": "string"
+                },
+                "occasion_date": {
+                    "type": "string"
+                },
+                "price": {
+                    "description": "negative removes the price",
+                    "type": "number"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_lunovoy_friendly_internal_models.Graph": {
+            "type": "object",
+            "properties": {
+                "edges": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.GraphEdge"
+                    }
+                },
+                "nodes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.GraphNode"
+                    }
+                }
+            }
+        },
+        "github_com_lunovoy_friendly_internal_models.GraphEdge": {
+            "type": "object",
+            "properties": {
+                "directed": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "target": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_lunovoy_friendly_internal_models.GraphNode": {
+            "type": "object",
+            "properties": {
+                "first_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "image_id": {
+                    "type": "string"
+                },
+                "last_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_lunovoy_friendly_internal_models.Interaction": {
+            "type": "object",
+            "required": [
+                "kind"
+            ],
+            "properties": {
+                "channel": {
+                    "type": "string"
+                },
+                "event_id": {
+                    "$ref": "#/definitions/uuid.NullUUID"
+                },
+                "friend_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "occurred_at": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_lunovoy_friendly_internal_models.InteractionUpdate": {
+            "type": "object",
+            "properties": {
+                "channel": {
+                    "type": "string"
+                },
+                "event_id": {
+                    "$ref": "#/definitions/uuid.NullUUID"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "occurred_at": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_lunovoy_friendly_internal_models.Note": {
+            "type": "object",
+            "required": [
+                "body"
             ],
             "properties": {
-                "tag_id": {
+                "body": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "event_id": {
+                    "$ref": "#/definitions/uuid.NullUUID"
+                },
+                "friend_id": {
+                    "$ref": "#/definitions/uuid.NullUUID"
+                },
+                "friendlist_id": {
+                    "$ref": "#/definitions/uuid.NullUUID"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "mentions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.NoteMention"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_lunovoy_friendly_internal_models.NoteMention": {
+            "type": "object",
+            "properties": {
+                "first_name": {
+                    "type": "string"
+                },
+                "friend_id": {
+                    "type": "string"
+                },
+                "last_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_lunovoy_friendly_internal_models.NoteUpdate": {
+            "type": "object",
+            "properties": {
+                "body": {
                     "type": "string"
                 }
             }
         },
-        "github_com_lunovoy_friendly_internal_models.Event": {
+        "github_com_lunovoy_friendly_internal_models.NotificationPreference": {
             "type": "object",
-            "required": [
-                "title"
-            ],
             "properties": {
-                "description": {
-                    "type": "string"
+                "birthday_reminder_minutes": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
                 },
-                "end_date": {
-                    "$ref": "#/definitions/sql.NullTime"
+                "birthdays": {
+                    "type": "boolean"
                 },
-                "frequency": {
-                    "type": "string"
+                "channels": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "digests": {
+                    "type": "boolean"
+                },
+                "events": {
+                    "type": "boolean"
                 },
                 "id": {
                     "type": "string"
                 },
-                "is_active": {
+                "keep_in_touch": {
                     "type": "boolean"
                 },
-                "start_date": {
-                    "$ref": "#/definitions/sql.NullTime"
+                "quiet_hours_end": {
+                    "type": "string"
                 },
-                "title": {
+                "quiet_hours_start": {
+                    "type": "string"
+                },
+                "timezone": {
                     "type": "string"
                 },
                 "user_id": {
@@ -3146,110 +10089,106 @@ const docTemplate = `{
                 }
             }
         },
-        "github_com_lunovoy_friendly_internal_models.EventFullUpdate": {
+        "github_com_lunovoy_friendly_internal_models.NotificationPreferenceUpdate": {
             "type": "object",
             "properties": {
-                "event": {
-                    "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.EventUpdate"
-                },
-                "friend_ids": {
+                "birthday_reminder_minutes": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.FriendID"
+                        "type": "integer"
                     }
                 },
-                "reminders": {
+                "birthdays": {
+                    "type": "boolean"
+                },
+                "channels": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.ReminderWithIDUpdate"
+                        "type": "string"
                     }
+                },
+                "digests": {
+                    "type": "boolean"
+                },
+                "events": {
+                    "type": "boolean"
+                },
+                "keep_in_touch": {
+                    "type": "boolean"
+                },
+                "quiet_hours_end": {
+                    "type": "string"
+                },
+                "quiet_hours_start": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "type": "string"
                 }
             }
         },
-        "github_com_lunovoy_friendly_internal_models.EventUpdate": {
+        "github_com_lunovoy_friendly_internal_models.Occurrence": {
             "type": "object",
-            "required": [
-                "title"
-            ],
             "properties": {
-                "description": {
+                "age": {
+                    "type": "integer"
+                },
+                "date": {
                     "type": "string"
                 },
-                "end_date": {
-                    "$ref": "#/definitions/sql.NullTime"
+                "event_id": {
+                    "type": "string"
                 },
-                "frequency": {
+                "friend_date_id": {
                     "type": "string"
                 },
-                "start_date": {
-                    "$ref": "#/definitions/sql.NullTime"
+                "friend_id": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string"
                 },
                 "title": {
                     "type": "string"
                 }
             }
         },
-        "github_com_lunovoy_friendly_internal_models.EventWithFriendIDsAndReminders": {
+        "github_com_lunovoy_friendly_internal_models.OverdueFriend": {
             "type": "object",
             "properties": {
-                "event": {
-                    "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Event"
+                "contact_interval_days": {
+                    "type": "integer"
                 },
-                "friend_ids": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.FriendID"
-                    }
+                "due_at": {
+                    "type": "string"
                 },
-                "reminders": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Reminder"
-                    }
-                }
-            }
-        },
-        "github_com_lunovoy_friendly_internal_models.EventWithFriends": {
-            "type": "object",
-            "properties": {
-                "event": {
-                    "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Event"
+                "friend": {
+                    "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Friend"
                 },
-                "friends": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Friend"
-                    }
+                "last_contacted_at": {
+                    "type": "string"
+                },
+                "overdue_days": {
+                    "type": "integer"
                 }
             }
         },
-        "github_com_lunovoy_friendly_internal_models.EventWithFriendsAndReminders": {
+        "github_com_lunovoy_friendly_internal_models.Photo": {
             "type": "object",
+            "required": [
+                "image_id"
+            ],
             "properties": {
-                "event": {
-                    "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Event"
+                "caption": {
+                    "type": "string"
                 },
-                "friends": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Friend"
-                    }
+                "created_at": {
+                    "type": "string"
                 },
-                "reminders": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Reminder"
-                    }
-                }
-            }
-        },
-        "github_com_lunovoy_friendly_internal_models.Friend": {
-            "type": "object",
-            "properties": {
-                "dob": {
-                    "$ref": "#/definitions/sql.NullTime"
+                "event_id": {
+                    "$ref": "#/definitions/uuid.NullUUID"
                 },
-                "first_name": {
+                "friend_id": {
                     "type": "string"
                 },
                 "id": {
@@ -3258,7 +10197,13 @@ const docTemplate = `{
                 "image_id": {
                     "type": "string"
                 },
-                "last_name": {
+                "is_avatar": {
+                    "type": "boolean"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "taken_at": {
                     "type": "string"
                 },
                 "user_id": {
@@ -3266,50 +10211,56 @@ const docTemplate = `{
                 }
             }
         },
-        "github_com_lunovoy_friendly_internal_models.FriendID": {
+        "github_com_lunovoy_friendly_internal_models.PhotoOrder": {
             "type": "object",
+            "required": [
+                "photo_ids"
+            ],
             "properties": {
-                "friend_id": {
-                    "type": "string"
+                "photo_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "github_com_lunovoy_friendly_internal_models.FriendWorkInfoTags": {
+        "github_com_lunovoy_friendly_internal_models.PhotoUpdate": {
             "type": "object",
             "properties": {
-                "friend": {
-                    "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Friend"
+                "caption": {
+                    "type": "string"
                 },
-                "tags": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Tag"
-                    }
+                "event_id": {
+                    "description": "nil uuid detaches the event",
+                    "type": "string"
                 },
-                "work_info": {
-                    "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.WorkInfo"
+                "taken_at": {
+                    "description": "zero time removes the date",
+                    "type": "string"
                 }
             }
         },
-        "github_com_lunovoy_friendly_internal_models.Friendlist": {
+        "github_com_lunovoy_friendly_internal_models.Relationship": {
             "type": "object",
             "required": [
-                "title"
+                "kind",
+                "related_friend_id"
             ],
             "properties": {
-                "color": {
-                    "type": "string"
+                "directed": {
+                    "type": "boolean"
                 },
-                "description": {
+                "friend_id": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "image_id": {
+                "kind": {
                     "type": "string"
                 },
-                "title": {
+                "related_friend_id": {
                     "type": "string"
                 },
                 "user_id": {
@@ -3317,89 +10268,139 @@ const docTemplate = `{
                 }
             }
         },
-        "github_com_lunovoy_friendly_internal_models.FriendlistFull": {
+        "github_com_lunovoy_friendly_internal_models.RelationshipUpdate": {
             "type": "object",
+            "required": [
+                "kind"
+            ],
             "properties": {
-                "friendlist": {
-                    "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.FriendlistWithTags"
-                },
-                "friends": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.FriendWorkInfoTags"
-                    }
+                "kind": {
+                    "type": "string"
                 }
             }
         },
-        "github_com_lunovoy_friendly_internal_models.FriendlistWithFriends": {
+        "github_com_lunovoy_friendly_internal_models.Reminder": {
             "type": "object",
             "properties": {
-                "friendlist": {
-                    "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Friendlist"
+                "event_id": {
+                    "type": "string"
                 },
-                "friends": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Friend"
-                    }
+                "id": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "minutes_until_event": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "string"
                 }
             }
         },
-        "github_com_lunovoy_friendly_internal_models.FriendlistWithTags": {
+        "github_com_lunovoy_friendly_internal_models.ReminderDelivery": {
             "type": "object",
             "properties": {
-                "friendlist": {
-                    "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Friendlist"
+                "attempts": {
+                    "type": "integer"
                 },
-                "tags": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Tag"
-                    }
+                "event_id": {
+                    "$ref": "#/definitions/uuid.NullUUID"
+                },
+                "friend_date_id": {
+                    "$ref": "#/definitions/uuid.NullUUID"
+                },
+                "friend_id": {
+                    "$ref": "#/definitions/uuid.NullUUID"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "notify_at": {
+                    "type": "string"
+                },
+                "occurrence_date": {
+                    "type": "string"
+                },
+                "offset_minutes": {
+                    "type": "integer"
+                },
+                "reminder_id": {
+                    "$ref": "#/definitions/uuid.NullUUID"
+                },
+                "sent_at": {
+                    "$ref": "#/definitions/sql.NullTime"
+                },
+                "snoozed_until": {
+                    "$ref": "#/definitions/sql.NullTime"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
                 }
             }
         },
-        "github_com_lunovoy_friendly_internal_models.FriendlistWithTagsUpdate": {
+        "github_com_lunovoy_friendly_internal_models.ReminderDeliverySnooze": {
             "type": "object",
             "properties": {
-                "friendlist": {
-                    "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.UpdateFriendlist"
+                "minutes": {
+                    "type": "integer"
                 },
-                "tag_ids": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.AdditionTag"
-                    }
+                "until": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_lunovoy_friendly_internal_models.ReminderWithIDUpdate": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "minutes_until_event": {
+                    "type": "integer"
                 }
             }
         },
-        "github_com_lunovoy_friendly_internal_models.Reminder": {
+        "github_com_lunovoy_friendly_internal_models.SearchResult": {
             "type": "object",
             "properties": {
-                "event_id": {
-                    "type": "string"
+                "friend_id": {
+                    "$ref": "#/definitions/uuid.NullUUID"
                 },
                 "id": {
                     "type": "string"
                 },
-                "is_active": {
-                    "type": "boolean"
+                "kind": {
+                    "type": "string"
                 },
-                "minutes_until_event": {
-                    "type": "integer"
+                "rank": {
+                    "type": "number"
                 },
-                "user_id": {
+                "snippet": {
+                    "type": "string"
+                },
+                "title": {
                     "type": "string"
                 }
             }
         },
-        "github_com_lunovoy_friendly_internal_models.ReminderWithIDUpdate": {
+        "github_com_lunovoy_friendly_internal_models.StorageUsage": {
             "type": "object",
             "properties": {
-                "id": {
-                    "type": "string"
+                "quota": {
+                    "type": "integer"
                 },
-                "minutes_until_event": {
+                "used": {
                     "type": "integer"
                 }
             }
@@ -3421,10 +10422,58 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_lunovoy_friendly_internal_models.TimelineItem": {
+            "type": "object",
+            "properties": {
+                "change": {
+                    "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.FriendChange"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "employment": {
+                    "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Employment"
+                },
+                "event": {
+                    "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Event"
+                },
+                "interaction": {
+                    "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Interaction"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "note": {
+                    "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Note"
+                }
+            }
+        },
+        "github_com_lunovoy_friendly_internal_models.TrashItem": {
+            "type": "object",
+            "properties": {
+                "deleted_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
         "github_com_lunovoy_friendly_internal_models.UpdateFriendInput": {
             "type": "object",
             "properties": {
+                "contact_interval_days": {
+                    "description": "0 removes the cadence",
+                    "type": "integer"
+                },
                 "dob": {
+                    "description": "zero time removes the dob",
                     "type": "string"
                 },
                 "first_name": {
@@ -3435,6 +10484,9 @@ const docTemplate = `{
                 },
                 "last_name": {
                     "type": "string"
+                },
+                "track_birthday": {
+                    "type": "boolean"
                 }
             }
         },
@@ -3472,12 +10524,20 @@ const docTemplate = `{
                 "color": {
                     "type": "string"
                 },
+                "contact_interval_days": {
+                    "description": "0 removes the cadence",
+                    "type": "integer"
+                },
                 "description": {
                     "type": "string"
                 },
                 "image_id": {
                     "type": "string"
                 },
+                "rule": {
+                    "description": "empty removes the rule",
+                    "type": "string"
+                },
                 "title": {
                     "type": "string"
                 },
@@ -3689,6 +10749,50 @@ const docTemplate = `{
                 }
             }
         },
+        "internal_handler.getAllAdditionalInfoFieldsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.AdditionalInfoField"
+                    }
+                }
+            }
+        },
+        "internal_handler.getAllAttachmentsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Attachment"
+                    }
+                }
+            }
+        },
+        "internal_handler.getAllContactPointsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.ContactPoint"
+                    }
+                }
+            }
+        },
+        "internal_handler.getAllEmploymentsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Employment"
+                    }
+                }
+            }
+        },
         "internal_handler.getAllEventsFullInfo": {
             "type": "object",
             "properties": {
@@ -3708,6 +10812,9 @@ const docTemplate = `{
                     "items": {
                         "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Event"
                     }
+                },
+                "next_cursor": {
+                    "type": "string"
                 }
             }
         },
@@ -3722,6 +10829,17 @@ const docTemplate = `{
                 }
             }
         },
+        "internal_handler.getAllFriendDatesResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.FriendDate"
+                    }
+                }
+            }
+        },
         "internal_handler.getAllFriendlistsFullResponse": {
             "type": "object",
             "properties": {
@@ -3741,6 +10859,9 @@ const docTemplate = `{
                     "items": {
                         "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Friendlist"
                     }
+                },
+                "next_cursor": {
+                    "type": "string"
                 }
             }
         },
@@ -3763,6 +10884,75 @@ const docTemplate = `{
                     "items": {
                         "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.FriendWorkInfoTags"
                     }
+                },
+                "next_cursor": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_handler.getAllGiftIdeasResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.GiftIdea"
+                    }
+                }
+            }
+        },
+        "internal_handler.getAllInteractionsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Interaction"
+                    }
+                }
+            }
+        },
+        "internal_handler.getAllNotesResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Note"
+                    }
+                }
+            }
+        },
+        "internal_handler.getAllPhotosResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Photo"
+                    }
+                }
+            }
+        },
+        "internal_handler.getAllRelationshipsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Relationship"
+                    }
+                }
+            }
+        },
+        "internal_handler.getAllReminderDeliveriesResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.ReminderDelivery"
+                    }
                 }
             }
         },
@@ -3785,6 +10975,108 @@ const docTemplate = `{
                     "items": {
                         "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Tag"
                     }
+                },
+                "next_cursor": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_handler.getCalendarResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.Occurrence"
+                    }
+                }
+            }
+        },
+        "internal_handler.getDuplicateFriendsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.DuplicateCandidate"
+                    }
+                }
+            }
+        },
+        "internal_handler.getFriendAdditionalInfoFieldsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.FriendAdditionalInfoField"
+                    }
+                }
+            }
+        },
+        "internal_handler.getFriendHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.FriendChange"
+                    }
+                }
+            }
+        },
+        "internal_handler.getFriendlistSharesResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.FriendlistShare"
+                    }
+                }
+            }
+        },
+        "internal_handler.getOverdueFriendsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.OverdueFriend"
+                    }
+                }
+            }
+        },
+        "internal_handler.getSearchResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.SearchResult"
+                    }
+                }
+            }
+        },
+        "internal_handler.getTimelineResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.TimelineItem"
+                    }
+                }
+            }
+        },
+        "internal_handler.getTrashResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_lunovoy_friendly_internal_models.TrashItem"
+                    }
                 }
             }
         },
@@ -3822,6 +11114,18 @@ const docTemplate = `{
                     "type": "boolean"
                 }
             }
+        },
+        "uuid.NullUUID": {
+            "type": "object",
+            "properties": {
+                "uuid": {
+                    "type": "string"
+                },
+                "valid": {
+                    "description": "Valid is true if UUID is not NULL",
+                    "type": "boolean"
+                }
+            }
         }
     },
     "securityDefinitions": {
@@ -9,30 +9,55 @@ import (
 	"github.com/lunovoy/friendly/internal/models"
 )
 
+// @Summary Create Additional Info Field
+// @Security ApiKeyAuth
+// @Tags additional-field
+// @Description create custom field (text, number, date, url, single_select, multi_select)
+// @ID create-additional-field
+// @Accept  json
+// @Produce  json
+// @Param input body models.AdditionalInfoField true "Field info"
+// @Success 201 {object} any
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/additional-field [post]
 func (h *Handler) createAdditionalInfoField(c *gin.Context) {
-
 	userID, err := getUserIDFromCtx(c)
 	if err != nil {
 		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
 		return
 	}
 
-	var payload models.Tag
+	var payload models.AdditionalInfoField
 	if err := c.BindJSON(&payload); err != nil {
 		newErrorResponse(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	tagID, err := h.services.Tag.Create(userID, payload)
+	fieldID, err := h.services.AdditionalInfoField.Create(userID, payload)
 	if err != nil {
-		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
 		return
 	}
+
 	c.JSON(http.StatusCreated, map[string]any{
-		"tag_id": tagID,
+		"field_id": fieldID,
 	})
-
 }
+
+// @Summary Get All Additional Info Fields
+// @Security ApiKeyAuth
+// @Tags additional-field
+// @Description get all custom fields
+// @ID get-all-additional-fields
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} getAllAdditionalInfoFieldsResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/additional-field [get]
 func (h *Handler) getAllAdditionalFields(c *gin.Context) {
 	userID, err := getUserIDFromCtx(c)
 	if err != nil {
@@ -40,17 +65,30 @@ func (h *Handler) getAllAdditionalFields(c *gin.Context) {
 		return
 	}
 
-	tags, err := h.services.Tag.GetAll(userID)
+	fields, err := h.services.AdditionalInfoField.GetAll(userID)
 	if err != nil {
 		newErrorResponse(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	c.JSON(http.StatusOK, map[string]any{
-		"tags": tags,
+	c.JSON(http.StatusOK, getAllAdditionalInfoFieldsResponse{
+		Data: fields,
 	})
-
 }
+
+// @Summary Get Additional Info Field By Id
+// @Security ApiKeyAuth
+// @Tags additional-field
+// @Description get custom field by id
+// @ID get-additional-field-by-id
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Field id"
+// @Success 200 {object} models.AdditionalInfoField
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/additional-field/{id} [get]
 func (h *Handler) getAdditionalFieldByID(c *gin.Context) {
 	userID, err := getUserIDFromCtx(c)
 	if err != nil {
@@ -58,23 +96,37 @@ func (h *Handler) getAdditionalFieldByID(c *gin.Context) {
 		return
 	}
 
-	tagID, err := uuid.Parse(c.Param("id"))
+	fieldID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
 		return
 	}
 
-	tag, err := h.services.Tag.GetByID(userID, tagID)
+	field, err := h.services.AdditionalInfoField.GetByID(userID, fieldID)
 	if err != nil {
-		newErrorResponse(c, http.StatusNotFound, err.Error())
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("field not found: %s", err.Error()))
 		return
 	}
 
 	c.JSON(http.StatusOK, map[string]any{
-		"tag": tag,
+		"field": field,
 	})
 }
 
+// @Summary Update Additional Info Field
+// @Security ApiKeyAuth
+// @Tags additional-field
+// @Description update title or options of custom field, the type can't be changed
+// @ID update-additional-field
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Field id"
+// @Param input body models.AdditionalInfoFieldUpdate true "Field info"
+// @Success 200 {object} statusResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/additional-field/{id} [put]
 func (h *Handler) updateAdditionalField(c *gin.Context) {
 	userID, err := getUserIDFromCtx(c)
 	if err != nil {
@@ -82,27 +134,27 @@ func (h *Handler) updateAdditionalField(c *gin.Context) {
 		return
 	}
 
-	tagID, err := uuid.Parse(c.Param("id"))
+	fieldID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
 		return
 	}
 
-	var payload models.Tag
+	var payload models.AdditionalInfoFieldUpdate
 	if err := c.BindJSON(&payload); err != nil {
 		newErrorResponse(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	_, err = h.services.Tag.GetByID(userID, tagID)
+	_, err = h.services.AdditionalInfoField.GetByID(userID, fieldID)
 	if err != nil {
-		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("tag not found: %s", err.Error()))
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("field not found: %s", err.Error()))
 		return
 	}
 
-	err = h.services.Tag.Update(userID, tagID, payload)
+	err = h.services.AdditionalInfoField.Update(userID, fieldID, payload)
 	if err != nil {
-		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
@@ -111,6 +163,19 @@ func (h *Handler) updateAdditionalField(c *gin.Context) {
 	})
 }
 
+// @Summary Delete Additional Info Field
+// @Security ApiKeyAuth
+// @Tags additional-field
+// @Description delete custom field with its values
+// @ID delete-additional-field
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Field id"
+// @Success 200 {object} statusResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/additional-field/{id} [delete]
 func (h *Handler) deleteAdditionalField(c *gin.Context) {
 	userID, err := getUserIDFromCtx(c)
 	if err != nil {
@@ -118,19 +183,175 @@ func (h *Handler) deleteAdditionalField(c *gin.Context) {
 		return
 	}
 
-	tagID, err := uuid.Parse(c.Param("id"))
+	fieldID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	_, err = h.services.AdditionalInfoField.GetByID(userID, fieldID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("field not found or already deleted: %s", err.Error()))
+		return
+	}
+
+	err = h.services.AdditionalInfoField.DeleteByID(userID, fieldID)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
+	})
+}
+
+// @Summary Get Friend Additional Info Fields
+// @Security ApiKeyAuth
+// @Tags friend
+// @Description get custom fields of friend with values
+// @ID get-friend-additional-fields
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Friend id"
+// @Success 200 {object} getFriendAdditionalInfoFieldsResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/friend/{id}/field [get]
+func (h *Handler) getFriendAdditionalFields(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	friendID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	_, err = h.services.Friend.GetByID(userID, friendID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("friend not found: %s", err.Error()))
+		return
+	}
+
+	fields, err := h.services.AdditionalInfoField.GetAllByFriendID(friendID)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, getFriendAdditionalInfoFieldsResponse{
+		Data: fields,
+	})
+}
+
+// @Summary Set Friend Additional Info Field Value
+// @Security ApiKeyAuth
+// @Tags friend
+// @Description set value of custom field for friend, null clears the value
+// @ID set-friend-additional-field
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Friend id"
+// @Param field_id path string true "Field id"
+// @Param input body models.AdditionalInfoFieldValueInput true "Value"
+// @Success 200 {object} statusResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/friend/{id}/field/{field_id} [put]
+func (h *Handler) setFriendAdditionalField(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	friendID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	fieldID, err := uuid.Parse(c.Param("field_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	var payload models.AdditionalInfoFieldValueInput
+	if err := c.BindJSON(&payload); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	_, err = h.services.Friend.GetByID(userID, friendID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("friend not found: %s", err.Error()))
+		return
+	}
+
+	_, err = h.services.AdditionalInfoField.GetByID(userID, fieldID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("field not found: %s", err.Error()))
+		return
+	}
+
+	err = h.services.AdditionalInfoField.SetValue(userID, friendID, fieldID, payload.Value)
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
+	})
+}
+
+// @Summary Delete Additional Info Field From Friend
+// @Security ApiKeyAuth
+// @Tags friend
+// @Description detach custom field from friend with its value
+// @ID delete-friend-additional-field
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Friend id"
+// @Param field_id path string true "Field id"
+// @Success 200 {object} statusResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/friend/{id}/field/{field_id} [delete]
+func (h *Handler) deleteFriendAdditionalField(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	friendID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	fieldID, err := uuid.Parse(c.Param("field_id"))
 	if err != nil {
 		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
 		return
 	}
 
-	_, err = h.services.Tag.GetByID(userID, tagID)
+	_, err = h.services.Friend.GetByID(userID, friendID)
 	if err != nil {
-		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("tag not found or already deleted: %s", err.Error()))
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("friend not found: %s", err.Error()))
 		return
 	}
 
-	err = h.services.Tag.DeleteByID(userID, tagID)
+	err = h.services.AdditionalInfoField.DeleteFromFriend(userID, friendID, fieldID)
 	if err != nil {
 		newErrorResponse(c, http.StatusInternalServerError, err.Error())
 		return
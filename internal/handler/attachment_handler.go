@@ -0,0 +1,332 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/lunovoy/friendly/internal/models"
+	"github.com/lunovoy/friendly/internal/service"
+	"github.com/lunovoy/friendly/internal/storage"
+	"github.com/sirupsen/logrus"
+)
+
+// @Summary Upload Attachment
+// @Security ApiKeyAuth
+// @Tags attachment
+// @Description upload document attached to a friend or an event, mime type is detected from the content
+// @ID upload-attachment
+// @Accept  multipart/form-data
+// @Produce  json
+// @Param file formData file true "File to upload"
+// @Param friend_id formData string false "Friend id"
+// @Param event_id formData string false "Event id"
+// @Success 201 {object} any
+// @Failure 400,404 {object} errorResponse
+// @Failure 413 {object} errorResponse "File is too large or storage quota exceeded"
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/attachment [post]
+func (h *Handler) uploadAttachment(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxAttachmentSize+1<<20)
+	file, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			newErrorResponse(c, http.StatusRequestEntityTooLarge, "file size exceeds the maximum allowed size 20MB")
+			return
+		}
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if file.Size > maxAttachmentSize {
+		newErrorResponse(c, http.StatusRequestEntityTooLarge, "file size exceeds the maximum allowed size 20MB")
+		return
+	}
+
+	attachment := models.Attachment{
+		FileName: file.Filename,
+		Size:     file.Size,
+	}
+	if param := c.PostForm("friend_id"); param != "" {
+		friendID, err := uuid.Parse(param)
+		if err != nil {
+			newErrorResponse(c, http.StatusBadRequest, "invalid friend_id param")
+			return
+		}
+		if _, err := h.services.Friend.GetByID(userID, friendID); err != nil {
+			newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("friend not found: %s", err.Error()))
+			return
+		}
+		attachment.FriendID = uuid.NullUUID{UUID: friendID, Valid: true}
+	}
+	if param := c.PostForm("event_id"); param != "" {
+		eventID, err := uuid.Parse(param)
+		if err != nil {
+			newErrorResponse(c, http.StatusBadRequest, "invalid event_id param")
+			return
+		}
+		if _, err := h.services.Event.GetByID(userID, eventID); err != nil {
+			newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("event not found: %s", err.Error()))
+			return
+		}
+		attachment.EventID = uuid.NullUUID{UUID: eventID, Valid: true}
+	}
+
+	// The type sent by the client is not trusted, it is sniffed from the first bytes
+	src, err := file.Open()
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, fmt.Sprintf("error reading file: %s", err.Error()))
+		return
+	}
+	head := make([]byte, 512)
+	n, err := io.ReadFull(src, head)
+	src.Close()
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		newErrorResponse(c, http.StatusInternalServerError, fmt.Sprintf("error reading file: %s", err.Error()))
+		return
+	}
+	attachment.MimeType = http.DetectContentType(head[:n])
+
+	attachmentID, err := h.services.Attachment.Create(userID, attachment)
+	if err != nil {
+		if errors.Is(err, service.ErrStorageQuotaExceeded) {
+			newErrorResponse(c, http.StatusRequestEntityTooLarge, err.Error())
+			return
+		}
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.saveFile(c, file, attachmentPrefix+attachmentID.String(), attachment.MimeType); err != nil {
+		if err := h.services.Attachment.DeleteByID(userID, attachmentID); err != nil {
+			logrus.Errorf("error deleting attachment %s: %s", attachmentID, err.Error())
+		}
+		newErrorResponse(c, http.StatusInternalServerError, fmt.Sprintf("error saving file: %s", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusCreated, map[string]any{
+		"attachment_id": attachmentID,
+	})
+}
+
+// @Summary Get All Attachments
+// @Security ApiKeyAuth
+// @Tags attachment
+// @Description get attachments metadata, the latest first
+// @ID get-all-attachments
+// @Accept  json
+// @Produce  json
+// @Param friend_id query string false "Attachments of friend"
+// @Param event_id query string false "Attachments of event"
+// @Success 200 {object} getAllAttachmentsResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/attachment [get]
+func (h *Handler) getAllAttachments(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	var filter models.AttachmentFilter
+	if filter.FriendID, err = parseUUIDQuery(c, "friend_id"); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if filter.EventID, err = parseUUIDQuery(c, "event_id"); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	attachments, err := h.services.Attachment.GetAll(userID, filter)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, getAllAttachmentsResponse{
+		Data: attachments,
+	})
+}
+
+// @Summary Get Storage Usage
+// @Security ApiKeyAuth
+// @Tags attachment
+// @Description get size of stored attachments and storage quota in bytes
+// @ID get-storage-usage
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} models.StorageUsage
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/attachment/usage [get]
+func (h *Handler) getStorageUsage(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	usage, err := h.services.Attachment.GetUsage(userID)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, usage)
+}
+
+// @Summary Get Attachment By Id
+// @Security ApiKeyAuth
+// @Tags attachment
+// @Description get attachment metadata by id
+// @ID get-attachment-by-id
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Attachment id"
+// @Success 200 {object} models.Attachment
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/attachment/{id} [get]
+func (h *Handler) getAttachmentByID(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	attachmentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	attachment, err := h.services.Attachment.GetByID(userID, attachmentID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("attachment not found: %s", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]any{
+		"attachment": attachment,
+	})
+}
+
+// @Summary Download Attachment
+// @Security ApiKeyAuth
+// @Tags attachment
+// @Description download attachment file
+// @ID download-attachment
+// @Accept  json
+// @Produce  octet-stream
+// @Param id path string true "Attachment id"
+// @Success 200 {file} file
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/attachment/{id}/download [get]
+func (h *Handler) downloadAttachment(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	attachmentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	attachment, err := h.services.Attachment.GetByID(userID, attachmentID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("attachment not found: %s", err.Error()))
+		return
+	}
+
+	key := attachmentPrefix + attachment.ID.String()
+	object, err := h.storage.Stat(c.Request.Context(), key)
+	if errors.Is(err, storage.ErrNotFound) {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("attachment file not found: %s", err.Error()))
+		return
+	}
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	file, err := h.storage.Get(c.Request.Context(), key)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	defer file.Close()
+
+	// Uploaded content is never rendered inline by the browser
+	c.DataFromReader(http.StatusOK, object.Size, attachment.MimeType, file, map[string]string{
+		"Content-Disposition":    mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}),
+		"X-Content-Type-Options": "nosniff",
+	})
+}
+
+// @Summary Delete Attachment
+// @Security ApiKeyAuth
+// @Tags attachment
+// @Description delete attachment with its file
+// @ID delete-attachment
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Attachment id"
+// @Success 200 {object} statusResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/attachment/{id} [delete]
+func (h *Handler) deleteAttachment(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	attachmentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	_, err = h.services.Attachment.GetByID(userID, attachmentID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("attachment not found: %s", err.Error()))
+		return
+	}
+
+	if err := h.services.Attachment.DeleteByID(userID, attachmentID); err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if err := h.deleteFile(c.Request.Context(), attachmentPrefix+attachmentID.String()); err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
+	})
+}
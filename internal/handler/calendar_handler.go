@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const defaultCalendarDays = 30
+
+// @Summary Get Calendar
+// @Security ApiKeyAuth
+// @Tags calendar
+// @Description get event, birthday and important date occurrences within a date range
+// @ID get-calendar
+// @Accept  json
+// @Produce  json
+// @Param from query string false "Range start, RFC3339 or YYYY-MM-DD (default now)"
+// @Param to query string false "Range end, RFC3339 or YYYY-MM-DD (default from + 30 days)"
+// @Success 200 {object} getCalendarResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/calendar [get]
+func (h *Handler) getCalendar(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	from := time.Now()
+	if param := c.Query("from"); param != "" {
+		from, err = parseDateParam(param)
+		if err != nil {
+			newErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("invalid from param: %s", err.Error()))
+			return
+		}
+	}
+	to := from.AddDate(0, 0, defaultCalendarDays)
+	if param := c.Query("to"); param != "" {
+		to, err = parseDateParam(param)
+		if err != nil {
+			newErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("invalid to param: %s", err.Error()))
+			return
+		}
+	}
+
+	occurrences, err := h.services.Calendar.GetOccurrences(userID, from, to)
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, getCalendarResponse{
+		Data: occurrences,
+	})
+}
@@ -0,0 +1,287 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/lunovoy/friendly/internal/models"
+)
+
+// @Summary Create Contact Point
+// @Security ApiKeyAuth
+// @Tags friend
+// @Description create contact point of friend (phone, email, telegram, whatsapp, signal, social, address), the first one of a kind becomes primary
+// @ID create-contact-point
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Friend id"
+// @Param input body models.ContactPoint true "Contact point info"
+// @Success 201 {object} any
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/friend/{id}/contacts [post]
+func (h *Handler) createContactPoint(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	friendID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	var contact models.ContactPoint
+	if err := c.BindJSON(&contact); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	_, err = h.services.Friend.GetByID(userID, friendID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("friend not found: %s", err.Error()))
+		return
+	}
+
+	contactID, err := h.services.ContactPoint.Create(userID, friendID, contact)
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, map[string]any{
+		"contact_id": contactID,
+	})
+}
+
+// @Summary Get All Contact Points
+// @Security ApiKeyAuth
+// @Tags friend
+// @Description get all contact points of friend
+// @ID get-all-contact-points
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Friend id"
+// @Success 200 {object} getAllContactPointsResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/friend/{id}/contacts [get]
+func (h *Handler) getAllContactPoints(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	friendID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	_, err = h.services.Friend.GetByID(userID, friendID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("friend not found: %s", err.Error()))
+		return
+	}
+
+	contacts, err := h.services.ContactPoint.GetAllByFriendID(userID, friendID)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, getAllContactPointsResponse{
+		Data: contacts,
+	})
+}
+
+// @Summary Get Contact Point By Id
+// @Security ApiKeyAuth
+// @Tags friend
+// @Description get contact point of friend by id
+// @ID get-contact-point-by-id
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Friend id"
+// @Param contact_id path string true "Contact point id"
+// @Success 200 {object} models.ContactPoint
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/friend/{id}/contacts/{contact_id} [get]
+func (h *Handler) getContactPointByID(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	friendID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	contactID, err := uuid.Parse(c.Param("contact_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	contact, err := h.services.ContactPoint.GetByID(userID, friendID, contactID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("contact point not found: %s", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]any{
+		"contact": contact,
+	})
+}
+
+// @Summary Update Contact Point
+// @Security ApiKeyAuth
+// @Tags friend
+// @Description update contact point of friend, making it primary resets the other primary of the kind
+// @ID update-contact-point
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Friend id"
+// @Param contact_id path string true "Contact point id"
+// @Param input body models.ContactPointUpdate true "Contact point info"
+// @Success 200 {object} statusResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/friend/{id}/contacts/{contact_id} [put]
+func (h *Handler) updateContactPoint(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	friendID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	contactID, err := uuid.Parse(c.Param("contact_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	var update models.ContactPointUpdate
+	if err := c.BindJSON(&update); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	_, err = h.services.ContactPoint.GetByID(userID, friendID, contactID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("contact point not found: %s", err.Error()))
+		return
+	}
+
+	if err := h.services.ContactPoint.Update(userID, friendID, contactID, update); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
+	})
+}
+
+// @Summary Delete Contact Point
+// @Security ApiKeyAuth
+// @Tags friend
+// @Description delete contact point of friend
+// @ID delete-contact-point
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Friend id"
+// @Param contact_id path string true "Contact point id"
+// @Success 200 {object} statusResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/friend/{id}/contacts/{contact_id} [delete]
+func (h *Handler) deleteContactPoint(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	friendID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	contactID, err := uuid.Parse(c.Param("contact_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	_, err = h.services.ContactPoint.GetByID(userID, friendID, contactID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("contact point not found: %s", err.Error()))
+		return
+	}
+
+	if err := h.services.ContactPoint.DeleteByID(userID, contactID); err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
+	})
+}
+
+// @Summary Export Friend vCard
+// @Security ApiKeyAuth
+// @Tags friend
+// @Description export friend with contact points as vCard 3.0
+// @ID export-friend-vcard
+// @Produce  text/vcard
+// @Param id path string true "Friend id"
+// @Success 200 {string} string
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/friend/{id}/vcard [get]
+func (h *Handler) exportFriendVCard(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	friendID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	card, err := h.services.Friend.GetVCard(userID, friendID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("friend not found: %s", err.Error()))
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.vcf"`, friendID))
+	c.Data(http.StatusOK, "text/vcard; charset=utf-8", []byte(card))
+}
@@ -0,0 +1,252 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/lunovoy/friendly/internal/models"
+)
+
+// @Summary Create Employment
+// @Security ApiKeyAuth
+// @Tags friend
+// @Description create employment record of friend, the current one is shown in work_info
+// @ID create-employment
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Friend id"
+// @Param input body models.Employment true "Employment info"
+// @Success 201 {object} any
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/friend/{id}/employment [post]
+func (h *Handler) createEmployment(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	friendID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	var employment models.Employment
+	if err := c.BindJSON(&employment); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	_, err = h.services.Friend.GetByID(userID, friendID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("friend not found: %s", err.Error()))
+		return
+	}
+
+	employmentID, err := h.services.Employment.Create(userID, friendID, employment)
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, map[string]any{
+		"employment_id": employmentID,
+	})
+}
+
+// @Summary Get All Employments
+// @Security ApiKeyAuth
+// @Tags friend
+// @Description get employment history of friend, the latest first
+// @ID get-all-employments
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Friend id"
+// @Success 200 {object} getAllEmploymentsResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/friend/{id}/employment [get]
+func (h *Handler) getAllEmployments(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	friendID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	_, err = h.services.Friend.GetByID(userID, friendID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("friend not found: %s", err.Error()))
+		return
+	}
+
+	employments, err := h.services.Employment.GetAllByFriendID(userID, friendID)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, getAllEmploymentsResponse{
+		Data: employments,
+	})
+}
+
+// @Summary Get Employment By Id
+// @Security ApiKeyAuth
+// @Tags friend
+// @Description get employment record of friend by id
+// @ID get-employment-by-id
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Friend id"
+// @Param employment_id path string true "Employment id"
+// @Success 200 {object} models.Employment
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/friend/{id}/employment/{employment_id} [get]
+func (h *Handler) getEmploymentByID(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	friendID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	employmentID, err := uuid.Parse(c.Param("employment_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	employment, err := h.services.Employment.GetByID(userID, friendID, employmentID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("employment not found: %s", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]any{
+		"employment": employment,
+	})
+}
+
+// @Summary Update Employment
+// @Security ApiKeyAuth
+// @Tags friend
+// @Description update employment record of friend, current true removes the end date
+// @ID update-employment
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Friend id"
+// @Param employment_id path string true "Employment id"
+// @Param input body models.EmploymentUpdate true "Employment info"
+// @Success 200 {object} statusResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/friend/{id}/employment/{employment_id} [put]
+func (h *Handler) updateEmployment(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	friendID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	employmentID, err := uuid.Parse(c.Param("employment_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	var update models.EmploymentUpdate
+	if err := c.BindJSON(&update); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	_, err = h.services.Employment.GetByID(userID, friendID, employmentID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("employment not found: %s", err.Error()))
+		return
+	}
+
+	if err := h.services.Employment.Update(userID, friendID, employmentID, update); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
+	})
+}
+
+// @Summary Delete Employment
+// @Security ApiKeyAuth
+// @Tags friend
+// @Description delete employment record of friend
+// @ID delete-employment
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Friend id"
+// @Param employment_id path string true "Employment id"
+// @Success 200 {object} statusResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/friend/{id}/employment/{employment_id} [delete]
+func (h *Handler) deleteEmployment(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	friendID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	employmentID, err := uuid.Parse(c.Param("employment_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	_, err = h.services.Employment.GetByID(userID, friendID, employmentID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("employment not found: %s", err.Error()))
+		return
+	}
+
+	if err := h.services.Employment.DeleteByID(userID, friendID, employmentID); err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
+	})
+}
@@ -3,6 +3,7 @@ package handler
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -155,10 +156,19 @@ func (h *Handler) getEventsByFriendID(c *gin.Context) {
 // @Summary Get All Events
 // @Security ApiKeyAuth
 // @Tags event
-// @Description get all events
+// @Description get page of events, filtered and sorted
 // @ID get-all-events
 // @Accept  json
 // @Produce  json
+// @Param friend_id query string false "Events with friend"
+// @Param is_active query bool false "Active or inactive events"
+// @Param frequency query string false "Events with frequency"
+// @Param start_from query string false "Start date not before, RFC3339 or YYYY-MM-DD"
+// @Param start_to query string false "Start date not after, RFC3339 or YYYY-MM-DD"
+// @Param limit query int false "Page size, 1-200 (default 50)"
+// @Param sort query string false "Sort key: start_date (default), title"
+// @Param order query string false "asc (default) or desc"
+// @Param cursor query string false "next_cursor of the previous page"
 // @Success 200 {object} getAllEventsResponse
 // @Failure 400,404 {object} errorResponse
 // @Failure 500 {object} errorResponse
@@ -171,14 +181,45 @@ func (h *Handler) getAllEvents(c *gin.Context) {
 		return
 	}
 
-	events, err := h.services.Event.GetAll(userID)
+	params, err := parseListParams(c)
 	if err != nil {
-		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	filter := models.EventFilter{
+		Frequency: c.Query("frequency"),
+	}
+	if filter.FriendID, err = parseUUIDQuery(c, "friend_id"); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if param := c.Query("is_active"); param != "" {
+		isActive, err := strconv.ParseBool(param)
+		if err != nil {
+			newErrorResponse(c, http.StatusBadRequest, "invalid is_active param")
+			return
+		}
+		filter.IsActive = &isActive
+	}
+	if filter.StartFrom, err = parseDateQuery(c, "start_from"); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if filter.StartTo, err = parseDateQuery(c, "start_to"); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	events, next, err := h.services.Event.List(userID, filter, params)
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	c.JSON(http.StatusOK, getAllEventsResponse{
-		Data: events,
+		Data:       events,
+		NextCursor: next,
 	})
 
 }
@@ -406,7 +447,7 @@ func (h *Handler) updateEvent(c *gin.Context) {
 // @Summary Delete Event
 // @Security ApiKeyAuth
 // @Tags event
-// @Description delete event
+// @Description move event to trash, it can be restored until purged
 // @ID delete-event
 // @Accept  json
 // @Produce  json
@@ -0,0 +1,179 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/jdeng/goheif"
+)
+
+const (
+	exifTagDateTime           = 0x0132
+	exifTagExifIFD            = 0x8769
+	exifTagDateTimeOriginal   = 0x9003
+	exifTagDateTimeDigitized  = 0x9004
+	exifTagOffsetTimeOriginal = 0x9011
+	exifTypeASCII             = 2
+	exifTypeLong              = 4
+)
+
+// imageTakenAt reads the date the photo was taken from EXIF of a stored JPEG,
+// PNG or HEIC image, ok is false when the image has no date.
+func (h *Handler) imageTakenAt(ctx context.Context, key string) (time.Time, bool) {
+	file, err := h.storage.Get(ctx, key)
+	if err != nil {
+		return time.Time{}, false
+	}
+	defer file.Close()
+	data, err := io.ReadAll(io.LimitReader(file, maxFileSize))
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	var tiff []byte
+	switch strings.ToLower(path.Ext(key)) {
+	case ".jpg", ".jpeg":
+		tiff = jpegExif(data)
+	case ".png":
+		tiff = pngExif(data)
+	case ".heic", ".heif":
+		if tiff, err = goheif.ExtractExif(bytes.NewReader(data)); err != nil {
+			return time.Time{}, false
+		}
+		tiff = bytes.TrimPrefix(tiff, []byte("Exif\x00\x00"))
+	}
+
+	return exifDate(tiff)
+}
+
+// jpegExif returns the TIFF part of the APP1 Exif segment.
+func jpegExif(data []byte) []byte {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return nil
+	}
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xFF {
+			return nil
+		}
+		marker := data[i+1]
+		// Image data follows start of scan, no metadata after it
+		if marker == 0xDA || marker == 0xD9 {
+			return nil
+		}
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+		end := i + 2 + length
+		if length < 2 || end > len(data) {
+			return nil
+		}
+		if marker == 0xE1 && bytes.HasPrefix(data[i+4:end], []byte("Exif\x00\x00")) {
+			return data[i+10 : end]
+		}
+		i = end
+	}
+	return nil
+}
+
+// pngExif returns the eXIf chunk.
+func pngExif(data []byte) []byte {
+	if !bytes.HasPrefix(data, []byte("\x89PNG\r\n\x1a\n")) {
+		return nil
+	}
+	for i := 8; i+12 <= len(data); {
+		length := int(binary.BigEndian.Uint32(data[i:]))
+		end := i + 12 + length
+		if length < 0 || end > len(data) {
+			return nil
+		}
+		if string(data[i+4:i+8]) == "eXIf" {
+			return data[i+8 : i+8+length]
+		}
+		i = end
+	}
+	return nil
+}
+
+// exifDate prefers the original date over the digitized one and the date of
+// the last change, the time is taken as UTC without an offset tag.
+func exifDate(tiff []byte) (time.Time, bool) {
+	if len(tiff) < 8 {
+		return time.Time{}, false
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return time.Time{}, false
+	}
+	if order.Uint16(tiff[2:]) != 42 {
+		return time.Time{}, false
+	}
+
+	ifd0 := exifEntries(tiff, order, order.Uint32(tiff[4:]))
+	tags := make(map[uint16]string)
+	if value, ok := ifd0[exifTagDateTime]; ok {
+		tags[exifTagDateTime] = value
+	}
+	if offset, ok := ifd0[exifTagExifIFD]; ok && len(offset) == 4 {
+		for tag, value := range exifEntries(tiff, order, order.Uint32([]byte(offset))) {
+			tags[tag] = value
+		}
+	}
+
+	for _, tag := range []uint16{exifTagDateTimeOriginal, exifTagDateTimeDigitized, exifTagDateTime} {
+		value, ok := tags[tag]
+		if !ok {
+			continue
+		}
+		layout, value := "2006:01:02 15:04:05", strings.TrimRight(value, "\x00 ")
+		if offset := strings.TrimRight(tags[exifTagOffsetTimeOriginal], "\x00 "); tag == exifTagDateTimeOriginal && offset != "" {
+			layout, value = layout+"-07:00", value+offset
+		}
+		if takenAt, err := time.Parse(layout, value); err == nil {
+			return takenAt, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// exifEntries reads ASCII values and long offsets of the IFD at offset, long
+// values are returned as their 4 raw bytes.
+func exifEntries(tiff []byte, order binary.ByteOrder, offset uint32) map[uint16]string {
+	entries := make(map[uint16]string)
+	start := int(offset)
+	if start < 8 || start+2 > len(tiff) {
+		return entries
+	}
+	count := int(order.Uint16(tiff[start:]))
+	for i := 0; i < count; i++ {
+		entry := start + 2 + i*12
+		if entry+12 > len(tiff) {
+			break
+		}
+		tag := order.Uint16(tiff[entry:])
+		valueType := order.Uint16(tiff[entry+2:])
+		valueCount := int(order.Uint32(tiff[entry+4:]))
+		value := tiff[entry+8 : entry+12]
+
+		switch {
+		case valueType == exifTypeLong && valueCount == 1:
+			entries[tag] = string(value)
+		case valueType == exifTypeASCII && valueCount <= 4:
+			entries[tag] = string(value[:valueCount])
+		case valueType == exifTypeASCII:
+			valueOffset := int(order.Uint32(value))
+			if valueOffset < 0 || valueOffset+valueCount > len(tiff) {
+				continue
+			}
+			entries[tag] = string(tiff[valueOffset : valueOffset+valueCount])
+		}
+	}
+	return entries
+}
@@ -0,0 +1,222 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/lunovoy/friendly/internal/storage"
+)
+
+type exifEntry struct {
+	tag   uint16
+	value string
+}
+
+// buildTIFF lays out IFD0 with the entries and a pointer to the Exif IFD
+// when there are exif entries, ASCII values go after both IFDs.
+func buildTIFF(order binary.ByteOrder, ifd0, exif []exifEntry) []byte {
+	ifd0Count := len(ifd0)
+	if len(exif) != 0 {
+		ifd0Count++
+	}
+	exifOffset := 8 + 2 + 12*ifd0Count + 4
+	dataOffset := exifOffset
+	if len(exif) != 0 {
+		dataOffset += 2 + 12*len(exif) + 4
+	}
+
+	var ifds, data bytes.Buffer
+	writeIFD := func(entries []exifEntry, exifPointer bool) {
+		count := len(entries)
+		if exifPointer {
+			count++
+		}
+		binary.Write(&ifds, order, uint16(count))
+		for _, entry := range entries {
+			value := entry.value + "\x00"
+			binary.Write(&ifds, order, entry.tag)
+			binary.Write(&ifds, order, uint16(exifTypeASCII))
+			binary.Write(&ifds, order, uint32(len(value)))
+			if len(value) <= 4 {
+				ifds.WriteString(value + string(make([]byte, 4-len(value))))
+				continue
+			}
+			binary.Write(&ifds, order, uint32(dataOffset+data.Len()))
+			data.WriteString(value)
+		}
+		if exifPointer {
+			binary.Write(&ifds, order, uint16(exifTagExifIFD))
+			binary.Write(&ifds, order, uint16(exifTypeLong))
+			binary.Write(&ifds, order, uint32(1))
+			binary.Write(&ifds, order, uint32(exifOffset))
+		}
+		binary.Write(&ifds, order, uint32(0))
+	}
+	writeIFD(ifd0, len(exif) != 0)
+	if len(exif) != 0 {
+		writeIFD(exif, false)
+	}
+
+	var tiff bytes.Buffer
+	if order == binary.LittleEndian {
+		tiff.WriteString("II")
+	} else {
+		tiff.WriteString("MM")
+	}
+	binary.Write(&tiff, order, uint16(42))
+	binary.Write(&tiff, order, uint32(8))
+	tiff.Write(ifds.Bytes())
+	tiff.Write(data.Bytes())
+	return tiff.Bytes()
+}
+
+func buildJPEG(tiff []byte) []byte {
+	var jpeg bytes.Buffer
+	jpeg.Write([]byte{0xFF, 0xD8})
+	jpeg.Write([]byte{0xFF, 0xE0, 0x00, 0x06})
+	jpeg.WriteString("JFIF")
+	if tiff != nil {
+		jpeg.Write([]byte{0xFF, 0xE1})
+		binary.Write(&jpeg, binary.BigEndian, uint16(2+6+len(tiff)))
+		jpeg.WriteString("Exif\x00\x00")
+		jpeg.Write(tiff)
+	}
+	jpeg.Write([]byte{0xFF, 0xDA, 0x00, 0x02, 0x01, 0x02, 0xFF, 0xD9})
+	return jpeg.Bytes()
+}
+
+func buildPNG(tiff []byte) []byte {
+	var png bytes.Buffer
+	png.WriteString("\x89PNG\r\n\x1a\n")
+	chunk := func(kind string, data []byte) {
+		binary.Write(&png, binary.BigEndian, uint32(len(data)))
+		png.WriteString(kind)
+		png.Write(data)
+		png.Write([]byte{0, 0, 0, 0})
+	}
+	chunk("IHDR", make([]byte, 13))
+	chunk("eXIf", tiff)
+	chunk("IEND", nil)
+	return png.Bytes()
+}
+
+func TestExifDate(t *testing.T) {
+	tests := []struct {
+		name   string
+		tiff   []byte
+		want   time.Time
+		wantOK bool
+	}{
+		{
+			name: "original date with offset",
+			tiff: buildTIFF(binary.LittleEndian, nil, []exifEntry{
+				{exifTagDateTimeOriginal, "2023:07:14 18:30:05"},
+				{exifTagOffsetTimeOriginal, "+03:00"},
+			}),
+			want:   time.Date(2023, time.July, 14, 15, 30, 5, 0, time.UTC),
+			wantOK: true,
+		},
+		{
+			name:   "date of the last change in big endian",
+			tiff:   buildTIFF(binary.BigEndian, []exifEntry{{exifTagDateTime, "2021:01:02 03:04:05"}}, nil),
+			want:   time.Date(2021, time.January, 2, 3, 4, 5, 0, time.UTC),
+			wantOK: true,
+		},
+		{
+			name: "original date wins",
+			tiff: buildTIFF(binary.LittleEndian, []exifEntry{{exifTagDateTime, "2021:01:02 03:04:05"}}, []exifEntry{
+				{exifTagDateTimeDigitized, "2020:05:06 07:08:09"},
+				{exifTagDateTimeOriginal, "2019:10:11 12:13:14"},
+			}),
+			want:   time.Date(2019, time.October, 11, 12, 13, 14, 0, time.UTC),
+			wantOK: true,
+		},
+		{
+			name: "invalid date falls back",
+			tiff: buildTIFF(binary.LittleEndian, []exifEntry{{exifTagDateTime, "2021:01:02 03:04:05"}}, []exifEntry{
+				{exifTagDateTimeOriginal, "    :  :     :  :  "},
+			}),
+			want:   time.Date(2021, time.January, 2, 3, 4, 5, 0, time.UTC),
+			wantOK: true,
+		},
+		{
+			name: "no date",
+			tiff: buildTIFF(binary.LittleEndian, []exifEntry{{0x010F, "Canon"}}, nil),
+		},
+		{
+			name: "not tiff",
+			tiff: []byte("XX\x2a\x00\x08\x00\x00\x00"),
+		},
+		{
+			name: "truncated",
+			tiff: buildTIFF(binary.LittleEndian, []exifEntry{{exifTagDateTime, "2021:01:02 03:04:05"}}, nil)[:20],
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := exifDate(tt.tiff)
+			if ok != tt.wantOK || !got.Equal(tt.want) {
+				t.Errorf("exifDate() = %s, %v, want %s, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestJPEGExif(t *testing.T) {
+	tiff := buildTIFF(binary.LittleEndian, []exifEntry{{exifTagDateTime, "2021:01:02 03:04:05"}}, nil)
+
+	if got := jpegExif(buildJPEG(tiff)); !bytes.Equal(got, tiff) {
+		t.Errorf("jpegExif() = %q, want %q", got, tiff)
+	}
+	if got := jpegExif(buildJPEG(nil)); got != nil {
+		t.Errorf("jpegExif() without exif = %q, want nil", got)
+	}
+	if got := jpegExif(buildJPEG(tiff)[:20]); got != nil {
+		t.Errorf("jpegExif() of a truncated file = %q, want nil", got)
+	}
+	if got := jpegExif(buildPNG(tiff)); got != nil {
+		t.Errorf("jpegExif() of png = %q, want nil", got)
+	}
+}
+
+func TestPNGExif(t *testing.T) {
+	tiff := buildTIFF(binary.BigEndian, []exifEntry{{exifTagDateTime, "2021:01:02 03:04:05"}}, nil)
+
+	if got := pngExif(buildPNG(tiff)); !bytes.Equal(got, tiff) {
+		t.Errorf("pngExif() = %q, want %q", got, tiff)
+	}
+	if got := pngExif(buildPNG(tiff)[:40]); got != nil {
+		t.Errorf("pngExif() of a truncated file = %q, want nil", got)
+	}
+	if got := pngExif(buildJPEG(tiff)); got != nil {
+		t.Errorf("pngExif() of jpeg = %q, want nil", got)
+	}
+}
+
+func TestImageTakenAt(t *testing.T) {
+	ctx := context.Background()
+	store := storage.NewLocal(t.TempDir())
+	h := &Handler{storage: store}
+	tiff := buildTIFF(binary.LittleEndian, nil, []exifEntry{{exifTagDateTimeOriginal, "2023:07:14 18:30:05"}})
+	want := time.Date(2023, time.July, 14, 18, 30, 5, 0, time.UTC)
+
+	for key, data := range map[string][]byte{
+		"images/a.JPG": buildJPEG(tiff),
+		"images/b.png": buildPNG(tiff),
+	} {
+		if err := store.Put(ctx, key, bytes.NewReader(data), ""); err != nil {
+			t.Fatal(err)
+		}
+		if got, ok := h.imageTakenAt(ctx, key); !ok || !got.Equal(want) {
+			t.Errorf("imageTakenAt(%s) = %s, %v, want %s", key, got, ok, want)
+		}
+	}
+
+	if _, ok := h.imageTakenAt(ctx, "images/missing.jpg"); ok {
+		t.Error("imageTakenAt() of a missing image is ok")
+	}
+}
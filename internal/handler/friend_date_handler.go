@@ -0,0 +1,252 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/lunovoy/friendly/internal/models"
+)
+
+// @Summary Create Friend Date
+// @Security ApiKeyAuth
+// @Tags friend
+// @Description create important date of friend (anniversary, name day, etc.)
+// @ID create-friend-date
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Friend id"
+// @Param input body models.FriendDate true "Date info"
+// @Success 201 {object} any
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/friend/{id}/dates [post]
+func (h *Handler) createFriendDate(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	friendID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	var date models.FriendDate
+	if err := c.BindJSON(&date); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	_, err = h.services.Friend.GetByID(userID, friendID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("friend not found: %s", err.Error()))
+		return
+	}
+
+	dateID, err := h.services.FriendDate.Create(userID, friendID, date)
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, map[string]any{
+		"date_id": dateID,
+	})
+}
+
+// @Summary Get All Friend Dates
+// @Security ApiKeyAuth
+// @Tags friend
+// @Description get all important dates of friend
+// @ID get-all-friend-dates
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Friend id"
+// @Success 200 {object} getAllFriendDatesResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/friend/{id}/dates [get]
+func (h *Handler) getAllFriendDates(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	friendID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	_, err = h.services.Friend.GetByID(userID, friendID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("friend not found: %s", err.Error()))
+		return
+	}
+
+	dates, err := h.services.FriendDate.GetAllByFriendID(userID, friendID)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, getAllFriendDatesResponse{
+		Data: dates,
+	})
+}
+
+// @Summary Get Friend Date By Id
+// @Security ApiKeyAuth
+// @Tags friend
+// @Description get important date of friend by id
+// @ID get-friend-date-by-id
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Friend id"
+// @Param date_id path string true "Date id"
+// @Success 200 {object} models.FriendDate
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/friend/{id}/dates/{date_id} [get]
+func (h *Handler) getFriendDateByID(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	friendID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	dateID, err := uuid.Parse(c.Param("date_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	date, err := h.services.FriendDate.GetByID(userID, friendID, dateID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("date not found: %s", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]any{
+		"date": date,
+	})
+}
+
+// @Summary Update Friend Date
+// @Security ApiKeyAuth
+// @Tags friend
+// @Description update important date of friend, year 0 removes the year
+// @ID update-friend-date
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Friend id"
+// @Param date_id path string true "Date id"
+// @Param input body models.FriendDateUpdate true "Date info"
+// @Success 200 {object} statusResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/friend/{id}/dates/{date_id} [put]
+func (h *Handler) updateFriendDate(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	friendID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	dateID, err := uuid.Parse(c.Param("date_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	var update models.FriendDateUpdate
+	if err := c.BindJSON(&update); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	_, err = h.services.FriendDate.GetByID(userID, friendID, dateID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("date not found: %s", err.Error()))
+		return
+	}
+
+	if err := h.services.FriendDate.Update(userID, friendID, dateID, update); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
+	})
+}
+
+// @Summary Delete Friend Date
+// @Security ApiKeyAuth
+// @Tags friend
+// @Description delete important date of friend
+// @ID delete-friend-date
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Friend id"
+// @Param date_id path string true "Date id"
+// @Success 200 {object} statusResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/friend/{id}/dates/{date_id} [delete]
+func (h *Handler) deleteFriendDate(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	friendID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	dateID, err := uuid.Parse(c.Param("date_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	_, err = h.services.FriendDate.GetByID(userID, friendID, dateID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("date not found: %s", err.Error()))
+		return
+	}
+
+	if err := h.services.FriendDate.DeleteByID(userID, dateID); err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
+	})
+}
@@ -1,15 +1,14 @@
 package handler
 
 import (
-	"database/sql"
 	"fmt"
-	"log"
 	"net/http"
-	"time"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/lunovoy/friendly/internal/models"
+	"github.com/sirupsen/logrus"
 )
 
 // @Summary Create Friend
@@ -38,6 +37,13 @@ func (h *Handler) createFriend(c *gin.Context) {
 		return
 	}
 
+	if payload.Friend != nil && payload.Friend.ImageID != nil {
+		if err := h.checkImageOwner(userID, *payload.Friend.ImageID); err != nil {
+			newErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
 	friendPayload := models.UpdateFriendWorkInfoInput{
 		Friend:   payload.Friend,
 		WorkInfo: payload.WorkInfo,
@@ -60,51 +66,6 @@ func (h *Handler) createFriend(c *gin.Context) {
 		}
 	}
 
-	friend, err := h.services.Friend.GetByID(userID, friendIDWorkID.FriendID)
-	if err != nil {
-		newErrorResponse(c, http.StatusInternalServerError, err.Error())
-		return
-	}
-	if friend.Friend.DOB.Valid {
-
-		eventDate := time.Date(time.Now().Year(), friend.Friend.DOB.Time.Month(), friend.Friend.DOB.Time.Day(), friend.Friend.DOB.Time.Hour(), friend.Friend.DOB.Time.Minute(), 0, 0, friend.Friend.DOB.Time.Location())
-		if eventDate.Before(time.Now()) {
-			eventDate = eventDate.AddDate(1, 0, 0)
-		}
-
-		eventID, err := h.services.Event.Create(userID, models.Event{
-			Title:       fmt.Sprintf("День рождение: %s %s", friend.Friend.FirstName, friend.Friend.LastName),
-			Description: fmt.Sprintf("%s %s", friend.WorkInfo.City, friend.WorkInfo.Company),
-			Frequency:   "annually",
-			StartDate: sql.NullTime{
-				Time:  eventDate,
-				Valid: true,
-			},
-			EndDate: sql.NullTime{
-				Time:  eventDate.Add(5 * time.Minute),
-				Valid: true,
-			},
-		})
-		if err != nil {
-			newErrorResponse(c, http.StatusInternalServerError, fmt.Sprintf("error while creating dob event of friend: %s", err.Error()))
-			return
-		}
-		log.Printf("event dob created: %s", eventID)
-		var friends []models.FriendID
-		friends = append(friends, models.FriendID{FriendID: friend.Friend.ID})
-		_, err = h.services.Event.AddFriendsToEvent(userID, eventID, friends)
-		if err != nil {
-			newErrorResponse(c, http.StatusInternalServerError, fmt.Sprintf("error while adding friend to event of friend dob: %s", err.Error()))
-			return
-		}
-		_, err = h.services.Reminder.Create(userID, models.Reminder{EventID: eventID, MinutesUntilEvent: 0})
-		if err != nil {
-			newErrorResponse(c, http.StatusInternalServerError, fmt.Sprintf("error while adding reminder to event of friend dob: %s", err.Error()))
-			return
-		}
-		friends = nil
-	}
-
 	c.JSON(http.StatusCreated, map[string]any{
 		"friend_id":    friendIDWorkID.FriendID,
 		"work_info_id": friendIDWorkID.WorkInfoID,
@@ -114,10 +75,21 @@ func (h *Handler) createFriend(c *gin.Context) {
 // @Summary Get All Friends
 // @Security ApiKeyAuth
 // @Tags friend
-// @Description get all friends
+// @Description get page of friends, filtered and sorted
 // @ID get-all-friends
 // @Accept  json
 // @Produce  json
+// @Param tag_id query string false "Friends with tag"
+// @Param friendlist_id query string false "Friends of friendlist"
+// @Param city query string false "City of work info, case insensitive"
+// @Param company query string false "Company of work info, case insensitive"
+// @Param dob_month query int false "Month of birth, 1-12"
+// @Param contacted_from query string false "Last contacted not before, RFC3339 or YYYY-MM-DD"
+// @Param contacted_to query string false "Last contacted not after, RFC3339 or YYYY-MM-DD"
+// @Param limit query int false "Page size, 1-200 (default 50)"
+// @Param sort query string false "Sort key: first_name (default), last_name, dob, last_contacted_at"
+// @Param order query string false "asc (default) or desc"
+// @Param cursor query string false "next_cursor of the previous page"
 // @Success 200 {object} getAllFriendsResponse
 // @Failure 400,404 {object} errorResponse
 // @Failure 500 {object} errorResponse
@@ -130,14 +102,63 @@ func (h *Handler) getAllFriends(c *gin.Context) {
 		return
 	}
 
-	friends, err := h.services.Friend.GetAll(userID)
+	params, err := parseListParams(c)
 	if err != nil {
-		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	filter := models.FriendFilter{
+		City:    c.Query("city"),
+		Company: c.Query("company"),
+	}
+	if filter.TagID, err = parseUUIDQuery(c, "tag_id"); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if filter.FriendlistID, err = parseUUIDQuery(c, "friendlist_id"); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if param := c.Query("dob_month"); param != "" {
+		if filter.DOBMonth, err = strconv.Atoi(param); err != nil {
+			newErrorResponse(c, http.StatusBadRequest, "invalid dob_month param")
+			return
+		}
+	}
+	if filter.ContactedFrom, err = parseDateQuery(c, "contacted_from"); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if filter.ContactedTo, err = parseDateQuery(c, "contacted_to"); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if filter.FriendlistID != nil {
+		friendlist, err := h.services.Friendlist.GetByIDWithFriends(userID, *filter.FriendlistID)
+		if err != nil {
+			newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("friendlist not found: %s", err.Error()))
+			return
+		}
+		if friendlist.Friendlist.Rule != nil {
+			filter.FriendlistID = nil
+			filter.FriendIDs = make([]uuid.UUID, 0, len(friendlist.Friends))
+			for _, friend := range friendlist.Friends {
+				filter.FriendIDs = append(filter.FriendIDs, friend.ID)
+			}
+		}
+	}
+
+	friends, next, err := h.services.Friend.List(userID, filter, params)
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	c.JSON(http.StatusOK, getAllFriendsResponse{
-		Data: friends,
+		Data:       friends,
+		NextCursor: next,
 	})
 }
 
@@ -218,18 +239,27 @@ func (h *Handler) updateFriend(c *gin.Context) {
 	}
 	oldImageID := friend.Friend.ImageID
 
+	imageChanged := payload.Friend != nil && payload.Friend.ImageID != nil && *payload.Friend.ImageID != oldImageID
+	if imageChanged {
+		if err := h.checkImageOwner(userID, *payload.Friend.ImageID); err != nil {
+			newErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
 	err = h.services.Friend.Update(userID, friendID, payload)
 	if err != nil {
-		if payload.Friend.ImageID != nil {
-			deleteFile(fmt.Sprintf("%s%s%s", uploadDir, payload.Friend.ImageID, imageExtension))
+		if imageChanged {
+			if err := h.services.Image.Release(c.Request.Context(), userID, *payload.Friend.ImageID); err != nil {
+				logrus.Errorf("error releasing image %s: %s", *payload.Friend.ImageID, err.Error())
+			}
 		}
 		newErrorResponse(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	if payload.Friend.ImageID != nil && oldImageID != uuid.Nil && *payload.Friend.ImageID != oldImageID {
-		err := deleteFile(fmt.Sprintf("%s%s%s", uploadDir, oldImageID, imageExtension))
-		if err != nil {
+	if imageChanged {
+		if err := h.services.Image.Release(c.Request.Context(), userID, oldImageID); err != nil {
 			newErrorResponse(c, http.StatusInternalServerError, err.Error())
 			return
 		}
@@ -243,7 +273,7 @@ func (h *Handler) updateFriend(c *gin.Context) {
 // @Summary Delete Friend
 // @Security ApiKeyAuth
 // @Tags friend
-// @Description delete friend
+// @Description move friend to trash, it can be restored until purged
 // @ID delete-friend
 // @Accept  json
 // @Produce  json
@@ -266,28 +296,18 @@ func (h *Handler) deleteFriend(c *gin.Context) {
 		return
 	}
 
-	friend, err := h.services.Friend.GetByID(userID, friendID)
+	_, err = h.services.Friend.GetByID(userID, friendID)
 	if err != nil {
 		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("friend not found or already deleted: %s", err.Error()))
 		return
 	}
 
-	oldImageID := friend.Friend.ImageID
-
 	err = h.services.Friend.DeleteByID(userID, friendID)
 	if err != nil {
 		newErrorResponse(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	if oldImageID != uuid.Nil {
-		err := deleteFile(fmt.Sprintf("%s%s%s", uploadDir, oldImageID, imageExtension))
-		if err != nil {
-			newErrorResponse(c, http.StatusInternalServerError, err.Error())
-			return
-		}
-	}
-
 	c.JSON(http.StatusOK, statusResponse{
 		Status: "ok",
 	})
@@ -338,7 +358,7 @@ func (h *Handler) addTagToFriend(c *gin.Context) {
 		return
 	}
 
-	err = h.services.Friend.AddTagToFriend(friendID, payload.TagID)
+	err = h.services.Friend.AddTagToFriend(userID, friendID, payload.TagID)
 	if err != nil {
 		newErrorResponse(c, http.StatusInternalServerError, err.Error())
 		return
@@ -389,7 +409,7 @@ func (h *Handler) deleteTagFromFriend(c *gin.Context) {
 		return
 	}
 
-	err = h.services.Friend.DeleteTagFromFriend(friendID, tagID)
+	err = h.services.Friend.DeleteTagFromFriend(userID, friendID, tagID)
 	if err != nil {
 		newErrorResponse(c, http.StatusInternalServerError, err.Error())
 		return
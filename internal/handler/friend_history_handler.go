@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/lunovoy/friendly/internal/models"
+)
+
+// @Summary Get Friend History
+// @Security ApiKeyAuth
+// @Tags friend
+// @Description get changes of friend profile, work info, tags and custom fields with their author, the latest first
+// @ID get-friend-history
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Friend id"
+// @Success 200 {object} getFriendHistoryResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/friend/{id}/history [get]
+func (h *Handler) getFriendHistory(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	friendID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	_, err = h.services.Friend.GetByID(userID, friendID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("friend not found: %s", err.Error()))
+		return
+	}
+
+	changes, err := h.services.Friend.GetHistory(userID, friendID)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, getFriendHistoryResponse{
+		Data: changes,
+	})
+}
+
+// @Summary Get Friend Version
+// @Security ApiKeyAuth
+// @Tags friend
+// @Description get friend as it was at the given time
+// @ID get-friend-version
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Friend id"
+// @Param at query string true "Point in time, RFC3339 or YYYY-MM-DD"
+// @Success 200 {object} models.FriendWorkInfoTags
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/friend/{id}/history/version [get]
+func (h *Handler) getFriendVersion(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	friendID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	at, err := parseDateParam(c.Query("at"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("invalid at param: %s", err.Error()))
+		return
+	}
+
+	_, err = h.services.Friend.GetByID(userID, friendID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("friend not found: %s", err.Error()))
+		return
+	}
+
+	friend, err := h.services.Friend.GetVersion(userID, friendID, at)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, friend)
+}
+
+// @Summary Revert Friend
+// @Security ApiKeyAuth
+// @Tags friend
+// @Description bring friend profile, work info, tags and custom fields back to the version at the given time
+// @ID revert-friend
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Friend id"
+// @Param input body models.FriendRevert true "Version time"
+// @Success 200 {object} statusResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/friend/{id}/history/revert [post]
+func (h *Handler) revertFriend(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	friendID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	var payload models.FriendRevert
+	if err := c.BindJSON(&payload); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	_, err = h.services.Friend.GetByID(userID, friendID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("friend not found: %s", err.Error()))
+		return
+	}
+
+	if err := h.services.Friend.Revert(userID, friendID, payload.At); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
+	})
+}
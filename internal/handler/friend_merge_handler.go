@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lunovoy/friendly/internal/models"
+)
+
+// @Summary Get Duplicate Friends
+// @Security ApiKeyAuth
+// @Tags friend
+// @Description get pairs of friends which look like the same person, scored by name, dob, shared contact points and company
+// @ID get-duplicate-friends
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} getDuplicateFriendsResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/friend/duplicates [get]
+func (h *Handler) getDuplicateFriends(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	candidates, err := h.services.Friend.FindDuplicates(userID)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, getDuplicateFriendsResponse{
+		Data: candidates,
+	})
+}
+
+// @Summary Merge Friends
+// @Security ApiKeyAuth
+// @Tags friend
+// @Description merge source friend into target and delete source, choices pick source values for conflicting fields
+// @ID merge-friends
+// @Accept  json
+// @Produce  json
+// @Param input body models.FriendMerge true "Friends to merge"
+// @Success 200 {object} statusResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/friend/merge [post]
+func (h *Handler) mergeFriends(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	var payload models.FriendMerge
+	if err := c.BindJSON(&payload); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	_, err = h.services.Friend.GetByID(userID, payload.TargetID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("friend not found: %s", err.Error()))
+		return
+	}
+
+	_, err = h.services.Friend.GetByID(userID, payload.SourceID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("friend not found: %s", err.Error()))
+		return
+	}
+
+	if err := h.services.Friend.Merge(userID, payload); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
+	})
+}
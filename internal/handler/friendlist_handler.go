@@ -12,7 +12,7 @@ import (
 // @Summary Create Friendlist
 // @Security ApiKeyAuth
 // @Tags friendlist
-// @Description create friendlist
+// @Description create friendlist, with a rule like "tag in (work) and city = Moscow and birthday within 30 days" it is smart and its friends follow the rule
 // @ID create-friendlist
 // @Accept  json
 // @Produce  json
@@ -35,6 +35,13 @@ func (h *Handler) createFriendlist(c *gin.Context) {
 		return
 	}
 
+	if payload.Friendlist.ImageID != nil {
+		if err := h.checkImageOwner(userID, *payload.Friendlist.ImageID); err != nil {
+			newErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
 	friendlistID, err := h.services.Friendlist.Create(userID, payload.Friendlist)
 	if err != nil {
 		newErrorResponse(c, http.StatusInternalServerError, err.Error())
@@ -60,10 +67,16 @@ func (h *Handler) createFriendlist(c *gin.Context) {
 // @Summary Get All Friendlists
 // @Security ApiKeyAuth
 // @Tags friendlist
-// @Description get all friendlists
+// @Description get page of friendlists, filtered and sorted
 // @ID get-all-friendlists
 // @Accept  json
 // @Produce  json
+// @Param tag_id query string false "Friendlists with tag"
+// @Param friend_id query string false "Friendlists with friend"
+// @Param limit query int false "Page size, 1-200 (default 50)"
+// @Param sort query string false "Sort key: title (default)"
+// @Param order query string false "asc (default) or desc"
+// @Param cursor query string false "next_cursor of the previous page"
 // @Success 200 {object} getAllFriendlistsResponse
 // @Failure 400,404 {object} errorResponse
 // @Failure 500 {object} errorResponse
@@ -76,14 +89,31 @@ func (h *Handler) getAllFriendlists(c *gin.Context) {
 		return
 	}
 
-	friendlists, err := h.services.Friendlist.GetAll(userID)
+	params, err := parseListParams(c)
 	if err != nil {
-		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var filter models.FriendlistFilter
+	if filter.TagID, err = parseUUIDQuery(c, "tag_id"); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if filter.FriendID, err = parseUUIDQuery(c, "friend_id"); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	friendlists, next, err := h.services.Friendlist.List(userID, filter, params)
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	c.JSON(http.StatusOK, getAllFriendlistsResponse{
-		Data: friendlists,
+		Data:       friendlists,
+		NextCursor: next,
 	})
 }
 
@@ -126,6 +156,12 @@ func (h *Handler) getAllFriendlistsFull(c *gin.Context) {
 		return
 	}
 
+	templateFields, err := h.services.Friendlist.GetAllTemplateFields(userID)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
 	type friendsInFriendlist struct {
 		friendlistID uuid.UUID
 		friends      []models.FriendWorkInfoTags
@@ -157,9 +193,14 @@ func (h *Handler) getAllFriendlistsFull(c *gin.Context) {
 			if friendsInFriendlist.friendlistID != friendlistWithTag.Friendlist.ID {
 				continue
 			}
+			fields := templateFields[friendlistWithTag.Friendlist.ID]
+			if fields == nil {
+				fields = []models.AdditionalInfoField{}
+			}
 			friendlistFull = append(friendlistFull, models.FriendlistFull{
 				FriendlistWithTags: friendlistWithTag,
 				FriendsWithTags:    friendsInFriendlist.friends,
+				Fields:             fields,
 			})
 		}
 	}
@@ -254,10 +295,17 @@ func (h *Handler) getFriendlistByIDFull(c *gin.Context) {
 		friends = append(friends, friendWithTags)
 	}
 
+	fields, err := h.services.Friendlist.GetTemplateFields(friendlistID)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
 	c.JSON(http.StatusOK, map[string]any{
 		"friendlist_full": models.FriendlistFull{
 			FriendlistWithTags: friendlistWithTags,
 			FriendsWithTags:    friends,
+			Fields:             fields,
 		},
 	})
 }
@@ -436,10 +484,12 @@ func (h *Handler) updateFriendlist(c *gin.Context) {
 	}
 	oldImageID := friendlist.ImageID
 
-	_, err = h.services.Friendlist.GetByID(userID, friendlistID)
-	if err != nil {
-		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("friendlist not found: %s", err.Error()))
-		return
+	imageChanged := payload.ImageID != nil && *payload.ImageID != oldImageID
+	if imageChanged {
+		if err := h.checkImageOwner(userID, *payload.ImageID); err != nil {
+			newErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
 	}
 
 	err = h.services.Friendlist.Update(userID, friendlistID, payload)
@@ -448,9 +498,8 @@ func (h *Handler) updateFriendlist(c *gin.Context) {
 		return
 	}
 
-	if payload.ImageID != nil && oldImageID != uuid.Nil && *payload.ImageID != oldImageID {
-		err := deleteFile(fmt.Sprintf("%s%s%s", uploadDir, oldImageID, imageExtension))
-		if err != nil {
+	if imageChanged {
+		if err := h.services.Image.Release(c.Request.Context(), userID, oldImageID); err != nil {
 			newErrorResponse(c, http.StatusInternalServerError, err.Error())
 			return
 		}
@@ -464,7 +513,7 @@ func (h *Handler) updateFriendlist(c *gin.Context) {
 // @Summary Delete Friendlist
 // @Security ApiKeyAuth
 // @Tags friendlist
-// @Description delete friendlist
+// @Description move friendlist to trash, it can be restored until purged
 // @ID delete-friendlist
 // @Accept  json
 // @Produce  json
@@ -487,28 +536,18 @@ func (h *Handler) deleteFriendlist(c *gin.Context) {
 		return
 	}
 
-	friendlist, err := h.services.Friendlist.GetByID(userID, friendlistID)
+	_, err = h.services.Friendlist.GetByID(userID, friendlistID)
 	if err != nil {
 		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("friendlist not found or already deleted: %s", err.Error()))
 		return
 	}
 
-	oldImageID := friendlist.ImageID
-
 	err = h.services.Friendlist.DeleteByID(userID, friendlistID)
 	if err != nil {
 		newErrorResponse(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	if oldImageID != uuid.Nil {
-		err := deleteFile(fmt.Sprintf("%s%s%s", uploadDir, oldImageID, imageExtension))
-		if err != nil {
-			newErrorResponse(c, http.StatusInternalServerError, err.Error())
-			return
-		}
-	}
-
 	c.JSON(http.StatusOK, statusResponse{
 		Status: "ok",
 	})
@@ -573,7 +612,7 @@ func (h *Handler) addTagToFriendlist(c *gin.Context) {
 // @Summary Add Friend To Friendlist
 // @Security ApiKeyAuth
 // @Tags friendlist
-// @Description add friend to friendlist
+// @Description add friend to friendlist, not allowed for smart friendlists
 // @ID add-friend-to-friendlist
 // @Accept  json
 // @Produce  json
@@ -597,11 +636,15 @@ func (h *Handler) addFriendToFriendlist(c *gin.Context) {
 		return
 	}
 
-	_, err = h.services.Friendlist.GetByID(userID, friendlistID)
+	friendlist, err := h.services.Friendlist.GetByID(userID, friendlistID)
 	if err != nil {
 		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("friendlist not found: %s", err.Error()))
 		return
 	}
+	if friendlist.Rule != nil {
+		newErrorResponse(c, http.StatusBadRequest, "friends of smart friendlist are defined by its rule")
+		return
+	}
 
 	var payload models.AdditionFriendToFriendlist
 	if err := c.BindJSON(&payload); err != nil {
@@ -725,3 +768,152 @@ func (h *Handler) deleteFriendFromFriendlist(c *gin.Context) {
 		Status: "ok",
 	})
 }
+
+// @Summary Get Friendlist Fields
+// @Security ApiKeyAuth
+// @Tags friendlist
+// @Description get custom fields of friendlist template
+// @ID get-friendlist-fields
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Friendlist id"
+// @Success 200 {object} getAllAdditionalInfoFieldsResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/friendlist/{id}/field [get]
+func (h *Handler) getFriendlistFields(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	friendlistID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	_, err = h.services.Friendlist.GetByID(userID, friendlistID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("friendlist not found: %s", err.Error()))
+		return
+	}
+
+	fields, err := h.services.Friendlist.GetTemplateFields(friendlistID)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, getAllAdditionalInfoFieldsResponse{
+		Data: fields,
+	})
+}
+
+// @Summary Add Field To Friendlist
+// @Security ApiKeyAuth
+// @Tags friendlist
+// @Description add custom field to friendlist template, friends of the friendlist get the field
+// @ID add-field-to-friendlist
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Friendlist id"
+// @Param input body models.AdditionFieldToFriendlist true "Add Field"
+// @Success 201 {object} statusResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/friendlist/{id}/field [post]
+func (h *Handler) addFieldToFriendlist(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	friendlistID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	_, err = h.services.Friendlist.GetByID(userID, friendlistID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("friendlist not found: %s", err.Error()))
+		return
+	}
+
+	var payload models.AdditionFieldToFriendlist
+	if err := c.BindJSON(&payload); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	_, err = h.services.AdditionalInfoField.GetByID(userID, payload.FieldID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("field not found: %s", err.Error()))
+		return
+	}
+
+	err = h.services.Friendlist.AddFieldToTemplate(friendlistID, payload.FieldID)
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, statusResponse{
+		Status: "ok",
+	})
+}
+
+// @Summary Delete Field From Friendlist
+// @Security ApiKeyAuth
+// @Tags friendlist
+// @Description delete custom field from friendlist template, values of friends are kept
+// @ID delete-field-from-friendlist
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Friendlist id"
+// @Param field_id path string true "Field id"
+// @Success 200 {object} statusResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/friendlist/{id}/field/{field_id} [delete]
+func (h *Handler) deleteFieldFromFriendlist(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	friendlistID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	fieldID, err := uuid.Parse(c.Param("field_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	_, err = h.services.Friendlist.GetByID(userID, friendlistID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("friendlist not found: %s", err.Error()))
+		return
+	}
+
+	err = h.services.Friendlist.DeleteFieldFromTemplate(friendlistID, fieldID)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
+	})
+}
@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/lunovoy/friendly/internal/models"
+	"github.com/lunovoy/friendly/internal/service"
+)
+
+type fakeFriendlistService struct {
+	service.Friendlist
+	friendlists []models.FriendlistWithFriends
+	fields      map[uuid.UUID][]models.AdditionalInfoField
+	// fieldQueries counts the template field loads
+	fieldQueries int
+}
+
+func (s *fakeFriendlistService) GetAllWithTags(userID uuid.UUID) ([]models.FriendlistWithTags, error) {
+	var friendlists []models.FriendlistWithTags
+	for _, friendlist := range s.friendlists {
+		friendlists = append(friendlists, models.FriendlistWithTags{Friendlist: friendlist.Friendlist})
+	}
+	return friendlists, nil
+}
+
+func (s *fakeFriendlistService) GetAllWithFriends(userID uuid.UUID) ([]models.FriendlistWithFriends, error) {
+	return s.friendlists, nil
+}
+
+func (s *fakeFriendlistService) GetByIDWithTags(userID, friendlistID uuid.UUID) (models.FriendlistWithTags, error) {
+	friendlist, err := s.GetByIDWithFriends(userID, friendlistID)
+	return models.FriendlistWithTags{Friendlist: friendlist.Friendlist}, err
+}
+
+func (s *fakeFriendlistService) GetByIDWithFriends(userID, friendlistID uuid.UUID) (models.FriendlistWithFriends, error) {
+	for _, friendlist := range s.friendlists {
+		if friendlist.Friendlist.ID == friendlistID {
+			return friendlist, nil
+		}
+	}
+	return models.FriendlistWithFriends{}, errors.New("not found")
+}
+
+func (s *fakeFriendlistService) GetTemplateFields(friendlistID uuid.UUID) ([]models.AdditionalInfoField, error) {
+	s.fieldQueries++
+	return s.fields[friendlistID], nil
+}
+
+func (s *fakeFriendlistService) GetAllTemplateFields(userID uuid.UUID) (map[uuid.UUID][]models.AdditionalInfoField, error) {
+	s.fieldQueries++
+	return s.fields, nil
+}
+
+type fakeFriendService struct {
+	service.Friend
+	friends []models.FriendWorkInfoTags
+}
+
+func (s *fakeFriendService) GetAll(userID uuid.UUID) ([]models.FriendWorkInfoTags, error) {
+	return s.friends, nil
+}
+
+func (s *fakeFriendService) GetByID(userID, friendID uuid.UUID) (models.FriendWorkInfoTags, error) {
+	for _, friend := range s.friends {
+		if friend.Friend.ID == friendID {
+			return friend, nil
+		}
+	}
+	return models.FriendWorkInfoTags{}, errors.New("not found")
+}
+
+func TestFriendlistFullFields(t *testing.T) {
+	friend := models.Friend{ID: uuid.New(), FirstName: "Иван"}
+	clients := models.Friendlist{ID: uuid.New(), Title: "Клиенты"}
+	family := models.Friendlist{ID: uuid.New(), Title: "Семья"}
+	contract := models.AdditionalInfoField{ID: uuid.New(), Title: "Договор до", Type: models.AdditionalInfoFieldTypeDate}
+
+	friendlists := &fakeFriendlistService{
+		friendlists: []models.FriendlistWithFriends{
+			{Friendlist: clients, Friends: []models.Friend{friend}},
+			{Friendlist: family},
+		},
+		fields: map[uuid.UUID][]models.AdditionalInfoField{
+			clients.ID: {contract},
+		},
+	}
+	h := &Handler{services: &service.Service{
+		Friendlist: friendlists,
+		Friend:     &fakeFriendService{friends: []models.FriendWorkInfoTags{{Friend: friend}}},
+	}}
+
+	serve := func(handler gin.HandlerFunc, params gin.Params) []byte {
+		t.Helper()
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+		c.Params = params
+		c.Set(userCtx, uuid.New())
+		handler(c)
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+		}
+		return w.Body.Bytes()
+	}
+
+	var all struct {
+		Data []models.FriendlistFull `json:"data"`
+	}
+	if err := json.Unmarshal(serve(h.getAllFriendlistsFull, nil), &all); err != nil {
+		t.Fatal(err)
+	}
+	if len(all.Data) != 2 {
+		t.Fatalf("got %d friendlists, want 2", len(all.Data))
+	}
+	if friendlists.fieldQueries != 1 {
+		t.Errorf("template fields loaded %d times, want once for all friendlists", friendlists.fieldQueries)
+	}
+	for _, friendlist := range all.Data {
+		if friendlist.Fields == nil {
+			t.Errorf("friendlist %s has null fields, want []", friendlist.FriendlistWithTags.Friendlist.Title)
+		}
+		wantFields := 0
+		if friendlist.FriendlistWithTags.Friendlist.ID == clients.ID {
+			wantFields = 1
+		}
+		if len(friendlist.Fields) != wantFields {
+			t.Errorf("friendlist %s has %d fields, want %d", friendlist.FriendlistWithTags.Friendlist.Title, len(friendlist.Fields), wantFields)
+		}
+	}
+
+	var one struct {
+		FriendlistFull models.FriendlistFull `json:"friendlist_full"`
+	}
+	if err := json.Unmarshal(serve(h.getFriendlistByIDFull, gin.Params{{Key: "id", Value: clients.ID.String()}}), &one); err != nil {
+		t.Fatal(err)
+	}
+	if fields := one.FriendlistFull.Fields; len(fields) != 1 || fields[0].ID != contract.ID || fields[0].Title != contract.Title {
+		t.Errorf("fields = %+v, want %+v", fields, []models.AdditionalInfoField{contract})
+	}
+	if friends := one.FriendlistFull.FriendsWithTags; len(friends) != 1 || friends[0].Friend.ID != friend.ID {
+		t.Errorf("friends = %+v, want %s", friends, friend.FirstName)
+	}
+}
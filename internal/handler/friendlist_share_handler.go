@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/lunovoy/friendly/internal/models"
+)
+
+// @Summary Share Friendlist
+// @Security ApiKeyAuth
+// @Tags friendlist
+// @Description share friendlist with another user by mail, the user can read images of the friendlist and its friends
+// @ID share-friendlist
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Friendlist id"
+// @Param input body models.FriendlistShareInput true "User to share with"
+// @Success 201 {object} any
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/friendlist/{id}/share [post]
+func (h *Handler) shareFriendlist(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	friendlistID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	var input models.FriendlistShareInput
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	_, err = h.services.Friendlist.GetByID(userID, friendlistID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("friendlist not found: %s", err.Error()))
+		return
+	}
+
+	shareID, err := h.services.FriendlistShare.Share(userID, friendlistID, input.Mail)
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, map[string]any{
+		"share_id": shareID,
+	})
+}
+
+// @Summary Get Friendlist Shares
+// @Security ApiKeyAuth
+// @Tags friendlist
+// @Description get users the friendlist is shared with
+// @ID get-friendlist-shares
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Friendlist id"
+// @Success 200 {object} getFriendlistSharesResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/friendlist/{id}/share [get]
+func (h *Handler) getFriendlistShares(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	friendlistID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	_, err = h.services.Friendlist.GetByID(userID, friendlistID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("friendlist not found: %s", err.Error()))
+		return
+	}
+
+	shares, err := h.services.FriendlistShare.GetAllByFriendlistID(friendlistID)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, getFriendlistSharesResponse{
+		Data: shares,
+	})
+}
+
+// @Summary Unshare Friendlist
+// @Security ApiKeyAuth
+// @Tags friendlist
+// @Description stop sharing friendlist with the user
+// @ID unshare-friendlist
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Friendlist id"
+// @Param user_id path string true "User id"
+// @Success 200 {object} statusResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/friendlist/{id}/share/{user_id} [delete]
+func (h *Handler) unshareFriendlist(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	friendlistID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	sharedUserID, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid user_id param")
+		return
+	}
+
+	_, err = h.services.Friendlist.GetByID(userID, friendlistID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("friendlist not found: %s", err.Error()))
+		return
+	}
+
+	if err := h.services.FriendlistShare.Unshare(friendlistID, sharedUserID); err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
+	})
+}
@@ -0,0 +1,252 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/lunovoy/friendly/internal/models"
+)
+
+// @Summary Create Gift Idea
+// @Security ApiKeyAuth
+// @Tags friend
+// @Description create gift idea for friend, optionally for a birthday or an event occurrence
+// @ID create-gift-idea
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Friend id"
+// @Param input body models.GiftIdea true "Gift idea info"
+// @Success 201 {object} any
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/friend/{id}/gifts [post]
+func (h *Handler) createGiftIdea(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	friendID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	var idea models.GiftIdea
+	if err := c.BindJSON(&idea); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	_, err = h.services.Friend.GetByID(userID, friendID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("friend not found: %s", err.Error()))
+		return
+	}
+
+	ideaID, err := h.services.GiftIdea.Create(userID, friendID, idea)
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, map[string]any{
+		"gift_id": ideaID,
+	})
+}
+
+// @Summary Get All Gift Ideas
+// @Security ApiKeyAuth
+// @Tags friend
+// @Description get all gift ideas for friend, the latest first
+// @ID get-all-gift-ideas
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Friend id"
+// @Success 200 {object} getAllGiftIdeasResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/friend/{id}/gifts [get]
+func (h *Handler) getAllGiftIdeas(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	friendID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	_, err = h.services.Friend.GetByID(userID, friendID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("friend not found: %s", err.Error()))
+		return
+	}
+
+	ideas, err := h.services.GiftIdea.GetAllByFriendID(userID, friendID)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, getAllGiftIdeasResponse{
+		Data: ideas,
+	})
+}
+
+// @Summary Get Gift Idea By Id
+// @Security ApiKeyAuth
+// @Tags friend
+// @Description get gift idea for friend by id
+// @ID get-gift-idea-by-id
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Friend id"
+// @Param gift_id path string true "Gift idea id"
+// @Success 200 {object} models.GiftIdea
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/friend/{id}/gifts/{gift_id} [get]
+func (h *Handler) getGiftIdeaByID(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	friendID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	ideaID, err := uuid.Parse(c.Param("gift_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	idea, err := h.services.GiftIdea.GetByID(userID, friendID, ideaID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("gift idea not found: %s", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]any{
+		"gift_idea": idea,
+	})
+}
+
+// @Summary Update Gift Idea
+// @Security ApiKeyAuth
+// @Tags friend
+// @Description update gift idea for friend, negative price removes the price, empty occasion removes the occasion
+// @ID update-gift-idea
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Friend id"
+// @Param gift_id path string true "Gift idea id"
+// @Param input body models.GiftIdeaUpdate true "Gift idea info"
+// @Success 200 {object} statusResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/friend/{id}/gifts/{gift_id} [put]
+func (h *Handler) updateGiftIdea(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	friendID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	ideaID, err := uuid.Parse(c.Param("gift_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	var update models.GiftIdeaUpdate
+	if err := c.BindJSON(&update); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	_, err = h.services.GiftIdea.GetByID(userID, friendID, ideaID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("gift idea not found: %s", err.Error()))
+		return
+	}
+
+	if err := h.services.GiftIdea.Update(userID, friendID, ideaID, update); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
+	})
+}
+
+// @Summary Delete Gift Idea
+// @Security ApiKeyAuth
+// @Tags friend
+// @Description delete gift idea for friend
+// @ID delete-gift-idea
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Friend id"
+// @Param gift_id path string true "Gift idea id"
+// @Success 200 {object} statusResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/friend/{id}/gifts/{gift_id} [delete]
+func (h *Handler) deleteGiftIdea(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	friendID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	ideaID, err := uuid.Parse(c.Param("gift_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	_, err = h.services.GiftIdea.GetByID(userID, friendID, ideaID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("gift idea not found: %s", err.Error()))
+		return
+	}
+
+	if err := h.services.GiftIdea.DeleteByID(userID, ideaID); err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
+	})
+}
@@ -3,22 +3,26 @@ package handler
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/lunovoy/friendly/internal/service"
+	"github.com/lunovoy/friendly/internal/storage"
 	swaggerfiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	"golang.org/x/sync/singleflight"
 )
 
 const (
-	maxFileSize    = 8 << 20 // 8MB
-	uploadDir      = "./images/"
-	imageExtension = ".jpg"
+	maxFileSize       = 8 << 20  // 8MB
+	maxAttachmentSize = 20 << 20 // 20MB
+	attachmentPrefix  = "attachments/"
 )
 
 type Handler struct {
 	services *service.Service
+	storage  storage.Storage
+	variants singleflight.Group
 }
 
-func NewHandler(services *service.Service) *Handler {
-	return &Handler{services: services}
+func NewHandler(services *service.Service, storage storage.Storage) *Handler {
+	return &Handler{services: services, storage: storage}
 }
 
 func (h *Handler) InitRoutes() *gin.Engine {
@@ -38,6 +42,11 @@ func (h *Handler) InitRoutes() *gin.Engine {
 		{
 			profile.GET("/", h.getProfile)
 			profile.PUT("/", h.updateProfile)
+			profile.GET("/digest", h.getDigestSetting)
+			profile.PUT("/digest", h.updateDigestSetting)
+			profile.GET("/digest/preview", h.previewDigest)
+			profile.GET("/notifications", h.getNotificationPreferences)
+			profile.PUT("/notifications", h.updateNotificationPreferences)
 		}
 
 		tags := api.Group("/tag", h.userIdentity)
@@ -66,17 +75,72 @@ func (h *Handler) InitRoutes() *gin.Engine {
 			friendlist.POST("/:id/friend", h.addFriendToFriendlist)
 			friendlist.DELETE("/:id/tag/:tag_id", h.deleteTagFromFriendlist)
 			friendlist.DELETE("/:id/friend/:friend_id", h.deleteFriendFromFriendlist)
+			friendlist.GET("/:id/field", h.getFriendlistFields)
+			friendlist.POST("/:id/field", h.addFieldToFriendlist)
+			friendlist.DELETE("/:id/field/:field_id", h.deleteFieldFromFriendlist)
+			friendlist.POST("/:id/share", h.shareFriendlist)
+			friendlist.GET("/:id/share", h.getFriendlistShares)
+			friendlist.DELETE("/:id/share/:user_id", h.unshareFriendlist)
 		}
 
 		friend := api.Group("/friend", h.userIdentity)
 		{
 			friend.POST("/", h.createFriend)
 			friend.GET("/", h.getAllFriends)
+			friend.GET("/overdue", h.getOverdueFriends)
+			friend.GET("/graph", h.getFriendsGraph)
+			friend.GET("/duplicates", h.getDuplicateFriends)
+			friend.POST("/merge", h.mergeFriends)
 			friend.GET("/:id", h.getFriendByID)
 			friend.PUT("/:id", h.updateFriend)
 			friend.DELETE("/:id", h.deleteFriend)
 			friend.POST("/:id/tag", h.addTagToFriend)
 			friend.DELETE("/:id/tag/:tag_id", h.deleteTagFromFriend)
+			friend.POST("/:id/contacted", h.markFriendContacted)
+			friend.POST("/:id/dates", h.createFriendDate)
+			friend.GET("/:id/dates", h.getAllFriendDates)
+			friend.GET("/:id/dates/:date_id", h.getFriendDateByID)
+			friend.PUT("/:id/dates/:date_id", h.updateFriendDate)
+			friend.DELETE("/:id/dates/:date_id", h.deleteFriendDate)
+			friend.POST("/:id/gifts", h.createGiftIdea)
+			friend.GET("/:id/gifts", h.getAllGiftIdeas)
+			friend.GET("/:id/gifts/:gift_id", h.getGiftIdeaByID)
+			friend.PUT("/:id/gifts/:gift_id", h.updateGiftIdea)
+			friend.DELETE("/:id/gifts/:gift_id", h.deleteGiftIdea)
+			friend.POST("/:id/photos", h.createPhoto)
+			friend.GET("/:id/photos", h.getAllPhotos)
+			friend.PUT("/:id/photos/order", h.reorderPhotos)
+			friend.PUT("/:id/photos/:photo_id", h.updatePhoto)
+			friend.DELETE("/:id/photos/:photo_id", h.deletePhoto)
+			friend.PUT("/:id/photos/:photo_id/avatar", h.setAvatarPhoto)
+			friend.POST("/:id/contacts", h.createContactPoint)
+			friend.GET("/:id/contacts", h.getAllContactPoints)
+			friend.GET("/:id/contacts/:contact_id", h.getContactPointByID)
+			friend.PUT("/:id/contacts/:contact_id", h.updateContactPoint)
+			friend.DELETE("/:id/contacts/:contact_id", h.deleteContactPoint)
+			friend.GET("/:id/vcard", h.exportFriendVCard)
+			friend.POST("/:id/employment", h.createEmployment)
+			friend.GET("/:id/employment", h.getAllEmployments)
+			friend.GET("/:id/employment/:employment_id", h.getEmploymentByID)
+			friend.PUT("/:id/employment/:employment_id", h.updateEmployment)
+			friend.DELETE("/:id/employment/:employment_id", h.deleteEmployment)
+			friend.POST("/:id/relationships", h.createRelationship)
+			friend.GET("/:id/relationships", h.getAllRelationships)
+			friend.GET("/:id/relationships/:relationship_id", h.getRelationshipByID)
+			friend.PUT("/:id/relationships/:relationship_id", h.updateRelationship)
+			friend.DELETE("/:id/relationships/:relationship_id", h.deleteRelationship)
+			friend.POST("/:id/interactions", h.createInteraction)
+			friend.GET("/:id/interactions", h.getAllInteractions)
+			friend.GET("/:id/interactions/:interaction_id", h.getInteractionByID)
+			friend.PUT("/:id/interactions/:interaction_id", h.updateInteraction)
+			friend.DELETE("/:id/interactions/:interaction_id", h.deleteInteraction)
+			friend.GET("/:id/timeline", h.getFriendTimeline)
+			friend.GET("/:id/history", h.getFriendHistory)
+			friend.GET("/:id/history/version", h.getFriendVersion)
+			friend.POST("/:id/history/revert", h.revertFriend)
+			friend.GET("/:id/field", h.getFriendAdditionalFields)
+			friend.PUT("/:id/field/:field_id", h.setFriendAdditionalField)
+			friend.DELETE("/:id/field/:field_id", h.deleteFriendAdditionalField)
 		}
 
 		event := api.Group("/event", h.userIdentity)
@@ -89,10 +153,30 @@ func (h *Handler) InitRoutes() *gin.Engine {
 			event.GET("/:id", h.getEventByID)
 			event.GET("/friends", h.getAllEventsWithFriends)
 			event.GET("/:id/full", h.getEventByIDFull)
+			event.GET("/:id/photos", h.getEventPhotos)
 			event.PUT("/:id", h.updateEvent)
 			event.DELETE("/:id", h.deleteEvent)
 		}
 
+		note := api.Group("/note", h.userIdentity)
+		{
+			note.POST("/", h.createNote)
+			note.GET("/", h.getAllNotes)
+			note.GET("/:id", h.getNoteByID)
+			note.PUT("/:id", h.updateNote)
+			note.DELETE("/:id", h.deleteNote)
+		}
+
+		attachment := api.Group("/attachment", h.userIdentity)
+		{
+			attachment.POST("/", h.uploadAttachment)
+			attachment.GET("/", h.getAllAttachments)
+			attachment.GET("/usage", h.getStorageUsage)
+			attachment.GET("/:id", h.getAttachmentByID)
+			attachment.GET("/:id/download", h.downloadAttachment)
+			attachment.DELETE("/:id", h.deleteAttachment)
+		}
+
 		reminder := api.Group("/reminder", h.userIdentity)
 		{
 			reminder.POST("/", h.createReminder)
@@ -101,6 +185,27 @@ func (h *Handler) InitRoutes() *gin.Engine {
 			reminder.GET("/:id", h.getReminderByID)
 			reminder.PUT("/:id", h.updateReminder)
 			reminder.DELETE("/:id", h.deleteReminder)
+			reminder.GET("/delivery", h.getAllReminderDeliveries)
+			reminder.GET("/delivery/:id", h.getReminderDeliveryByID)
+			reminder.POST("/delivery/:id/acknowledge", h.acknowledgeReminderDelivery)
+			reminder.POST("/delivery/:id/snooze", h.snoozeReminderDelivery)
+			reminder.POST("/delivery/:id/dismiss", h.dismissReminderDelivery)
+		}
+
+		calendar := api.Group("/calendar", h.userIdentity)
+		{
+			calendar.GET("/", h.getCalendar)
+		}
+
+		search := api.Group("/search", h.userIdentity)
+		{
+			search.GET("/", h.search)
+		}
+
+		trash := api.Group("/trash", h.userIdentity)
+		{
+			trash.GET("/", h.getTrash)
+			trash.POST("/:kind/:id/restore", h.restoreFromTrash)
 		}
 
 		additionalInfoField := api.Group("/additional-field", h.userIdentity)
@@ -112,7 +217,7 @@ func (h *Handler) InitRoutes() *gin.Engine {
 			additionalInfoField.DELETE("/:id", h.deleteAdditionalField)
 		}
 
-		image := api.Group("/image")
+		image := api.Group("/image", h.userIdentity)
 		{
 			image.POST("/", h.uploadImage)
 			image.GET("/:id/:res", h.getImage)
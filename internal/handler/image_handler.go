@@ -1,27 +1,36 @@
 package handler
 
 import (
+	"errors"
 	"fmt"
 	"image"
-	"image/jpeg"
-	"image/png"
 	"net/http"
-	"os"
-	"path/filepath"
 	"strconv"
-	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
-	"github.com/jdeng/goheif"
-	"github.com/nfnt/resize"
+	"github.com/lunovoy/friendly/internal/models"
+	"github.com/lunovoy/friendly/internal/service"
+	"github.com/lunovoy/friendly/internal/storage"
+	"github.com/sirupsen/logrus"
 	_ "golang.org/x/image/webp"
 )
 
+// imageFormats maps formats detected by image.DecodeConfig to the MIME type
+// and the file extension the image is stored with.
+var imageFormats = map[string]struct {
+	mimeType  string
+	extension string
+}{
+	"jpeg": {"image/jpeg", ".jpg"},
+	"png":  {"image/png", ".png"},
+	"heic": {"image/heic", ".heic"},
+}
+
 // @Summary Upload Image
 // @Security ApiKeyAuth
 // @Tags image
-// @Description upload image
+// @Description upload image owned by the user, the type is detected from the content
 // @ID upload-image
 // @Accept  multipart/form-data
 // @Produce  json
@@ -33,50 +42,68 @@ import (
 // @Failure default {object} errorResponse
 // @Router /api/image [post]
 func (h *Handler) uploadImage(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
 
 	if err := c.Request.ParseMultipartForm(maxFileSize); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "File size exceeds the maximum allowed size 8MB"})
+		return
 	}
-	uuid := uuid.NewString()
+	imageID := uuid.New()
 
 	file, err := c.FormFile("image")
 	if err != nil {
 		newErrorResponse(c, http.StatusBadRequest, err.Error())
 		return
 	}
-	inputFileType := file.Header.Get("Content-Type")
 
-	// Проверяем тип файла
-	if inputFileType != "image/jpeg" && inputFileType != "image/jpg" && inputFileType != "image/png" && inputFileType != "image/heif" {
+	// Проверяем тип файла по содержимому, а не по заголовку клиента
+	src, err := file.Open()
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, fmt.Sprintf("error reading file: %s", err.Error()))
+		return
+	}
+	config, format, err := image.DecodeConfig(src)
+	src.Close()
+	imageFormat, ok := imageFormats[format]
+	if err != nil || !ok {
 		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": "Invalid file type. Only JPG, PNG, HEIC/HEIF files are allowed."})
 		return
 	}
 
-	fileExt := strings.Split(file.Filename, ".")[1]
-	filename := fmt.Sprintf("%s.%s", uuid, fileExt)
-	filePath := uploadDir + filename
+	key := service.ImageKey(imageID, imageFormat.extension)
 
-	// Проверяем, существует ли файл с таким именем, и если существует - удаляем его
-	if _, err := os.Stat(filePath); err == nil {
-		if err := os.Remove(filePath); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": "Failed to delete existing file",
-			})
-			return
-		}
+	// Сохраняем файл в хранилище
+	if err := h.saveFile(c, file, key, imageFormat.mimeType); err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, fmt.Sprintf("error saving file: %s", err.Error()))
+		return
 	}
 
-	// Сохраняем файл на сервере
-
-	if err := saveFile(c, file, filePath); err != nil {
-		newErrorResponse(c, http.StatusInternalServerError, fmt.Sprintf("error saving file: %s", err.Error()))
+	// Сохраняем метаданные, без них файл никому не доступен
+	if err := h.services.Image.Create(userID, models.Image{
+		ID:       imageID,
+		MimeType: imageFormat.mimeType,
+		Width:    config.Width,
+		Height:   config.Height,
+		Size:     file.Size,
+	}); err != nil {
+		if err := h.deleteFile(c.Request.Context(), key); err != nil {
+			logrus.Errorf("error deleting image %s: %s", imageID, err.Error())
+		}
+		newErrorResponse(c, http.StatusInternalServerError, fmt.Sprintf("error saving image: %s", err.Error()))
 		return
 	}
 
 	data := map[string]interface{}{
-		"header":   file.Header,
-		"image_id": uuid,
-		"size":     file.Size,
+		"header":    file.Header,
+		"image_id":  imageID,
+		"size":      file.Size,
+		"mime_type": imageFormat.mimeType,
+		"width":     config.Width,
+		"height":    config.Height,
 	}
 
 	c.JSON(http.StatusCreated, gin.H{
@@ -87,90 +114,73 @@ func (h *Handler) uploadImage(c *gin.Context) {
 // @Summary Get Image
 // @Security ApiKeyAuth
 // @Tags image
-// @Description get image by ID and resolution
+// @Description get image by ID and resolution, the image is available to its owner and to users a friendlist with it is shared with.
+// @Description Resolution is snapped to 64, 128, 256, 512 or 1024, resized variants are cached and served with ETag
 // @ID get-image
 // @Accept  json
 // @Produce  image/jpeg
 // @Param id path string true "Image ID"
 // @Param res path int true "Resolution" Format(int64)
+// @Param If-None-Match header string false "ETag of the cached variant"
 // @Success 200 {string} image/jpeg "Successfully retrieved image"
+// @Success 304 {string} string "Cached variant is up to date"
 // @Failure 400 {object} errorResponse
 // @Failure 404 {object} errorResponse "Image not found"
 // @Failure 500 {object} errorResponse "Internal server error"
 // @Failure default {object} errorResponse
 // @Router /api/image/{id}/{res} [get]
 func (h *Handler) getImage(c *gin.Context) {
-	imageID := c.Param("id")
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	imageID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
 	resolutionString := c.Param("res")
 	resolution, err := strconv.Atoi(resolutionString)
-	if err != nil {
+	if err != nil || resolution <= 0 {
 		newErrorResponse(c, http.StatusBadRequest, "invalid param")
 		return
 	}
-	filePath := fmt.Sprintf("%s%s", uploadDir, imageID)
 
-	// Определяем расширение файла
-	fileExtensions := []string{".jpg", ".jpeg", ".png", ".heic", ".HEIC", ".heif"}
-	var imageFile string
-	for _, ext := range fileExtensions {
-		if _, err := os.Stat(filePath + ext); err == nil {
-			imageFile = filePath + ext
-			break
-		}
-	}
-	if imageFile == "" {
+	// Чужие изображения не отличаются от несуществующих
+	if _, err := h.services.Image.GetShared(userID, imageID); err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Image not found"})
 		return
 	}
 
-	// Открываем файл
-	file, err := os.Open(imageFile)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to open file"})
-		return
-	}
-	defer file.Close()
-	// Декодируем изображение
-	var img image.Image
-	switch filepath.Ext(imageFile) {
-	case ".jpg", ".jpeg":
-		img, err = jpeg.Decode(file)
-	case ".png":
-		img, err = png.Decode(file)
-	case ".heic", ".HEIC", ".heif":
-		// Для формата HEIC требуется специальная обработка
-		heifImg, err := goheif.Decode(file)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "error decoding heic"})
-			return
-		}
-		img = heifImg
-	default:
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unsupported image format"})
+	// Отдаём закэшированный вариант, клиенту с актуальной копией - 304
+	size := snapImageSize(resolution)
+	etag := imageVariantETag(imageID, size)
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", imageCacheControl)
+	if etagMatches(c.GetHeader("If-None-Match"), etag) {
+		c.Status(http.StatusNotModified)
 		return
 	}
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to decode image"})
+
+	data, err := h.imageVariant(c.Request.Context(), imageID, size)
+	if errors.Is(err, storage.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Image not found"})
 		return
 	}
-
-	// Изменяем размер изображения
-	img = resize.Resize(uint(resolution), uint(resolution), img, resize.Lanczos3)
-
-	// Устанавливаем заголовок Content-Type для изображения
-	c.Header("Content-Type", "image/jpeg")
-
-	// Копируем содержимое файла в ответ
-	if err := jpeg.Encode(c.Writer, img, nil); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to copy file contents"})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to resize image: %s", err.Error())})
 		return
 	}
+
+	c.Data(http.StatusOK, "image/jpeg", data)
 }
 
 // @Summary Delete Image
 // @Security ApiKeyAuth
 // @Tags image
-// @Description delete image by ID
+// @Description delete image by ID, only the owner can delete it
 // @ID delete-image
 // @Accept  json
 // @Produce  json
@@ -181,28 +191,26 @@ func (h *Handler) getImage(c *gin.Context) {
 // @Failure default {object} errorResponse
 // @Router /api/image/{id} [delete]
 func (h *Handler) deleteImage(c *gin.Context) {
-	id := c.Param("id")
-	fileExtensions := []string{".jpg", ".jpeg", ".png", ".heif", ".HEIC", ".heic"}
-
-	var filePath string
-	var fileFound bool
-	for _, ext := range fileExtensions {
-		path := fmt.Sprintf("%s%s%s", uploadDir, id, ext)
-		if _, err := os.Stat(path); err == nil {
-			filePath = path
-			fileFound = true
-			break
-		}
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	imageID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
 	}
 
-	if !fileFound {
+	if _, err := h.services.Image.GetByID(userID, imageID); err != nil {
 		newErrorResponse(c, http.StatusNotFound, "Image not found")
 		return
 	}
 
-	// Удаляем файл с сервера
-	if err := deleteFile(filePath); err != nil {
-		newErrorResponse(c, http.StatusInternalServerError, fmt.Sprintf("Failed to delete file: %s", err.Error()))
+	// Удаляем файлы из хранилища вместе с записью
+	if err := h.services.Image.DeleteByID(c.Request.Context(), userID, imageID); err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, fmt.Sprintf("Failed to delete image: %s", err.Error()))
 		return
 	}
 
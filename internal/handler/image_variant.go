@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jdeng/goheif"
+	"github.com/lunovoy/friendly/internal/service"
+	"github.com/nfnt/resize"
+)
+
+// imageCacheControl lets clients keep variants, an image never changes under
+// its id.
+const imageCacheControl = "private, max-age=31536000, immutable"
+
+// snapImageSize returns the smallest size of the ladder not less than res,
+// the largest one for bigger resolutions.
+func snapImageSize(res int) int {
+	for _, size := range service.ImageVariantSizes {
+		if res <= size {
+			return size
+		}
+	}
+	return service.ImageVariantSizes[len(service.ImageVariantSizes)-1]
+}
+
+func imageVariantETag(imageID uuid.UUID, size int) string {
+	return fmt.Sprintf(`"%s-%d"`, imageID, size)
+}
+
+// etagMatches reports whether the If-None-Match header matches the etag.
+func etagMatches(header, etag string) bool {
+	for _, value := range strings.Split(header, ",") {
+		value = strings.TrimSpace(value)
+		if value == "*" || strings.TrimPrefix(value, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// imageVariant returns the cached variant of the image, the variant is
+// generated from the original and stored on the first request.
+func (h *Handler) imageVariant(ctx context.Context, imageID uuid.UUID, size int) ([]byte, error) {
+	key := service.ImageVariantKey(imageID, size)
+	if data, err := h.readObject(ctx, key); err == nil {
+		return data, nil
+	}
+
+	// Concurrent requests for the same variant wait for one resize, it is
+	// finished even if the first request goes away
+	data, err, _ := h.variants.Do(key, func() (interface{}, error) {
+		ctx := context.WithoutCancel(ctx)
+
+		originalKey, err := h.findImageKey(ctx, imageID)
+		if err != nil {
+			return nil, err
+		}
+		file, err := h.storage.Get(ctx, originalKey)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+
+		img, err := decodeImage(file, path.Ext(originalKey))
+		if err != nil {
+			return nil, err
+		}
+		img = resize.Resize(uint(size), uint(size), img, resize.Lanczos3)
+
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, img, nil); err != nil {
+			return nil, err
+		}
+		if err := h.storage.Put(ctx, key, bytes.NewReader(buf.Bytes()), "image/jpeg"); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return data.([]byte), nil
+}
+
+func (h *Handler) readObject(ctx context.Context, key string) ([]byte, error) {
+	file, err := h.storage.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return io.ReadAll(file)
+}
+
+func decodeImage(r io.Reader, ext string) (image.Image, error) {
+	switch strings.ToLower(ext) {
+	case ".jpg", ".jpeg":
+		return jpeg.Decode(r)
+	case ".png":
+		return png.Decode(r)
+	case ".heic", ".heif":
+		return goheif.Decode(r)
+	default:
+		return nil, fmt.Errorf("unsupported image format %s", ext)
+	}
+}
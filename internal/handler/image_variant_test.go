@@ -0,0 +1,181 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lunovoy/friendly/internal/service"
+	"github.com/lunovoy/friendly/internal/storage"
+)
+
+func TestSnapImageSize(t *testing.T) {
+	tests := []struct {
+		res, want int
+	}{
+		{0, 64},
+		{1, 64},
+		{64, 64},
+		{65, 128},
+		{500, 512},
+		{1024, 1024},
+		{5000, 1024},
+	}
+	for _, tt := range tests {
+		if got := snapImageSize(tt.res); got != tt.want {
+			t.Errorf("snapImageSize(%d) = %d, want %d", tt.res, got, tt.want)
+		}
+	}
+}
+
+func TestEtagMatches(t *testing.T) {
+	etag := imageVariantETag(uuid.MustParse("11111111-1111-1111-1111-111111111111"), 256)
+	if want := `"11111111-1111-1111-1111-111111111111-256"`; etag != want {
+		t.Fatalf("imageVariantETag() = %s, want %s", etag, want)
+	}
+
+	tests := []struct {
+		header string
+		want   bool
+	}{
+		{"", false},
+		{etag, true},
+		{"W/" + etag, true},
+		{`"other", ` + etag, true},
+		{"*", true},
+		{`"11111111-1111-1111-1111-111111111111-128"`, false},
+	}
+	for _, tt := range tests {
+		if got := etagMatches(tt.header, etag); got != tt.want {
+			t.Errorf("etagMatches(%q) = %v, want %v", tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestImageVariant(t *testing.T) {
+	ctx := context.Background()
+	store := storage.NewLocal(t.TempDir())
+	h := &Handler{storage: store}
+
+	imageID := uuid.New()
+	var original bytes.Buffer
+	if err := png.Encode(&original, image.NewRGBA(image.Rect(0, 0, 300, 200))); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Put(ctx, service.ImageKey(imageID, ".png"), &original, "image/png"); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := h.imageVariant(ctx, imageID, 128)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config, err := jpeg.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.Width != 128 || config.Height != 128 {
+		t.Errorf("variant is %dx%d, want 128x128", config.Width, config.Height)
+	}
+
+	// The stored variant is served from now on
+	if err := store.Put(ctx, service.ImageVariantKey(imageID, 128), bytes.NewReader([]byte("cached")), "image/jpeg"); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := h.imageVariant(ctx, imageID, 128); err != nil || string(data) != "cached" {
+		t.Errorf("imageVariant() = %q, %v, want the cached variant", data, err)
+	}
+
+	if _, err := h.imageVariant(ctx, uuid.New(), 128); !errors.Is(err, storage.ErrNotFound) {
+		t.Errorf("imageVariant() of a missing image error = %v, want %v", err, storage.ErrNotFound)
+	}
+}
+
+// slowStore holds variant uploads until release is closed and counts them.
+type slowStore struct {
+	storage.Storage
+	release chan struct{}
+	puts    atomic.Int32
+}
+
+func (s *slowStore) Put(ctx context.Context, key string, r io.Reader, contentType string) error {
+	s.puts.Add(1)
+	<-s.release
+	return s.Storage.Put(ctx, key, r, contentType)
+}
+
+func TestImageVariantConcurrent(t *testing.T) {
+	ctx := context.Background()
+	local := storage.NewLocal(t.TempDir())
+	store := &slowStore{Storage: local, release: make(chan struct{})}
+	h := &Handler{storage: store}
+
+	imageID := uuid.New()
+	var original bytes.Buffer
+	if err := png.Encode(&original, image.NewRGBA(image.Rect(0, 0, 300, 200))); err != nil {
+		t.Fatal(err)
+	}
+	if err := local.Put(ctx, service.ImageKey(imageID, ".png"), &original, "image/png"); err != nil {
+		t.Fatal(err)
+	}
+
+	results := make([][]byte, 8)
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			data, err := h.imageVariant(ctx, imageID, 64)
+			if err != nil {
+				t.Error(err)
+			}
+			results[i] = data
+		}(i)
+	}
+
+	// Give every caller time to join the running generation
+	time.Sleep(50 * time.Millisecond)
+	close(store.release)
+	wg.Wait()
+
+	for _, result := range results {
+		if !bytes.Equal(result, results[0]) || len(result) == 0 {
+			t.Errorf("callers got different variants")
+			break
+		}
+	}
+	if got := store.puts.Load(); got != 1 {
+		t.Errorf("variant is generated %d times, want once", got)
+	}
+}
+
+func TestDecodeImage(t *testing.T) {
+	var data bytes.Buffer
+	if err := png.Encode(&data, image.NewRGBA(image.Rect(0, 0, 1, 1))); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		ext     string
+		wantErr bool
+	}{
+		{".png", false},
+		{".PNG", false},
+		{".gif", true},
+	}
+	for _, tt := range tests {
+		_, err := decodeImage(bytes.NewReader(data.Bytes()), tt.ext)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("decodeImage(%s) error = %v, want error %v", tt.ext, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,315 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/lunovoy/friendly/internal/models"
+)
+
+// @Summary Create Interaction
+// @Security ApiKeyAuth
+// @Tags friend
+// @Description log interaction with friend (call, message, meeting, note)
+// @ID create-interaction
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Friend id"
+// @Param input body models.Interaction true "Interaction info"
+// @Success 201 {object} any
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/friend/{id}/interactions [post]
+func (h *Handler) createInteraction(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	friendID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	var interaction models.Interaction
+	if err := c.BindJSON(&interaction); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	_, err = h.services.Friend.GetByID(userID, friendID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("friend not found: %s", err.Error()))
+		return
+	}
+
+	interactionID, err := h.services.Interaction.Create(userID, friendID, interaction)
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, map[string]any{
+		"interaction_id": interactionID,
+	})
+}
+
+// @Summary Get All Interactions
+// @Security ApiKeyAuth
+// @Tags friend
+// @Description get all interactions with friend, the latest first
+// @ID get-all-interactions
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Friend id"
+// @Success 200 {object} getAllInteractionsResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/friend/{id}/interactions [get]
+func (h *Handler) getAllInteractions(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	friendID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	_, err = h.services.Friend.GetByID(userID, friendID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("friend not found: %s", err.Error()))
+		return
+	}
+
+	interactions, err := h.services.Interaction.GetAllByFriendID(userID, friendID)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, getAllInteractionsResponse{
+		Data: interactions,
+	})
+}
+
+// @Summary Get Interaction By Id
+// @Security ApiKeyAuth
+// @Tags friend
+// @Description get interaction with friend by id
+// @ID get-interaction-by-id
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Friend id"
+// @Param interaction_id path string true "Interaction id"
+// @Success 200 {object} models.Interaction
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/friend/{id}/interactions/{interaction_id} [get]
+func (h *Handler) getInteractionByID(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	friendID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	interactionID, err := uuid.Parse(c.Param("interaction_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	interaction, err := h.services.Interaction.GetByID(userID, friendID, interactionID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("interaction not found: %s", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]any{
+		"interaction": interaction,
+	})
+}
+
+// @Summary Update Interaction
+// @Security ApiKeyAuth
+// @Tags friend
+// @Description update interaction with friend
+// @ID update-interaction
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Friend id"
+// @Param interaction_id path string true "Interaction id"
+// @Param input body models.InteractionUpdate true "Interaction info"
+// @Success 200 {object} statusResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/friend/{id}/interactions/{interaction_id} [put]
+func (h *Handler) updateInteraction(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	friendID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	interactionID, err := uuid.Parse(c.Param("interaction_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	var update models.InteractionUpdate
+	if err := c.BindJSON(&update); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	_, err = h.services.Interaction.GetByID(userID, friendID, interactionID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("interaction not found: %s", err.Error()))
+		return
+	}
+
+	if err := h.services.Interaction.Update(userID, friendID, interactionID, update); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
+	})
+}
+
+// @Summary Delete Interaction
+// @Security ApiKeyAuth
+// @Tags friend
+// @Description delete interaction with friend
+// @ID delete-interaction
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Friend id"
+// @Param interaction_id path string true "Interaction id"
+// @Success 200 {object} statusResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/friend/{id}/interactions/{interaction_id} [delete]
+func (h *Handler) deleteInteraction(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	friendID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	interactionID, err := uuid.Parse(c.Param("interaction_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	_, err = h.services.Interaction.GetByID(userID, friendID, interactionID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("interaction not found: %s", err.Error()))
+		return
+	}
+
+	if err := h.services.Interaction.DeleteByID(userID, interactionID); err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
+	})
+}
+
+// @Summary Get Friend Timeline
+// @Security ApiKeyAuth
+// @Tags friend
+// @Description get interactions, past event occurrences, profile and career changes and notes about or mentioning friend, the latest first
+// @ID get-friend-timeline
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Friend id"
+// @Param from query string false "Range start, RFC3339 or YYYY-MM-DD (default a year before to)"
+// @Param to query string false "Range end, RFC3339 or YYYY-MM-DD (default now)"
+// @Success 200 {object} getTimelineResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/friend/{id}/timeline [get]
+func (h *Handler) getFriendTimeline(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	friendID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	to := time.Now()
+	if param := c.Query("to"); param != "" {
+		to, err = parseDateParam(param)
+		if err != nil {
+			newErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("invalid to param: %s", err.Error()))
+			return
+		}
+	}
+	from := to.AddDate(-1, 0, 0)
+	if param := c.Query("from"); param != "" {
+		from, err = parseDateParam(param)
+		if err != nil {
+			newErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("invalid from param: %s", err.Error()))
+			return
+		}
+	}
+
+	_, err = h.services.Friend.GetByID(userID, friendID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("friend not found: %s", err.Error()))
+		return
+	}
+
+	timeline, err := h.services.Timeline.GetTimeline(userID, friendID, from, to)
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, getTimelineResponse{
+		Data: timeline,
+	})
+}
@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/lunovoy/friendly/internal/models"
+)
+
+// @Summary Get Overdue Friends
+// @Security ApiKeyAuth
+// @Tags friend
+// @Description get friends whose keep-in-touch cadence has elapsed, the latest first
+// @ID get-overdue-friends
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} getOverdueFriendsResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/friend/overdue [get]
+func (h *Handler) getOverdueFriends(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	overdue, err := h.services.KeepInTouch.GetOverdue(userID, time.Now())
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, getOverdueFriendsResponse{
+		Data: overdue,
+	})
+}
+
+// @Summary Mark Friend Contacted
+// @Security ApiKeyAuth
+// @Tags friend
+// @Description mark friend as contacted, now when time is not set
+// @ID mark-friend-contacted
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Friend id"
+// @Param input body models.FriendContacted true "Contact time"
+// @Success 200 {object} statusResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/friend/{id}/contacted [post]
+func (h *Handler) markFriendContacted(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	friendID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	var payload models.FriendContacted
+	if err := c.BindJSON(&payload); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	at := time.Now()
+	if payload.At != nil {
+		at = *payload.At
+	}
+
+	_, err = h.services.Friend.GetByID(userID, friendID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("friend not found: %s", err.Error()))
+		return
+	}
+
+	if err := h.services.KeepInTouch.MarkContacted(userID, friendID, at); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
+	})
+}
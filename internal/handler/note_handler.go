@@ -0,0 +1,246 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/lunovoy/friendly/internal/models"
+)
+
+// @Summary Create Note
+// @Security ApiKeyAuth
+// @Tags note
+// @Description create markdown note attached to a friend, an event or a friendlist, friends are mentioned as @[label](friend id)
+// @ID create-note
+// @Accept  json
+// @Produce  json
+// @Param input body models.Note true "Note info"
+// @Success 201 {object} any
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/note [post]
+func (h *Handler) createNote(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	var note models.Note
+	if err := c.BindJSON(&note); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if note.FriendID.Valid {
+		if _, err := h.services.Friend.GetByID(userID, note.FriendID.UUID); err != nil {
+			newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("friend not found: %s", err.Error()))
+			return
+		}
+	}
+	if note.EventID.Valid {
+		if _, err := h.services.Event.GetByID(userID, note.EventID.UUID); err != nil {
+			newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("event not found: %s", err.Error()))
+			return
+		}
+	}
+	if note.FriendlistID.Valid {
+		if _, err := h.services.Friendlist.GetByID(userID, note.FriendlistID.UUID); err != nil {
+			newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("friendlist not found: %s", err.Error()))
+			return
+		}
+	}
+
+	noteID, err := h.services.Note.Create(userID, note)
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, map[string]any{
+		"note_id": noteID,
+	})
+}
+
+// @Summary Get All Notes
+// @Security ApiKeyAuth
+// @Tags note
+// @Description get notes with mentioned friends, the latest first
+// @ID get-all-notes
+// @Accept  json
+// @Produce  json
+// @Param friend_id query string false "Notes attached to friend"
+// @Param event_id query string false "Notes attached to event"
+// @Param friendlist_id query string false "Notes attached to friendlist"
+// @Param mentioned_friend_id query string false "Notes mentioning friend"
+// @Success 200 {object} getAllNotesResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/note [get]
+func (h *Handler) getAllNotes(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	var filter models.NoteFilter
+	if filter.FriendID, err = parseUUIDQuery(c, "friend_id"); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if filter.EventID, err = parseUUIDQuery(c, "event_id"); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if filter.FriendlistID, err = parseUUIDQuery(c, "friendlist_id"); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if filter.MentionedFriendID, err = parseUUIDQuery(c, "mentioned_friend_id"); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	notes, err := h.services.Note.GetAll(userID, filter)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, getAllNotesResponse{
+		Data: notes,
+	})
+}
+
+// @Summary Get Note By Id
+// @Security ApiKeyAuth
+// @Tags note
+// @Description get note by id with mentioned friends
+// @ID get-note-by-id
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Note id"
+// @Success 200 {object} models.Note
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/note/{id} [get]
+func (h *Handler) getNoteByID(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	noteID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	note, err := h.services.Note.GetByID(userID, noteID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("note not found: %s", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]any{
+		"note": note,
+	})
+}
+
+// @Summary Update Note
+// @Security ApiKeyAuth
+// @Tags note
+// @Description update note body, mentions are rebuilt from it
+// @ID update-note
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Note id"
+// @Param input body models.NoteUpdate true "Note info"
+// @Success 200 {object} statusResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/note/{id} [put]
+func (h *Handler) updateNote(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	noteID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	var update models.NoteUpdate
+	if err := c.BindJSON(&update); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	_, err = h.services.Note.GetByID(userID, noteID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("note not found: %s", err.Error()))
+		return
+	}
+
+	if err := h.services.Note.Update(userID, noteID, update); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
+	})
+}
+
+// @Summary Delete Note
+// @Security ApiKeyAuth
+// @Tags note
+// @Description delete note
+// @ID delete-note
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Note id"
+// @Success 200 {object} statusResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/note/{id} [delete]
+func (h *Handler) deleteNote(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	noteID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	_, err = h.services.Note.GetByID(userID, noteID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("note not found: %s", err.Error()))
+		return
+	}
+
+	if err := h.services.Note.DeleteByID(userID, noteID); err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
+	})
+}
@@ -0,0 +1,397 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/lunovoy/friendly/internal/models"
+)
+
+// @Summary Create Photo
+// @Security ApiKeyAuth
+// @Tags friend
+// @Description add image uploaded with /api/image to friend gallery, taken_at is read from EXIF when not set
+// @ID create-photo
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Friend id"
+// @Param input body models.Photo true "Photo info"
+// @Success 201 {object} any
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/friend/{id}/photos [post]
+func (h *Handler) createPhoto(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	friendID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	var photo models.Photo
+	if err := c.BindJSON(&photo); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	_, err = h.services.Friend.GetByID(userID, friendID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("friend not found: %s", err.Error()))
+		return
+	}
+
+	_, err = h.services.Image.GetByID(userID, photo.ImageID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("image not found: %s", err.Error()))
+		return
+	}
+	if photo.TakenAt == nil {
+		key, err := h.findImageKey(c.Request.Context(), photo.ImageID)
+		if err != nil {
+			newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("image not found: %s", err.Error()))
+			return
+		}
+		if takenAt, ok := h.imageTakenAt(c.Request.Context(), key); ok {
+			photo.TakenAt = &takenAt
+		}
+	}
+
+	photoID, err := h.services.Photo.Create(userID, friendID, photo)
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, map[string]any{
+		"photo_id": photoID,
+	})
+}
+
+// @Summary Get All Photos
+// @Security ApiKeyAuth
+// @Tags friend
+// @Description get friend gallery in the set order, the avatar is marked with is_avatar
+// @ID get-all-photos
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Friend id"
+// @Success 200 {object} getAllPhotosResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/friend/{id}/photos [get]
+func (h *Handler) getAllPhotos(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	friendID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	_, err = h.services.Friend.GetByID(userID, friendID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("friend not found: %s", err.Error()))
+		return
+	}
+
+	photos, err := h.services.Photo.GetAllByFriendID(userID, friendID)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, getAllPhotosResponse{
+		Data: photos,
+	})
+}
+
+// @Summary Update Photo
+// @Security ApiKeyAuth
+// @Tags friend
+// @Description update photo caption, date or event
+// @ID update-photo
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Friend id"
+// @Param photo_id path string true "Photo id"
+// @Param input body models.PhotoUpdate true "Photo info"
+// @Success 200 {object} statusResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/friend/{id}/photos/{photo_id} [put]
+func (h *Handler) updatePhoto(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	friendID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	photoID, err := uuid.Parse(c.Param("photo_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid photo_id param")
+		return
+	}
+
+	var update models.PhotoUpdate
+	if err := c.BindJSON(&update); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	_, err = h.services.Photo.GetByID(userID, friendID, photoID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("photo not found: %s", err.Error()))
+		return
+	}
+
+	if err := h.services.Photo.Update(userID, friendID, photoID, update); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
+	})
+}
+
+// @Summary Delete Photo
+// @Security ApiKeyAuth
+// @Tags friend
+// @Description remove photo from friend gallery, removing the avatar clears friend image, the image is deleted once nothing references it
+// @ID delete-photo
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Friend id"
+// @Param photo_id path string true "Photo id"
+// @Success 200 {object} statusResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/friend/{id}/photos/{photo_id} [delete]
+func (h *Handler) deletePhoto(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	friendID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	photoID, err := uuid.Parse(c.Param("photo_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid photo_id param")
+		return
+	}
+
+	photo, err := h.services.Photo.GetByID(userID, friendID, photoID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("photo not found: %s", err.Error()))
+		return
+	}
+
+	if err := h.services.Photo.DeleteByID(userID, photoID); err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if photo.IsAvatar {
+		noImage := uuid.Nil
+		if err := h.services.Friend.Update(userID, friendID, models.UpdateFriendWorkInfoInput{
+			Friend: &models.UpdateFriendInput{ImageID: &noImage},
+		}); err != nil {
+			newErrorResponse(c, http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
+
+	if err := h.services.Image.Release(c.Request.Context(), userID, photo.ImageID); err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
+	})
+}
+
+// @Summary Reorder Photos
+// @Security ApiKeyAuth
+// @Tags friend
+// @Description set order of friend gallery, all photos must be listed
+// @ID reorder-photos
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Friend id"
+// @Param input body models.PhotoOrder true "Photo ids in the new order"
+// @Success 200 {object} statusResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/friend/{id}/photos/order [put]
+func (h *Handler) reorderPhotos(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	friendID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	var order models.PhotoOrder
+	if err := c.BindJSON(&order); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	_, err = h.services.Friend.GetByID(userID, friendID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("friend not found: %s", err.Error()))
+		return
+	}
+
+	if err := h.services.Photo.Reorder(userID, friendID, order.PhotoIDs); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
+	})
+}
+
+// @Summary Set Avatar Photo
+// @Security ApiKeyAuth
+// @Tags friend
+// @Description make photo the friend avatar, friend image is set to the photo image
+// @ID set-avatar-photo
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Friend id"
+// @Param photo_id path string true "Photo id"
+// @Success 200 {object} statusResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/friend/{id}/photos/{photo_id}/avatar [put]
+func (h *Handler) setAvatarPhoto(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	friendID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	photoID, err := uuid.Parse(c.Param("photo_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid photo_id param")
+		return
+	}
+
+	photo, err := h.services.Photo.GetByID(userID, friendID, photoID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("photo not found: %s", err.Error()))
+		return
+	}
+
+	friend, err := h.services.Friend.GetByID(userID, friendID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("friend not found: %s", err.Error()))
+		return
+	}
+
+	// The avatar shares the image with the photo, the previous avatar is
+	// released unless it is a photo of the gallery too
+	if err := h.services.Friend.Update(userID, friendID, models.UpdateFriendWorkInfoInput{
+		Friend: &models.UpdateFriendInput{ImageID: &photo.ImageID},
+	}); err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if friend.Friend.ImageID != photo.ImageID {
+		if err := h.services.Image.Release(c.Request.Context(), userID, friend.Friend.ImageID); err != nil {
+			newErrorResponse(c, http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
+	})
+}
+
+// @Summary Get Event Photos
+// @Security ApiKeyAuth
+// @Tags event
+// @Description get photos of friends attached to the event, in the order they were taken
+// @ID get-event-photos
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Event id"
+// @Success 200 {object} getAllPhotosResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/event/{id}/photos [get]
+func (h *Handler) getEventPhotos(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	_, err = h.services.Event.GetByID(userID, eventID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("event not found: %s", err.Error()))
+		return
+	}
+
+	photos, err := h.services.Photo.GetAllByEventID(userID, eventID)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, getAllPhotosResponse{
+		Data: photos,
+	})
+}
@@ -3,6 +3,7 @@ package handler
 import (
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/lunovoy/friendly/internal/models"
@@ -84,3 +85,167 @@ func (h *Handler) updateProfile(c *gin.Context) {
 	})
 
 }
+
+// @Summary Get Digest Setting
+// @Security ApiKeyAuth
+// @Tags profile
+// @Description get digest setting
+// @ID get-digest-setting
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} models.DigestSetting
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/profile/digest [get]
+func (h *Handler) getDigestSetting(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	setting, err := h.services.Digest.GetSetting(userID)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]any{
+		"digest": setting,
+	})
+}
+
+// @Summary Update Digest Setting
+// @Security ApiKeyAuth
+// @Tags profile
+// @Description update digest setting
+// @ID update-digest-setting
+// @Accept  json
+// @Produce  json
+// @Param input body models.DigestSettingUpdate true "Digest setting"
+// @Success 200 {object} statusResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/profile/digest [put]
+func (h *Handler) updateDigestSetting(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	var payload models.DigestSettingUpdate
+	if err := c.BindJSON(&payload); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = h.services.Digest.UpdateSetting(userID, payload)
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
+	})
+}
+
+// @Summary Preview Digest
+// @Security ApiKeyAuth
+// @Tags profile
+// @Description compose digest as it would be sent now
+// @ID preview-digest
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} models.Digest
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/profile/digest/preview [get]
+func (h *Handler) previewDigest(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	digest, err := h.services.Digest.Compose(userID, time.Now())
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]any{
+		"digest": digest,
+	})
+}
+
+// @Summary Get Notification Preferences
+// @Security ApiKeyAuth
+// @Tags profile
+// @Description get notification preferences
+// @ID get-notification-preferences
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} models.NotificationPreference
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/profile/notifications [get]
+func (h *Handler) getNotificationPreferences(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	preference, err := h.services.NotificationPreference.Get(userID)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]any{
+		"notifications": preference,
+	})
+}
+
+// @Summary Update Notification Preferences
+// @Security ApiKeyAuth
+// @Tags profile
+// @Description update enabled channels, quiet hours and notification categories
+// @ID update-notification-preferences
+// @Accept  json
+// @Produce  json
+// @Param input body models.NotificationPreferenceUpdate true "Notification preferences"
+// @Success 200 {object} statusResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/profile/notifications [put]
+func (h *Handler) updateNotificationPreferences(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	var payload models.NotificationPreferenceUpdate
+	if err := c.BindJSON(&payload); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = h.services.NotificationPreference.Update(userID, payload)
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
+	})
+}
@@ -0,0 +1,301 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/lunovoy/friendly/internal/models"
+)
+
+// @Summary Create Relationship
+// @Security ApiKeyAuth
+// @Tags friend
+// @Description create relationship of friend with another friend (spouse, parent, child, sibling, colleague, introduced_by), directed kinds read as "friend is kind of related friend"
+// @ID create-relationship
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Friend id"
+// @Param input body models.Relationship true "Relationship info"
+// @Success 201 {object} any
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/friend/{id}/relationships [post]
+func (h *Handler) createRelationship(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	friendID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	var relationship models.Relationship
+	if err := c.BindJSON(&relationship); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	_, err = h.services.Friend.GetByID(userID, friendID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("friend not found: %s", err.Error()))
+		return
+	}
+
+	relationshipID, err := h.services.Relationship.Create(userID, friendID, relationship)
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, map[string]any{
+		"relationship_id": relationshipID,
+	})
+}
+
+// @Summary Get All Relationships
+// @Security ApiKeyAuth
+// @Tags friend
+// @Description get all relationships of friend from both sides
+// @ID get-all-relationships
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Friend id"
+// @Success 200 {object} getAllRelationshipsResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/friend/{id}/relationships [get]
+func (h *Handler) getAllRelationships(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	friendID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	_, err = h.services.Friend.GetByID(userID, friendID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("friend not found: %s", err.Error()))
+		return
+	}
+
+	relationships, err := h.services.Relationship.GetAllByFriendID(userID, friendID)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, getAllRelationshipsResponse{
+		Data: relationships,
+	})
+}
+
+// @Summary Get Relationship By Id
+// @Security ApiKeyAuth
+// @Tags friend
+// @Description get relationship of friend by id
+// @ID get-relationship-by-id
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Friend id"
+// @Param relationship_id path string true "Relationship id"
+// @Success 200 {object} models.Relationship
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/friend/{id}/relationships/{relationship_id} [get]
+func (h *Handler) getRelationshipByID(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	friendID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	relationshipID, err := uuid.Parse(c.Param("relationship_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	relationship, err := h.services.Relationship.GetByID(userID, friendID, relationshipID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("relationship not found: %s", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]any{
+		"relationship": relationship,
+	})
+}
+
+// @Summary Update Relationship
+// @Security ApiKeyAuth
+// @Tags friend
+// @Description update kind of relationship as seen from friend
+// @ID update-relationship
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Friend id"
+// @Param relationship_id path string true "Relationship id"
+// @Param input body models.RelationshipUpdate true "Relationship info"
+// @Success 200 {object} statusResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/friend/{id}/relationships/{relationship_id} [put]
+func (h *Handler) updateRelationship(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	friendID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	relationshipID, err := uuid.Parse(c.Param("relationship_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	var update models.RelationshipUpdate
+	if err := c.BindJSON(&update); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	_, err = h.services.Relationship.GetByID(userID, friendID, relationshipID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("relationship not found: %s", err.Error()))
+		return
+	}
+
+	if err := h.services.Relationship.Update(userID, friendID, relationshipID, update); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
+	})
+}
+
+// @Summary Delete Relationship
+// @Security ApiKeyAuth
+// @Tags friend
+// @Description delete relationship of friend
+// @ID delete-relationship
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Friend id"
+// @Param relationship_id path string true "Relationship id"
+// @Success 200 {object} statusResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/friend/{id}/relationships/{relationship_id} [delete]
+func (h *Handler) deleteRelationship(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	friendID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	relationshipID, err := uuid.Parse(c.Param("relationship_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	_, err = h.services.Relationship.GetByID(userID, friendID, relationshipID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("relationship not found: %s", err.Error()))
+		return
+	}
+
+	if err := h.services.Relationship.DeleteByID(userID, relationshipID); err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
+	})
+}
+
+// @Summary Get Friends Graph
+// @Security ApiKeyAuth
+// @Tags friend
+// @Description get friends as nodes and relationships as edges for visualization
+// @ID get-friends-graph
+// @Accept  json
+// @Produce  json
+// @Param friendlist_id query string false "Keep friends of friendlist"
+// @Param tag_id query string false "Keep friends with tag"
+// @Success 200 {object} models.Graph
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/friend/graph [get]
+func (h *Handler) getFriendsGraph(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	friendlistID, err := parseUUIDQuery(c, "friendlist_id")
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if friendlistID != nil {
+		if _, err := h.services.Friendlist.GetByID(userID, *friendlistID); err != nil {
+			newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("friendlist not found: %s", err.Error()))
+			return
+		}
+	}
+	tagID, err := parseUUIDQuery(c, "tag_id")
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	graph, err := h.services.Relationship.GetGraph(userID, friendlistID, tagID)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]any{
+		"graph": graph,
+	})
+}
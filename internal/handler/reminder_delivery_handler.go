@@ -0,0 +1,214 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/lunovoy/friendly/internal/models"
+)
+
+// @Summary Get All Reminder Deliveries
+// @Security ApiKeyAuth
+// @Tags reminder
+// @Description get all fired reminder deliveries, optionally filtered by status
+// @ID get-all-reminder-deliveries
+// @Accept  json
+// @Produce  json
+// @Param status query string false "Delivery status (pending, sent, acknowledged, snoozed, dismissed, failed)"
+// @Success 200 {object} getAllReminderDeliveriesResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/reminder/delivery [get]
+func (h *Handler) getAllReminderDeliveries(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	deliveries, err := h.services.ReminderDelivery.GetAll(userID, c.Query("status"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, getAllReminderDeliveriesResponse{
+		Data: deliveries,
+	})
+}
+
+// @Summary Get Reminder Delivery By Id
+// @Security ApiKeyAuth
+// @Tags reminder
+// @Description get reminder delivery by id
+// @ID get-reminder-delivery-by-id
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Delivery id"
+// @Success 200 {object} models.ReminderDelivery
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/reminder/delivery/{id} [get]
+func (h *Handler) getReminderDeliveryByID(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	deliveryID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	delivery, err := h.services.ReminderDelivery.GetByID(userID, deliveryID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]any{
+		"delivery": delivery,
+	})
+}
+
+// @Summary Acknowledge Reminder Delivery
+// @Security ApiKeyAuth
+// @Tags reminder
+// @Description acknowledge fired reminder
+// @ID acknowledge-reminder-delivery
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Delivery id"
+// @Success 200 {object} statusResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/reminder/delivery/{id}/acknowledge [post]
+func (h *Handler) acknowledgeReminderDelivery(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	deliveryID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	_, err = h.services.ReminderDelivery.GetByID(userID, deliveryID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("delivery not found: %s", err.Error()))
+		return
+	}
+
+	err = h.services.ReminderDelivery.Acknowledge(userID, deliveryID)
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
+	})
+}
+
+// @Summary Snooze Reminder Delivery
+// @Security ApiKeyAuth
+// @Tags reminder
+// @Description snooze fired reminder for some minutes or until the given time
+// @ID snooze-reminder-delivery
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Delivery id"
+// @Param input body models.ReminderDeliverySnooze true "Snooze info"
+// @Success 200 {object} statusResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/reminder/delivery/{id}/snooze [post]
+func (h *Handler) snoozeReminderDelivery(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	deliveryID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	var payload models.ReminderDeliverySnooze
+	if err := c.BindJSON(&payload); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	_, err = h.services.ReminderDelivery.GetByID(userID, deliveryID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("delivery not found: %s", err.Error()))
+		return
+	}
+
+	err = h.services.ReminderDelivery.Snooze(userID, deliveryID, payload)
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
+	})
+}
+
+// @Summary Dismiss Reminder Delivery
+// @Security ApiKeyAuth
+// @Tags reminder
+// @Description dismiss fired reminder
+// @ID dismiss-reminder-delivery
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Delivery id"
+// @Success 200 {object} statusResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/reminder/delivery/{id}/dismiss [post]
+func (h *Handler) dismissReminderDelivery(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	deliveryID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	_, err = h.services.ReminderDelivery.GetByID(userID, deliveryID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("delivery not found: %s", err.Error()))
+		return
+	}
+
+	err = h.services.ReminderDelivery.Dismiss(userID, deliveryID)
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
+	})
+}
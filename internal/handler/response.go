@@ -11,7 +11,8 @@ type errorResponse struct {
 }
 
 type getAllEventsResponse struct {
-	Data []models.Event `json:"data"`
+	Data       []models.Event `json:"data"`
+	NextCursor string         `json:"next_cursor,omitempty"`
 }
 
 type getAllEventsFullInfo struct {
@@ -23,15 +24,18 @@ type getAllEventsWithFriendsResponse struct {
 }
 
 type getAllTagsResponse struct {
-	Data []models.Tag `json:"data"`
+	Data       []models.Tag `json:"data"`
+	NextCursor string       `json:"next_cursor,omitempty"`
 }
 
 type getAllFriendsResponse struct {
-	Data []models.FriendWorkInfoTags `json:"data"`
+	Data       []models.FriendWorkInfoTags `json:"data"`
+	NextCursor string                      `json:"next_cursor,omitempty"`
 }
 
 type getAllFriendlistsResponse struct {
-	Data []models.Friendlist `json:"data"`
+	Data       []models.Friendlist `json:"data"`
+	NextCursor string              `json:"next_cursor,omitempty"`
 }
 
 type getAllFriendlistsFullResponse struct {
@@ -50,6 +54,86 @@ type getAllRemindersResponse struct {
 	Data []models.Reminder `json:"data"`
 }
 
+type getAllReminderDeliveriesResponse struct {
+	Data []models.ReminderDelivery `json:"data"`
+}
+
+type getAllFriendDatesResponse struct {
+	Data []models.FriendDate `json:"data"`
+}
+
+type getAllGiftIdeasResponse struct {
+	Data []models.GiftIdea `json:"data"`
+}
+
+type getAllNotesResponse struct {
+	Data []models.Note `json:"data"`
+}
+
+type getAllAttachmentsResponse struct {
+	Data []models.Attachment `json:"data"`
+}
+
+type getAllPhotosResponse struct {
+	Data []models.Photo `json:"data"`
+}
+
+type getFriendlistSharesResponse struct {
+	Data []models.FriendlistShare `json:"data"`
+}
+
+type getOverdueFriendsResponse struct {
+	Data []models.OverdueFriend `json:"data"`
+}
+
+type getAllContactPointsResponse struct {
+	Data []models.ContactPoint `json:"data"`
+}
+
+type getAllEmploymentsResponse struct {
+	Data []models.Employment `json:"data"`
+}
+
+type getAllRelationshipsResponse struct {
+	Data []models.Relationship `json:"data"`
+}
+
+type getSearchResponse struct {
+	Data []models.SearchResult `json:"data"`
+}
+
+type getAllInteractionsResponse struct {
+	Data []models.Interaction `json:"data"`
+}
+
+type getTimelineResponse struct {
+	Data []models.TimelineItem `json:"data"`
+}
+
+type getFriendHistoryResponse struct {
+	Data []models.FriendChange `json:"data"`
+}
+
+type getAllAdditionalInfoFieldsResponse struct {
+	Data []models.AdditionalInfoField `json:"data"`
+}
+
+type getFriendAdditionalInfoFieldsResponse struct {
+	Data []models.FriendAdditionalInfoField `json:"data"`
+}
+
+type getCalendarResponse struct {
+	Data []models.Occurrence `json:"data"`
+}
+
+type getTrashResponse struct {
+	Data []models.TrashItem `json:"data"`
+}
+
+type getDuplicateFriendsResponse struct {
+	Data []models.DuplicateCandidate `json:"data"`
+}
+
 type statusResponse struct {
 	Status string
 }
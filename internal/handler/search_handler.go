@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+const defaultSearchLimit = 20
+
+// @Summary Search
+// @Security ApiKeyAuth
+// @Tags search
+// @Description full-text and fuzzy search across friends, events, interactions and notes, matches in snippets are wrapped in <b></b>
+// @ID search
+// @Accept  json
+// @Produce  json
+// @Param q query string true "Search query, supports quotes, or and -"
+// @Param limit query int false "Max results, 1-100 (default 20)"
+// @Success 200 {object} getSearchResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/search [get]
+func (h *Handler) search(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	limit := defaultSearchLimit
+	if param := c.Query("limit"); param != "" {
+		limit, err = strconv.Atoi(param)
+		if err != nil {
+			newErrorResponse(c, http.StatusBadRequest, "invalid limit param")
+			return
+		}
+	}
+
+	results, err := h.services.Search.Search(userID, c.Query("q"), limit)
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, getSearchResponse{
+		Data: results,
+	})
+}
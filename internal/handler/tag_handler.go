@@ -59,10 +59,14 @@ func (h *Handler) createTag(c *gin.Context) {
 // @Summary Get All Tags
 // @Security ApiKeyAuth
 // @Tags tag
-// @Description get all tags
+// @Description get page of tags sorted by title
 // @ID get-all-tags
 // @Accept  json
 // @Produce  json
+// @Param limit query int false "Page size, 1-200 (default 50)"
+// @Param sort query string false "Sort key: title (default)"
+// @Param order query string false "asc (default) or desc"
+// @Param cursor query string false "next_cursor of the previous page"
 // @Success 200 {object} getAllTagsResponse
 // @Failure 400,404 {object} errorResponse
 // @Failure 500 {object} errorResponse
@@ -75,14 +79,21 @@ func (h *Handler) getAllTags(c *gin.Context) {
 		return
 	}
 
-	tags, err := h.services.Tag.GetAll(userID)
+	params, err := parseListParams(c)
 	if err != nil {
-		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	tags, next, err := h.services.Tag.List(userID, params)
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	c.JSON(http.StatusOK, getAllTagsResponse{
-		Data: tags,
+		Data:       tags,
+		NextCursor: next,
 	})
 
 }
@@ -177,7 +188,7 @@ func (h *Handler) updateTag(c *gin.Context) {
 // @Summary Delete Tag
 // @Security ApiKeyAuth
 // @Tags tag
-// @Description delete tag
+// @Description move tag to trash, it can be restored until purged
 // @ID delete-tag
 // @Accept  json
 // @Produce  json
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// @Summary Get Trash
+// @Security ApiKeyAuth
+// @Tags trash
+// @Description get deleted friends, events, friendlists and tags, the latest deleted first; they are purged after the retention
+// @ID get-trash
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} getTrashResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/trash [get]
+func (h *Handler) getTrash(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	items, err := h.services.Trash.GetAll(userID)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, getTrashResponse{
+		Data: items,
+	})
+}
+
+// @Summary Restore From Trash
+// @Security ApiKeyAuth
+// @Tags trash
+// @Description restore deleted friend, event, friendlist or tag with its tags, friendlists and events
+// @ID restore-from-trash
+// @Accept  json
+// @Produce  json
+// @Param kind path string true "friend, event, friendlist or tag"
+// @Param id path string true "Id"
+// @Success 200 {object} statusResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/trash/{kind}/{id}/restore [post]
+func (h *Handler) restoreFromTrash(c *gin.Context) {
+	userID, err := getUserIDFromCtx(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "user id from ctx not found")
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "invalid id param")
+		return
+	}
+
+	err = h.services.Trash.Restore(userID, c.Param("kind"), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		newErrorResponse(c, http.StatusNotFound, fmt.Sprintf("%s not found in trash", c.Param("kind")))
+		return
+	}
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
+	})
+}
@@ -1,14 +1,19 @@
 package handler
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"mime/multipart"
 	"net/http"
-	"os"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/lunovoy/friendly/internal/models"
+	"github.com/lunovoy/friendly/internal/service"
+	"github.com/lunovoy/friendly/internal/storage"
 	_ "golang.org/x/image/webp"
 )
 
@@ -28,17 +33,110 @@ func getUserIDFromCtx(c *gin.Context) (uuid.UUID, error) {
 	return convertedID, nil
 }
 
-func saveFile(c *gin.Context, file *multipart.FileHeader, filePath string) error {
+func (h *Handler) saveFile(c *gin.Context, file *multipart.FileHeader, key, contentType string) error {
+	src, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer src.Close()
 
-	err := c.SaveUploadedFile(file, filePath)
-	return err
+	return h.storage.Put(c.Request.Context(), key, src, contentType)
 }
 
 // Функция для удаления файла
-func deleteFile(filePath string) error {
-	err := os.Remove(filePath)
-	if err != nil {
+func (h *Handler) deleteFile(ctx context.Context, key string) error {
+	if err := h.storage.Delete(ctx, key); err != nil {
 		return fmt.Errorf("unable to delete file: %w", err)
 	}
 	return nil
 }
+
+// findImageKey returns the storage key of the uploaded image with any of the
+// supported extensions, storage.ErrNotFound when there is no such image.
+func (h *Handler) findImageKey(ctx context.Context, imageID uuid.UUID) (string, error) {
+	for _, ext := range service.ImageExtensions {
+		key := service.ImageKey(imageID, ext)
+		_, err := h.storage.Stat(ctx, key)
+		if err == nil {
+			return key, nil
+		}
+		if !errors.Is(err, storage.ErrNotFound) {
+			return "", err
+		}
+	}
+	return "", storage.ErrNotFound
+}
+
+// checkImageOwner accepts only images uploaded by the user to be set on the
+// user's friends and friendlists.
+func (h *Handler) checkImageOwner(userID, imageID uuid.UUID) error {
+	if imageID == uuid.Nil {
+		return nil
+	}
+	if _, err := h.services.Image.GetByID(userID, imageID); err != nil {
+		return fmt.Errorf("image not found: %s", err.Error())
+	}
+	return nil
+}
+
+// parseDateParam accepts either a full RFC3339 timestamp or a bare date.
+func parseDateParam(param string) (time.Time, error) {
+	if date, err := time.Parse(time.RFC3339, param); err == nil {
+		return date, nil
+	}
+	return time.Parse("2006-01-02", param)
+}
+
+const defaultListLimit = 50
+
+// parseListParams reads limit, sort, order and cursor query params of list
+// endpoints.
+func parseListParams(c *gin.Context) (models.ListParams, error) {
+	params := models.ListParams{
+		Sort:   c.Query("sort"),
+		Cursor: c.Query("cursor"),
+		Limit:  defaultListLimit,
+	}
+
+	if param := c.Query("limit"); param != "" {
+		limit, err := strconv.Atoi(param)
+		if err != nil {
+			return params, errors.New("invalid limit param")
+		}
+		params.Limit = limit
+	}
+
+	switch c.Query("order") {
+	case "", "asc":
+	case "desc":
+		params.Desc = true
+	default:
+		return params, errors.New("order must be asc or desc")
+	}
+
+	return params, nil
+}
+
+func parseUUIDQuery(c *gin.Context, name string) (*uuid.UUID, error) {
+	param := c.Query(name)
+	if param == "" {
+		return nil, nil
+	}
+	id, err := uuid.Parse(param)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s param", name)
+	}
+	return &id, nil
+}
+
+func parseDateQuery(c *gin.Context, name string) (*time.Time, error) {
+	param := c.Query(name)
+	if param == "" {
+		return nil, nil
+	}
+	date, err := parseDateParam(param)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s param: %w", name, err)
+	}
+	return &date, nil
+}
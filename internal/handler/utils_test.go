@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lunovoy/friendly/internal/models"
+)
+
+func queryContext(query string) *gin.Context {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, "/?"+query, nil)
+	return c
+}
+
+func TestParseListParams(t *testing.T) {
+	tests := []struct {
+		query   string
+		want    models.ListParams
+		wantErr bool
+	}{
+		{query: "", want: models.ListParams{Limit: defaultListLimit}},
+		{query: "limit=10&sort=dob&order=desc&cursor=abc", want: models.ListParams{Sort: "dob", Desc: true, Cursor: "abc", Limit: 10}},
+		{query: "order=asc", want: models.ListParams{Limit: defaultListLimit}},
+		{query: "limit=ten", wantErr: true},
+		{query: "order=up", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseListParams(queryContext(tt.query))
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseListParams(%q) error = %v, wantErr %v", tt.query, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("parseListParams(%q) = %+v, want %+v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestParseDateParam(t *testing.T) {
+	tests := []struct {
+		param   string
+		want    time.Time
+		wantErr bool
+	}{
+		{param: "2024-03-10", want: time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC)},
+		{param: "2024-03-10T12:30:00Z", want: time.Date(2024, time.March, 10, 12, 30, 0, 0, time.UTC)},
+		{param: "2024-03-10T12:30:00+03:00", want: time.Date(2024, time.March, 10, 9, 30, 0, 0, time.UTC)},
+		{param: "10.03.2024", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseDateParam(tt.param)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseDateParam(%q) error = %v, wantErr %v", tt.param, err, tt.wantErr)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("parseDateParam(%q) = %s, want %s", tt.param, got, tt.want)
+		}
+	}
+}
+
+func TestParseUUIDQuery(t *testing.T) {
+	id, err := parseUUIDQuery(queryContext("tag_id=11111111-1111-1111-1111-111111111111"), "tag_id")
+	if err != nil || id == nil || id.String() != "11111111-1111-1111-1111-111111111111" {
+		t.Errorf("parseUUIDQuery() = %v, %v", id, err)
+	}
+	if id, err := parseUUIDQuery(queryContext(""), "tag_id"); id != nil || err != nil {
+		t.Errorf("parseUUIDQuery() of a missing param = %v, %v, want nil", id, err)
+	}
+	if _, err := parseUUIDQuery(queryContext("tag_id=1"), "tag_id"); err == nil {
+		t.Error("parseUUIDQuery() of an invalid id succeeded, want error")
+	}
+}
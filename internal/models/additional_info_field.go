@@ -1,11 +1,32 @@
 package models
 
-import "github.com/google/uuid"
+import (
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const (
+	AdditionalInfoFieldTypeText         = "text"
+	AdditionalInfoFieldTypeNumber       = "number"
+	AdditionalInfoFieldTypeDate         = "date"
+	AdditionalInfoFieldTypeURL          = "url"
+	AdditionalInfoFieldTypeSingleSelect = "single_select"
+	AdditionalInfoFieldTypeMultiSelect  = "multi_select"
+)
 
 type AdditionalInfoField struct {
-	ID     uuid.UUID `json:"id,omitempty" db:"id"`
-	Title  string    `json:"title" db:"title"`
-	UserID uuid.UUID `json:"user_id" db:"user_id"`
+	ID      uuid.UUID      `json:"id,omitempty" db:"id"`
+	Title   string         `json:"title" db:"title" binding:"required"`
+	Type    string         `json:"type" db:"type"`
+	Options pq.StringArray `json:"options" db:"options"`
+	UserID  uuid.UUID      `json:"user_id" db:"user_id"`
+}
+
+type AdditionalInfoFieldUpdate struct {
+	Title   *string  `json:"title"`
+	Options []string `json:"options"`
 }
 
 type FriendsAdditionalInfoFields struct {
@@ -20,3 +41,20 @@ type AdditionalInfoFieldText struct {
 	AdditionalInfoFieldID uuid.UUID `json:"additional_info_field_id" db:"additional_info_field_id"`
 	FriendID              uuid.UUID `json:"friend_id" db:"friend_id"`
 }
+
+// AdditionalInfoFieldContent is a field attached to a friend with its raw
+// stored value.
+type AdditionalInfoFieldContent struct {
+	AdditionalInfoField
+	FriendID uuid.UUID      `db:"friend_id"`
+	Content  sql.NullString `db:"content"`
+}
+
+type FriendAdditionalInfoField struct {
+	Field AdditionalInfoField `json:"field"`
+	Value any                 `json:"value"`
+}
+
+type AdditionalInfoFieldValueInput struct {
+	Value any `json:"value"`
+}
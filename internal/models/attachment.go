@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Attachment is a document attached to a friend or an event. MimeType is
+// sniffed from the file content on upload.
+type Attachment struct {
+	ID        uuid.UUID     `json:"id" db:"id"`
+	FileName  string        `json:"file_name" db:"file_name"`
+	MimeType  string        `json:"mime_type" db:"mime_type"`
+	Size      int64         `json:"size" db:"size"`
+	FriendID  uuid.NullUUID `json:"friend_id" db:"friend_id"`
+	EventID   uuid.NullUUID `json:"event_id" db:"event_id"`
+	CreatedAt time.Time     `json:"created_at" db:"created_at"`
+	UserID    uuid.UUID     `json:"user_id" db:"user_id"`
+}
+
+type AttachmentFilter struct {
+	FriendID *uuid.UUID
+	EventID  *uuid.UUID
+}
+
+// StorageUsage is the size of attachments of the user against the quota,
+// both in bytes.
+type StorageUsage struct {
+	Used  int64 `json:"used" db:"used"`
+	Quota int64 `json:"quota" db:"quota"`
+}
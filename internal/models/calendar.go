@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	OccurrenceKindEvent      = "event"
+	OccurrenceKindBirthday   = "birthday"
+	OccurrenceKindFriendDate = "friend_date"
+)
+
+type Occurrence struct {
+	Kind         string     `json:"kind"`
+	Title        string     `json:"title"`
+	Date         time.Time  `json:"date"`
+	EventID      *uuid.UUID `json:"event_id,omitempty"`
+	FriendID     *uuid.UUID `json:"friend_id,omitempty"`
+	FriendDateID *uuid.UUID `json:"friend_date_id,omitempty"`
+	Age          int        `json:"age,omitempty"`
+}
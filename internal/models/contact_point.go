@@ -0,0 +1,30 @@
+package models
+
+import "github.com/google/uuid"
+
+const (
+	ContactPointKindPhone    = "phone"
+	ContactPointKindEmail    = "email"
+	ContactPointKindTelegram = "telegram"
+	ContactPointKindWhatsApp = "whatsapp"
+	ContactPointKindSignal   = "signal"
+	ContactPointKindSocial   = "social"
+	ContactPointKindAddress  = "address"
+)
+
+type ContactPoint struct {
+	ID        uuid.UUID `json:"id" db:"id"`
+	Kind      string    `json:"kind" db:"kind"`
+	Label     string    `json:"label" db:"label"`
+	Value     string    `json:"value" db:"value"`
+	IsPrimary bool      `json:"is_primary" db:"is_primary"`
+	FriendID  uuid.UUID `json:"friend_id" db:"friend_id"`
+	UserID    uuid.UUID `json:"user_id" db:"user_id"`
+}
+
+type ContactPointUpdate struct {
+	Kind      *string `json:"kind"`
+	Label     *string `json:"label"`
+	Value     *string `json:"value"`
+	IsPrimary *bool   `json:"is_primary"`
+}
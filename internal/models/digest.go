@@ -0,0 +1,58 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type DigestSetting struct {
+	ID         uuid.UUID    `json:"id" db:"id"`
+	IsActive   bool         `json:"is_active" db:"is_active"`
+	Frequency  string       `json:"frequency" db:"frequency"`
+	TimeOfDay  string       `json:"time_of_day" db:"time_of_day"`
+	Weekday    int          `json:"weekday" db:"weekday"`
+	Timezone   string       `json:"timezone" db:"timezone"`
+	DaysAhead  int          `json:"days_ahead" db:"days_ahead"`
+	LastSentAt sql.NullTime `json:"last_sent_at" db:"last_sent_at"`
+	UserID     uuid.UUID    `json:"user_id" db:"user_id"`
+}
+
+type DigestSettingUpdate struct {
+	IsActive  *bool   `json:"is_active"`
+	Frequency *string `json:"frequency"`
+	TimeOfDay *string `json:"time_of_day"`
+	Weekday   *int    `json:"weekday"`
+	Timezone  *string `json:"timezone"`
+	DaysAhead *int    `json:"days_ahead"`
+}
+
+type DigestBirthday struct {
+	Friend     Friend     `json:"friend"`
+	Date       time.Time  `json:"date"`
+	AgeTurning int        `json:"age_turning"`
+	GiftIdeas  []GiftIdea `json:"gift_ideas,omitempty"`
+}
+
+type DigestEvent struct {
+	Event     Event      `json:"event"`
+	Date      time.Time  `json:"date"`
+	GiftIdeas []GiftIdea `json:"gift_ideas,omitempty"`
+}
+
+type DigestFriendDate struct {
+	FriendDate FriendDate `json:"friend_date"`
+	Friend     Friend     `json:"friend"`
+	Date       time.Time  `json:"date"`
+	Years      int        `json:"years"`
+}
+
+type Digest struct {
+	From      time.Time          `json:"from"`
+	To        time.Time          `json:"to"`
+	Birthdays []DigestBirthday   `json:"birthdays"`
+	Dates     []DigestFriendDate `json:"dates"`
+	Events    []DigestEvent      `json:"events"`
+	Overdue   []OverdueFriend    `json:"overdue"`
+}
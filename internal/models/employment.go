@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Employment struct {
+	ID         uuid.UUID  `json:"id" db:"id"`
+	Company    string     `json:"company" db:"company"`
+	Position   string     `json:"position" db:"position"`
+	Profession string     `json:"profession" db:"profession"`
+	City       string     `json:"city" db:"city"`
+	StartedAt  *time.Time `json:"started_at" db:"started_at"`
+	EndedAt    *time.Time `json:"ended_at" db:"ended_at"`
+	IsCurrent  bool       `json:"is_current" db:"-"`
+	FriendID   uuid.UUID  `json:"friend_id" db:"friend_id"`
+	UserID     uuid.UUID  `json:"user_id" db:"user_id"`
+}
+
+type EmploymentUpdate struct {
+	Company    *string    `json:"company"`
+	Position   *string    `json:"position"`
+	Profession *string    `json:"profession"`
+	City       *string    `json:"city"`
+	StartedAt  *time.Time `json:"started_at"`
+	EndedAt    *time.Time `json:"ended_at"`
+	Current    *bool      `json:"current"` // true removes the end date
+}
@@ -15,6 +15,7 @@ type Event struct {
 	Frequency   string       `json:"frequency" db:"frequency"`
 	IsActive    bool         `json:"is_active" db:"is_active"`
 	UserID      uuid.UUID    `json:"user_id" db:"user_id"`
+	DeletedAt   sql.NullTime `json:"-" db:"deleted_at"`
 }
 
 type EventWithFriends struct {
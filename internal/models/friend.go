@@ -8,25 +8,35 @@ import (
 )
 
 type Friend struct {
-	ID        uuid.UUID    `json:"id,omitempty" db:"id"`
-	FirstName string       `json:"first_name" db:"first_name"`
-	LastName  string       `json:"last_name" db:"last_name"`
-	DOB       sql.NullTime `json:"dob" db:"dob"`
-	ImageID   uuid.UUID    `json:"image_id" db:"image_id"`
-	UserID    uuid.UUID    `json:"user_id" db:"user_id"`
+	ID            uuid.UUID    `json:"id,omitempty" db:"id"`
+	FirstName     string       `json:"first_name" db:"first_name"`
+	LastName      string       `json:"last_name" db:"last_name"`
+	DOB           sql.NullTime `json:"dob" db:"dob"`
+	ImageID       uuid.UUID    `json:"image_id" db:"image_id"`
+	TrackBirthday bool         `json:"track_birthday" db:"track_birthday"`
+	UserID        uuid.UUID    `json:"user_id" db:"user_id"`
+
+	ContactIntervalDays *int         `json:"contact_interval_days" db:"contact_interval_days"`
+	LastContactedAt     sql.NullTime `json:"last_contacted_at" db:"last_contacted_at"`
+	DeletedAt           sql.NullTime `json:"-" db:"deleted_at"`
 }
 
 type UpdateFriendInput struct {
-	FirstName *string    `json:"first_name"`
-	LastName  *string    `json:"last_name"`
-	DOB       *time.Time `json:"dob"`
-	ImageID   *uuid.UUID `json:"image_id"`
+	FirstName     *string    `json:"first_name"`
+	LastName      *string    `json:"last_name"`
+	DOB           *time.Time `json:"dob"` // zero time removes the dob
+	ImageID       *uuid.UUID `json:"image_id"`
+	TrackBirthday *bool      `json:"track_birthday"`
+
+	ContactIntervalDays *int `json:"contact_interval_days"` // 0 removes the cadence
 }
 
 type FriendWorkInfoTags struct {
-	Friend   Friend   `json:"friend"`
-	WorkInfo WorkInfo `json:"work_info"`
-	Tags     []Tag    `json:"tags,omitempty"`
+	Friend           Friend                      `json:"friend"`
+	WorkInfo         WorkInfo                    `json:"work_info"`
+	Tags             []Tag                       `json:"tags,omitempty"`
+	AdditionalFields []FriendAdditionalInfoField `json:"additional_fields,omitempty"`
+	ContactPoints    []ContactPoint              `json:"contact_points,omitempty"`
 }
 
 type UpdateFriendWorkInfoInput struct {
@@ -59,3 +69,21 @@ type FriendWithTags struct {
 type FriendID struct {
 	FriendID uuid.UUID `json:"friend_id"`
 }
+
+type ContactCadence struct {
+	FriendID            uuid.UUID    `json:"friend_id" db:"friend_id"`
+	ContactIntervalDays int          `json:"contact_interval_days" db:"contact_interval_days"`
+	LastContactedAt     sql.NullTime `json:"last_contacted_at" db:"last_contacted_at"`
+}
+
+type OverdueFriend struct {
+	Friend              Friend     `json:"friend"`
+	ContactIntervalDays int        `json:"contact_interval_days"`
+	LastContactedAt     *time.Time `json:"last_contacted_at"`
+	DueAt               *time.Time `json:"due_at"`
+	OverdueDays         int        `json:"overdue_days"`
+}
+
+type FriendContacted struct {
+	At *time.Time `json:"at"`
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const (
+	FriendDateRecurrenceAnnually = "annually"
+	FriendDateRecurrenceOnce     = "once"
+)
+
+type FriendDate struct {
+	ID              uuid.UUID     `json:"id" db:"id"`
+	Label           string        `json:"label" db:"label"`
+	Kind            string        `json:"kind" db:"kind"`
+	Month           int           `json:"month" db:"month"`
+	Day             int           `json:"day" db:"day"`
+	Year            *int          `json:"year" db:"year"`
+	Recurrence      string        `json:"recurrence" db:"recurrence"`
+	ReminderMinutes pq.Int64Array `json:"reminder_minutes" db:"reminder_minutes"`
+	FriendID        uuid.UUID     `json:"friend_id" db:"friend_id"`
+	UserID          uuid.UUID     `json:"user_id" db:"user_id"`
+}
+
+type FriendDateUpdate struct {
+	Label           *string `json:"label"`
+	Kind            *string `json:"kind"`
+	Month           *int    `json:"month"`
+	Day             *int    `json:"day"`
+	Year            *int    `json:"year"` // 0 removes the year
+	Recurrence      *string `json:"recurrence"`
+	ReminderMinutes []int64 `json:"reminder_minutes"`
+}
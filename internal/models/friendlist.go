@@ -1,6 +1,8 @@
 package models
 
 import (
+	"database/sql"
+
 	"github.com/google/uuid"
 )
 
@@ -11,6 +13,14 @@ type Friendlist struct {
 	Color       string    `json:"color" db:"color"`
 	ImageID     uuid.UUID `json:"image_id" db:"image_id"`
 	UserID      uuid.UUID `json:"user_id" db:"user_id"`
+
+	ContactIntervalDays *int `json:"contact_interval_days" db:"contact_interval_days"`
+
+	// Rule makes the friendlist smart, its friends are the ones matching
+	// the rule instead of added by hand
+	Rule *string `json:"rule" db:"rule"`
+
+	DeletedAt sql.NullTime `json:"-" db:"deleted_at"`
 }
 
 type UpdateFriendlist struct {
@@ -19,6 +29,9 @@ type UpdateFriendlist struct {
 	Color       *string    `json:"color" db:"color"`
 	ImageID     *uuid.UUID `json:"image_id" db:"image_id"`
 	UserID      *uuid.UUID `json:"user_id" db:"user_id"`
+
+	ContactIntervalDays *int    `json:"contact_interval_days" db:"contact_interval_days"` // 0 removes the cadence
+	Rule                *string `json:"rule" db:"rule"`                                   // empty removes the rule
 }
 
 type FriendlistsTags struct {
@@ -44,8 +57,14 @@ type FriendlistWithTagsUpdate struct {
 }
 
 type FriendlistFull struct {
-	FriendlistWithTags FriendlistWithTags   `json:"friendlist"`
-	FriendsWithTags    []FriendWorkInfoTags `json:"friends"`
+	FriendlistWithTags FriendlistWithTags    `json:"friendlist"`
+	FriendsWithTags    []FriendWorkInfoTags  `json:"friends"`
+	Fields             []AdditionalInfoField `json:"fields"`
+}
+
+type FriendlistTemplateField struct {
+	FriendlistID uuid.UUID `db:"friendlist_id"`
+	AdditionalInfoField
 }
 
 type FriendlistWithFriends struct {
@@ -56,3 +75,7 @@ type FriendlistWithFriends struct {
 type AdditionFriendToFriendlist struct {
 	FriendID uuid.UUID `json:"friend_id" db:"friend_id" binding:"required"`
 }
+
+type AdditionFieldToFriendlist struct {
+	FieldID uuid.UUID `json:"field_id" binding:"required"`
+}
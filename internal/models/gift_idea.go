@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	GiftIdeaStatusIdea   = "idea"
+	GiftIdeaStatusBought = "bought"
+	GiftIdeaStatusGiven  = "given"
+)
+
+const (
+	GiftOccasionBirthday = "birthday"
+	GiftOccasionEvent    = "event"
+)
+
+type GiftIdea struct {
+	ID           uuid.UUID     `json:"id" db:"id"`
+	Title        string        `json:"title" db:"title" binding:"required"`
+	Link         string        `json:"link" db:"link"`
+	Price        *float64      `json:"price" db:"price"`
+	Status       string        `json:"status" db:"status"`
+	Occasion     string        `json:"occasion" db:"occasion"`
+	OccasionDate *time.Time    `json:"occasion_date" db:"occasion_date"`
+	EventID      uuid.NullUUID `json:"event_id" db:"event_id"`
+	CreatedAt    time.Time     `json:"created_at" db:"created_at"`
+	FriendID     uuid.UUID     `json:"friend_id" db:"friend_id"`
+	UserID       uuid.UUID     `json:"user_id" db:"user_id"`
+}
+
+type GiftIdeaUpdate struct {
+	Title        *string    `json:"title"`
+	Link         *string    `json:"link"`
+	Price        *float64   `json:"price"` // negative removes the price
+	Status       *string    `json:"status"`
+	Occasion     *string    `json:"occasion"` // empty removes the occasion
+	OccasionDate *time.Time `json:"occasion_date"`
+	EventID      *uuid.UUID `json:"event_id"`
+}
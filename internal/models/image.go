@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Image is metadata of an uploaded image, the file is stored as
+// <id>.<ext> in the upload dir.
+type Image struct {
+	ID        uuid.UUID `json:"id" db:"id"`
+	MimeType  string    `json:"mime_type" db:"mime_type"`
+	Width     int       `json:"width" db:"width"`
+	Height    int       `json:"height" db:"height"`
+	Size      int64     `json:"size" db:"size"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UserID    uuid.UUID `json:"user_id" db:"user_id"`
+}
+
+// FriendlistShare gives another user access to the friendlist images and
+// images of its friends.
+type FriendlistShare struct {
+	ID           uuid.UUID `json:"id" db:"id"`
+	FriendlistID uuid.UUID `json:"friendlist_id" db:"friendlist_id"`
+	UserID       uuid.UUID `json:"user_id" db:"user_id"`
+	Username     string    `json:"username" db:"username"`
+	Mail         string    `json:"mail" db:"mail"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+}
+
+type FriendlistShareInput struct {
+	Mail string `json:"mail" binding:"required"`
+}
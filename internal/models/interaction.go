@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	InteractionKindCall    = "call"
+	InteractionKindMessage = "message"
+	InteractionKindMeeting = "meeting"
+	InteractionKindNote    = "note"
+)
+
+type Interaction struct {
+	ID         uuid.UUID     `json:"id" db:"id"`
+	Kind       string        `json:"kind" db:"kind" binding:"required"`
+	OccurredAt time.Time     `json:"occurred_at" db:"occurred_at"`
+	Channel    string        `json:"channel" db:"channel"`
+	Text       string        `json:"text" db:"text"`
+	EventID    uuid.NullUUID `json:"event_id" db:"event_id"`
+	FriendID   uuid.UUID     `json:"friend_id" db:"friend_id"`
+	UserID     uuid.UUID     `json:"user_id" db:"user_id"`
+}
+
+type InteractionUpdate struct {
+	Kind       *string        `json:"kind"`
+	OccurredAt *time.Time     `json:"occurred_at"`
+	Channel    *string        `json:"channel"`
+	Text       *string        `json:"text"`
+	EventID    *uuid.NullUUID `json:"event_id"`
+}
+
+type LastInteraction struct {
+	FriendID   uuid.UUID `json:"friend_id" db:"friend_id"`
+	OccurredAt time.Time `json:"occurred_at" db:"occurred_at"`
+}
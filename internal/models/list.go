@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ListParams select a page of a list, Cursor is NextCursor of the previous
+// page, empty for the first one.
+type ListParams struct {
+	Sort   string
+	Desc   bool
+	Cursor string
+	Limit  int
+}
+
+type FriendFilter struct {
+	TagID         *uuid.UUID
+	FriendlistID  *uuid.UUID
+	FriendIDs     []uuid.UUID // members of a smart friendlist, nil means any
+	City          string
+	Company       string
+	DOBMonth      int
+	ContactedFrom *time.Time
+	ContactedTo   *time.Time
+}
+
+type EventFilter struct {
+	FriendID  *uuid.UUID
+	IsActive  *bool
+	Frequency string
+	StartFrom *time.Time
+	StartTo   *time.Time
+}
+
+type FriendlistFilter struct {
+	TagID    *uuid.UUID
+	FriendID *uuid.UUID
+	// SmartIDs are smart friendlists FriendID matches, they pass the filter
+	// along with friendlists the friend was added to
+	SmartIDs []uuid.UUID
+}
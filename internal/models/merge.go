@@ -0,0 +1,26 @@
+package models
+
+import "github.com/google/uuid"
+
+const (
+	MergeChoiceTarget = "target"
+	MergeChoiceSource = "source"
+)
+
+// DuplicateCandidate is a pair of friends looking like the same person,
+// Score is from 0 to 1.
+type DuplicateCandidate struct {
+	Friend    Friend   `json:"friend"`
+	Duplicate Friend   `json:"duplicate"`
+	Score     float64  `json:"score"`
+	Reasons   []string `json:"reasons"`
+}
+
+// FriendMerge merges source friend into target, source is deleted.
+type FriendMerge struct {
+	TargetID uuid.UUID `json:"target_id" binding:"required"`
+	SourceID uuid.UUID `json:"source_id" binding:"required"`
+	// Choices pick whose value is kept when both friends have one, keys are
+	// friend and work info fields or custom field ids. Target wins by default.
+	Choices map[string]string `json:"choices"`
+}
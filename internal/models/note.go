@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Note is a markdown note attached to a friend, an event or a friendlist.
+// Friends are mentioned in the body as @[label](friend id), the label is
+// refreshed from the friend name on read.
+type Note struct {
+	ID           uuid.UUID     `json:"id" db:"id"`
+	Body         string        `json:"body" db:"body" binding:"required"`
+	FriendID     uuid.NullUUID `json:"friend_id" db:"friend_id"`
+	EventID      uuid.NullUUID `json:"event_id" db:"event_id"`
+	FriendlistID uuid.NullUUID `json:"friendlist_id" db:"friendlist_id"`
+	CreatedAt    time.Time     `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time     `json:"updated_at" db:"updated_at"`
+	UserID       uuid.UUID     `json:"user_id" db:"user_id"`
+	Mentions     []NoteMention `json:"mentions" db:"-"`
+}
+
+type NoteUpdate struct {
+	Body *string `json:"body"`
+}
+
+type NoteMention struct {
+	NoteID    uuid.UUID `json:"-" db:"note_id"`
+	FriendID  uuid.UUID `json:"friend_id" db:"friend_id"`
+	FirstName string    `json:"first_name" db:"first_name"`
+	LastName  string    `json:"last_name" db:"last_name"`
+}
+
+type NoteFilter struct {
+	FriendID          *uuid.UUID
+	EventID           *uuid.UUID
+	FriendlistID      *uuid.UUID
+	MentionedFriendID *uuid.UUID
+}
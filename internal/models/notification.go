@@ -0,0 +1,59 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const (
+	NotificationCategoryBirthdays   = "birthdays"
+	NotificationCategoryEvents      = "events"
+	NotificationCategoryDigests     = "digests"
+	NotificationCategoryKeepInTouch = "keep_in_touch"
+)
+
+const (
+	NotificationActionAcknowledge = "acknowledge"
+	NotificationActionSnooze      = "snooze"
+	NotificationActionDismiss     = "dismiss"
+)
+
+type Notification struct {
+	Category   string    `json:"category"`
+	Title      string    `json:"title"`
+	Text       string    `json:"text"`
+	DeliveryID uuid.UUID `json:"delivery_id"`
+	Actions    []string  `json:"actions"`
+}
+
+type NotificationPreference struct {
+	ID              uuid.UUID      `json:"id" db:"id"`
+	Channels        pq.StringArray `json:"channels" db:"channels"`
+	QuietHoursStart string         `json:"quiet_hours_start" db:"quiet_hours_start"`
+	QuietHoursEnd   string         `json:"quiet_hours_end" db:"quiet_hours_end"`
+	Timezone        string         `json:"timezone" db:"timezone"`
+	Birthdays       bool           `json:"birthdays" db:"birthdays"`
+	Events          bool           `json:"events" db:"events"`
+	Digests         bool           `json:"digests" db:"digests"`
+	KeepInTouch     bool           `json:"keep_in_touch" db:"keep_in_touch"`
+	BirthdayMinutes pq.Int64Array  `json:"birthday_reminder_minutes" db:"birthday_reminder_minutes"`
+	UserID          uuid.UUID      `json:"user_id" db:"user_id"`
+}
+
+type NotificationPreferenceUpdate struct {
+	Channels        []string `json:"channels"`
+	QuietHoursStart *string  `json:"quiet_hours_start"`
+	QuietHoursEnd   *string  `json:"quiet_hours_end"`
+	Timezone        *string  `json:"timezone"`
+	Birthdays       *bool    `json:"birthdays"`
+	Events          *bool    `json:"events"`
+	Digests         *bool    `json:"digests"`
+	KeepInTouch     *bool    `json:"keep_in_touch"`
+	BirthdayMinutes []int64  `json:"birthday_reminder_minutes"`
+}
+
+type TgChat struct {
+	ID     uuid.UUID `json:"id" db:"id"`
+	ChatID int64     `json:"chat_id" db:"chat_id"`
+	UserID uuid.UUID `json:"user_id" db:"user_id"`
+}
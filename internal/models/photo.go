@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Photo is an uploaded image in the gallery of a friend. A photo attached to
+// an event is shown in the event gallery as well. The avatar is the photo
+// whose image is the friend image.
+type Photo struct {
+	ID        uuid.UUID     `json:"id" db:"id"`
+	ImageID   uuid.UUID     `json:"image_id" db:"image_id" binding:"required"`
+	Caption   string        `json:"caption" db:"caption"`
+	TakenAt   *time.Time    `json:"taken_at" db:"taken_at"`
+	Position  int           `json:"position" db:"position"`
+	FriendID  uuid.UUID     `json:"friend_id" db:"friend_id"`
+	EventID   uuid.NullUUID `json:"event_id" db:"event_id"`
+	IsAvatar  bool          `json:"is_avatar" db:"is_avatar"`
+	CreatedAt time.Time     `json:"created_at" db:"created_at"`
+	UserID    uuid.UUID     `json:"user_id" db:"user_id"`
+}
+
+type PhotoUpdate struct {
+	Caption *string    `json:"caption"`
+	TakenAt *time.Time `json:"taken_at"` // zero time removes the date
+	EventID *uuid.UUID `json:"event_id"` // nil uuid detaches the event
+}
+
+// PhotoOrder lists all photos of the friend in the new order.
+type PhotoOrder struct {
+	PhotoIDs []uuid.UUID `json:"photo_ids" binding:"required"`
+}
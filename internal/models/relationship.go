@@ -0,0 +1,47 @@
+package models
+
+import "github.com/google/uuid"
+
+// Directed kinds read as "friend is <kind> of related friend", child is
+// stored as parent with the friends swapped.
+const (
+	RelationshipKindSpouse       = "spouse"
+	RelationshipKindParent       = "parent"
+	RelationshipKindChild        = "child"
+	RelationshipKindSibling      = "sibling"
+	RelationshipKindColleague    = "colleague"
+	RelationshipKindIntroducedBy = "introduced_by"
+)
+
+type Relationship struct {
+	ID              uuid.UUID `json:"id" db:"id"`
+	Kind            string    `json:"kind" db:"kind" binding:"required"`
+	FriendID        uuid.UUID `json:"friend_id" db:"friend_id"`
+	RelatedFriendID uuid.UUID `json:"related_friend_id" db:"related_friend_id" binding:"required"`
+	Directed        bool      `json:"directed" db:"-"`
+	UserID          uuid.UUID `json:"user_id" db:"user_id"`
+}
+
+type RelationshipUpdate struct {
+	Kind *string `json:"kind" binding:"required"`
+}
+
+type GraphNode struct {
+	ID        uuid.UUID `json:"id"`
+	FirstName string    `json:"first_name"`
+	LastName  string    `json:"last_name"`
+	ImageID   uuid.UUID `json:"image_id"`
+}
+
+type GraphEdge struct {
+	ID       uuid.UUID `json:"id"`
+	Kind     string    `json:"kind"`
+	Source   uuid.UUID `json:"source"`
+	Target   uuid.UUID `json:"target"`
+	Directed bool      `json:"directed"`
+}
+
+type Graph struct {
+	Nodes []GraphNode `json:"nodes"`
+	Edges []GraphEdge `json:"edges"`
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DeliveryKindEvent       = "event"
+	DeliveryKindBirthday    = "birthday"
+	DeliveryKindFriendDate  = "friend_date"
+	DeliveryKindKeepInTouch = "keep_in_touch"
+)
+
+const (
+	DeliveryStatusPending      = "pending"
+	DeliveryStatusSent         = "sent"
+	DeliveryStatusAcknowledged = "acknowledged"
+	DeliveryStatusSnoozed      = "snoozed"
+	DeliveryStatusDismissed    = "dismissed"
+	DeliveryStatusFailed       = "failed"
+)
+
+type ReminderDelivery struct {
+	ID             uuid.UUID     `json:"id" db:"id"`
+	Kind           string        `json:"kind" db:"kind"`
+	ReminderID     uuid.NullUUID `json:"reminder_id" db:"reminder_id"`
+	EventID        uuid.NullUUID `json:"event_id" db:"event_id"`
+	FriendID       uuid.NullUUID `json:"friend_id" db:"friend_id"`
+	FriendDateID   uuid.NullUUID `json:"friend_date_id" db:"friend_date_id"`
+	OffsetMinutes  int           `json:"offset_minutes" db:"offset_minutes"`
+	OccurrenceDate time.Time     `json:"occurrence_date" db:"occurrence_date"`
+	NotifyAt       time.Time     `json:"notify_at" db:"notify_at"`
+	Status         string        `json:"status" db:"status"`
+	SnoozedUntil   sql.NullTime  `json:"snoozed_until" db:"snoozed_until"`
+	SentAt         sql.NullTime  `json:"sent_at" db:"sent_at"`
+	Attempts       int           `json:"attempts" db:"attempts"`
+	ClaimedUntil   sql.NullTime  `json:"-" db:"claimed_until"`
+	UpdatedAt      time.Time     `json:"updated_at" db:"updated_at"`
+	UserID         uuid.UUID     `json:"user_id" db:"user_id"`
+}
+
+type ReminderDeliveryWithSource struct {
+	Delivery   ReminderDelivery `json:"delivery"`
+	Event      *Event           `json:"event,omitempty"`
+	Friend     *Friend          `json:"friend,omitempty"`
+	FriendDate *FriendDate      `json:"friend_date,omitempty"`
+	GiftIdeas  []GiftIdea       `json:"gift_ideas,omitempty"`
+}
+
+type ReminderDeliverySnooze struct {
+	Minutes int        `json:"minutes"`
+	Until   *time.Time `json:"until"`
+}
+
+type ReminderWithEvent struct {
+	Reminder Reminder `json:"reminder"`
+	Event    Event    `json:"event"`
+}
@@ -0,0 +1,19 @@
+package models
+
+import "github.com/google/uuid"
+
+const (
+	SearchKindFriend      = "friend"
+	SearchKindEvent       = "event"
+	SearchKindInteraction = "interaction"
+	SearchKindNote        = "note"
+)
+
+type SearchResult struct {
+	Kind     string        `json:"kind" db:"kind"`
+	ID       uuid.UUID     `json:"id" db:"id"`
+	FriendID uuid.NullUUID `json:"friend_id" db:"friend_id"`
+	Title    string        `json:"title" db:"title"`
+	Snippet  string        `json:"snippet" db:"snippet"`
+	Rank     float64       `json:"rank" db:"rank"`
+}
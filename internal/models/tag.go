@@ -1,11 +1,16 @@
 package models
 
-import "github.com/google/uuid"
+import (
+	"database/sql"
+
+	"github.com/google/uuid"
+)
 
 type Tag struct {
-	ID     uuid.UUID `json:"id,omitempty" db:"id"`
-	Title  string    `json:"title" db:"title" binding:"required"`
-	UserID uuid.UUID `json:"user_id" db:"user_id"`
+	ID        uuid.UUID    `json:"id,omitempty" db:"id"`
+	Title     string       `json:"title" db:"title" binding:"required"`
+	UserID    uuid.UUID    `json:"user_id" db:"user_id"`
+	DeletedAt sql.NullTime `json:"-" db:"deleted_at"`
 }
 
 type AdditionTag struct {
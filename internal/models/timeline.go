@@ -0,0 +1,54 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	FriendChangeEntityFriend   = "friend"
+	FriendChangeEntityWorkInfo = "work_info"
+	FriendChangeEntityTag      = "tag"
+	FriendChangeEntityField    = "field"
+)
+
+// FriendChange is a change of a single friend field. Tag changes keep the tag
+// id in field and its title as value, custom field changes keep the field id
+// and the stored text of the value. An empty value means the tag or the field
+// wasn't attached.
+type FriendChange struct {
+	ID        uuid.UUID     `json:"id" db:"id"`
+	Entity    string        `json:"entity" db:"entity"`
+	Field     string        `json:"field" db:"field"`
+	OldValue  string        `json:"old_value" db:"old_value"`
+	NewValue  string        `json:"new_value" db:"new_value"`
+	ChangedAt time.Time     `json:"changed_at" db:"changed_at"`
+	ChangedBy uuid.NullUUID `json:"changed_by" db:"changed_by"`
+	FriendID  uuid.UUID     `json:"friend_id" db:"friend_id"`
+	UserID    uuid.UUID     `json:"user_id" db:"user_id"`
+}
+
+const (
+	TimelineKindInteraction = "interaction"
+	TimelineKindEvent       = "event"
+	TimelineKindChange      = "change"
+	TimelineKindNote        = "note"
+
+	TimelineKindEmploymentStarted = "employment_started"
+	TimelineKindEmploymentEnded   = "employment_ended"
+)
+
+type TimelineItem struct {
+	Kind        string        `json:"kind"`
+	Date        time.Time     `json:"date"`
+	Interaction *Interaction  `json:"interaction,omitempty"`
+	Event       *Event        `json:"event,omitempty"`
+	Change      *FriendChange `json:"change,omitempty"`
+	Employment  *Employment   `json:"employment,omitempty"`
+	Note        *Note         `json:"note,omitempty"`
+}
+
+type FriendRevert struct {
+	At time.Time `json:"at" binding:"required"`
+}
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	TrashKindFriend     = "friend"
+	TrashKindEvent      = "event"
+	TrashKindFriendlist = "friendlist"
+	TrashKindTag        = "tag"
+)
+
+type TrashItem struct {
+	Kind      string    `json:"kind" db:"kind"`
+	ID        uuid.UUID `json:"id" db:"id"`
+	Title     string    `json:"title" db:"title"`
+	DeletedAt time.Time `json:"deleted_at" db:"deleted_at"`
+}
@@ -23,6 +23,7 @@ type User struct {
 	Nationality         string    `json:"nationality" db:"nationality"`
 	Resident            bool      `json:"resident" db:"resident"`
 	Language            string    `json:"language" db:"language"`
+	StorageQuota        *int64    `json:"-" db:"storage_quota"`
 }
 
 type UserUpdate struct {
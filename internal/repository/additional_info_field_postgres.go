@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/lunovoy/friendly/internal/models"
+)
+
+type AdditionalInfoFieldPostgres struct {
+	db *sqlx.DB
+}
+
+func NewAdditionalInfoFieldPostgres(db *sqlx.DB) *AdditionalInfoFieldPostgres {
+	return &AdditionalInfoFieldPostgres{
+		db: db,
+	}
+}
+
+func (r *AdditionalInfoFieldPostgres) Create(userID uuid.UUID, field models.AdditionalInfoField) (uuid.UUID, error) {
+	var fieldID uuid.UUID
+	query := fmt.Sprintf(`INSERT INTO "%s" (title, type, options, user_id) VALUES ($1, $2, $3, $4) RETURNING id`, additionalInfoFieldTable)
+
+	row := r.db.QueryRow(query, field.Title, field.Type, field.Options, userID)
+	if err := row.Scan(&fieldID); err != nil {
+		return uuid.Nil, err
+	}
+
+	return fieldID, nil
+}
+
+func (r *AdditionalInfoFieldPostgres) GetAll(userID uuid.UUID) ([]models.AdditionalInfoField, error) {
+	var fields []models.AdditionalInfoField
+
+	query := fmt.Sprintf("SELECT * FROM %s WHERE user_id = $1 ORDER BY title", additionalInfoFieldTable)
+
+	err := r.db.Select(&fields, query, userID)
+
+	return fields, err
+}
+
+func (r *AdditionalInfoFieldPostgres) GetByID(userID, fieldID uuid.UUID) (models.AdditionalInfoField, error) {
+	var field models.AdditionalInfoField
+
+	query := fmt.Sprintf("SELECT * FROM %s WHERE id = $1 AND user_id = $2", additionalInfoFieldTable)
+
+	err := r.db.Get(&field, query, fieldID, userID)
+
+	return field, err
+}
+
+func (r *AdditionalInfoFieldPostgres) Update(userID, fieldID uuid.UUID, field models.AdditionalInfoField) error {
+	query := fmt.Sprintf("UPDATE %s SET title = $1, options = $2 WHERE id = $3 AND user_id = $4", additionalInfoFieldTable)
+
+	_, err := r.db.Exec(query, field.Title, field.Options, fieldID, userID)
+
+	return err
+}
+
+func (r *AdditionalInfoFieldPostgres) DeleteByID(userID, fieldID uuid.UUID) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE id = $1 AND user_id = $2", additionalInfoFieldTable)
+
+	_, err := r.db.Exec(query, fieldID, userID)
+
+	return err
+}
+
+// GetContentsByFriendIDs returns fields attached to the friends with their
+// stored values, a field without value has NULL content.
+func (r *AdditionalInfoFieldPostgres) GetContentsByFriendIDs(friendIDs []uuid.UUID) ([]models.AdditionalInfoFieldContent, error) {
+	var contents []models.AdditionalInfoFieldContent
+
+	query := fmt.Sprintf(`SELECT aif.*, faif.friend_id, t.content
+						FROM %s faif
+						INNER JOIN %s aif ON aif.id = faif.additional_info_field_id
+						LEFT JOIN %s t ON t.additional_info_field_id = faif.additional_info_field_id AND t.friend_id = faif.friend_id
+						WHERE faif.friend_id = ANY($1)
+						ORDER BY aif.title`, friendsAdditionalInfoFieldsTable, additionalInfoFieldTable, additionalInfoFieldTextTable)
+
+	err := r.db.Select(&contents, query, pq.Array(friendIDs))
+
+	return contents, err
+}
+
+func (r *AdditionalInfoFieldPostgres) AttachToFriend(friendID, fieldID uuid.UUID) error {
+	query := fmt.Sprintf(`INSERT INTO "%s" (friend_id, additional_info_field_id) VALUES ($1, $2)
+						ON CONFLICT (additional_info_field_id, friend_id) DO NOTHING`, friendsAdditionalInfoFieldsTable)
+
+	_, err := r.db.Exec(query, friendID, fieldID)
+
+	return err
+}
+
+func (r *AdditionalInfoFieldPostgres) SetValue(friendID, fieldID uuid.UUID, content string) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	queryAttach := fmt.Sprintf(`INSERT INTO "%s" (friend_id, additional_info_field_id) VALUES ($1, $2)
+						ON CONFLICT (additional_info_field_id, friend_id) DO NOTHING`, friendsAdditionalInfoFieldsTable)
+	if _, err := tx.Exec(queryAttach, friendID, fieldID); err != nil {
+		return err
+	}
+
+	queryValue := fmt.Sprintf(`INSERT INTO "%s" (content, additional_info_field_id, friend_id) VALUES ($1, $2, $3)
+						ON CONFLICT (additional_info_field_id, friend_id) DO UPDATE SET content = EXCLUDED.content`, additionalInfoFieldTextTable)
+	if _, err := tx.Exec(queryValue, content, fieldID, friendID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *AdditionalInfoFieldPostgres) ClearValue(friendID, fieldID uuid.UUID) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE friend_id = $1 AND additional_info_field_id = $2", additionalInfoFieldTextTable)
+
+	_, err := r.db.Exec(query, friendID, fieldID)
+
+	return err
+}
+
+func (r *AdditionalInfoFieldPostgres) DetachFromFriend(friendID, fieldID uuid.UUID) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	queryValue := fmt.Sprintf("DELETE FROM %s WHERE friend_id = $1 AND additional_info_field_id = $2", additionalInfoFieldTextTable)
+	if _, err := tx.Exec(queryValue, friendID, fieldID); err != nil {
+		return err
+	}
+
+	queryDetach := fmt.Sprintf("DELETE FROM %s WHERE friend_id = $1 AND additional_info_field_id = $2", friendsAdditionalInfoFieldsTable)
+	if _, err := tx.Exec(queryDetach, friendID, fieldID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/jmoiron/sqlx"
+	"github.com/lunovoy/friendly/internal/models"
+)
+
+type AttachmentPostgres struct {
+	db *sqlx.DB
+}
+
+func NewAttachmentPostgres(db *sqlx.DB) *AttachmentPostgres {
+	return &AttachmentPostgres{
+		db: db,
+	}
+}
+
+// Create inserts the attachment unless it takes the user over the storage
+// quota, ok is false then.
+func (r *AttachmentPostgres) Create(userID uuid.UUID, attachment models.Attachment) (uuid.UUID, bool, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return uuid.Nil, false, err
+	}
+	defer tx.Rollback()
+
+	// Lock the user row so concurrent uploads are checked one by one
+	var usage models.StorageUsage
+	queryUsage := fmt.Sprintf(`SELECT u.storage_quota AS quota,
+						(SELECT COALESCE(SUM(a.size), 0) FROM %s a WHERE a.user_id = u.id) AS used
+						FROM "%s" u WHERE u.id = $1 FOR UPDATE`, attachmentTable, userTable)
+	if err := tx.Get(&usage, queryUsage, userID); err != nil {
+		return uuid.Nil, false, err
+	}
+	if usage.Used+attachment.Size > usage.Quota {
+		return uuid.Nil, false, nil
+	}
+
+	var attachmentID uuid.UUID
+	query := fmt.Sprintf(`INSERT INTO "%s" (file_name, mime_type, size, friend_id, event_id, user_id)
+						VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`, attachmentTable)
+
+	row := tx.QueryRow(query, attachment.FileName, attachment.MimeType, attachment.Size, attachment.FriendID, attachment.EventID, userID)
+	if err := row.Scan(&attachmentID); err != nil {
+		return uuid.Nil, false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return uuid.Nil, false, err
+	}
+	return attachmentID, true, nil
+}
+
+// GetAll returns attachments of the user whose friend or event is not in the
+// trash, the latest first.
+func (r *AttachmentPostgres) GetAll(userID uuid.UUID, filter models.AttachmentFilter) ([]models.Attachment, error) {
+	sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
+	sb.Select("a.*").From(fmt.Sprintf("%s a", attachmentTable))
+	sb.JoinWithOption(sqlbuilder.LeftJoin, fmt.Sprintf("%s f", friendTable), "f.id = a.friend_id")
+	sb.JoinWithOption(sqlbuilder.LeftJoin, fmt.Sprintf("%s e", eventTable), "e.id = a.event_id")
+	sb.Where(
+		sb.Equal("a.user_id", userID),
+		sb.IsNull("f.deleted_at"),
+		sb.IsNull("e.deleted_at"),
+	)
+
+	if filter.FriendID != nil {
+		sb.Where(sb.Equal("a.friend_id", *filter.FriendID))
+	}
+	if filter.EventID != nil {
+		sb.Where(sb.Equal("a.event_id", *filter.EventID))
+	}
+	sb.OrderBy("a.created_at DESC", "a.id")
+
+	var attachments []models.Attachment
+	query, args := sb.Build()
+	err := r.db.Select(&attachments, query, args...)
+
+	return attachments, err
+}
+
+func (r *AttachmentPostgres) GetByID(userID, attachmentID uuid.UUID) (models.Attachment, error) {
+	var attachment models.Attachment
+
+	query := fmt.Sprintf("SELECT * FROM %s WHERE id = $1 AND user_id = $2", attachmentTable)
+
+	err := r.db.Get(&attachment, query, attachmentID, userID)
+
+	return attachment, err
+}
+
+func (r *AttachmentPostgres) DeleteByID(userID, attachmentID uuid.UUID) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE id = $1 AND user_id = $2", attachmentTable)
+
+	_, err := r.db.Exec(query, attachmentID, userID)
+
+	return err
+}
+
+// GetUsage counts attachments in the trash as well, they are still stored.
+func (r *AttachmentPostgres) GetUsage(userID uuid.UUID) (models.StorageUsage, error) {
+	var usage models.StorageUsage
+
+	query := fmt.Sprintf(`SELECT u.storage_quota AS quota,
+						(SELECT COALESCE(SUM(a.size), 0) FROM %s a WHERE a.user_id = u.id) AS used
+						FROM "%s" u WHERE u.id = $1`, attachmentTable, userTable)
+
+	err := r.db.Get(&usage, query, userID)
+
+	return usage, err
+}
@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/lunovoy/friendly/internal/models"
+)
+
+type ContactPointPostgres struct {
+	db *sqlx.DB
+}
+
+func NewContactPointPostgres(db *sqlx.DB) *ContactPointPostgres {
+	return &ContactPointPostgres{
+		db: db,
+	}
+}
+
+func (r *ContactPointPostgres) Create(userID uuid.UUID, contact models.ContactPoint) (uuid.UUID, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return uuid.Nil, err
+	}
+	defer tx.Rollback()
+
+	// The first contact point of a kind becomes primary
+	var count int
+	queryCount := fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE friend_id = $1 AND kind = $2", contactPointTable)
+	if err := tx.Get(&count, queryCount, contact.FriendID, contact.Kind); err != nil {
+		return uuid.Nil, err
+	}
+	if count == 0 {
+		contact.IsPrimary = true
+	}
+
+	if contact.IsPrimary {
+		if err := resetPrimaryContactPoint(tx, contact.FriendID, contact.Kind); err != nil {
+			return uuid.Nil, err
+		}
+	}
+
+	var contactID uuid.UUID
+	query := fmt.Sprintf(`INSERT INTO "%s" (kind, label, value, is_primary, friend_id, user_id)
+						VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`, contactPointTable)
+
+	row := tx.QueryRow(query, contact.Kind, contact.Label, contact.Value, contact.IsPrimary, contact.FriendID, userID)
+	if err := row.Scan(&contactID); err != nil {
+		return uuid.Nil, err
+	}
+
+	return contactID, tx.Commit()
+}
+
+func (r *ContactPointPostgres) GetAllByFriendID(userID, friendID uuid.UUID) ([]models.ContactPoint, error) {
+	var contacts []models.ContactPoint
+
+	query := fmt.Sprintf("SELECT * FROM %s WHERE friend_id = $1 AND user_id = $2 ORDER BY kind, is_primary DESC, label", contactPointTable)
+
+	err := r.db.Select(&contacts, query, friendID, userID)
+
+	return contacts, err
+}
+
+func (r *ContactPointPostgres) GetAllByFriendIDs(friendIDs []uuid.UUID) ([]models.ContactPoint, error) {
+	var contacts []models.ContactPoint
+
+	query := fmt.Sprintf("SELECT * FROM %s WHERE friend_id = ANY($1) ORDER BY kind, is_primary DESC, label", contactPointTable)
+
+	err := r.db.Select(&contacts, query, pq.Array(friendIDs))
+
+	return contacts, err
+}
+
+func (r *ContactPointPostgres) GetByID(userID, friendID, contactID uuid.UUID) (models.ContactPoint, error) {
+	var contact models.ContactPoint
+
+	query := fmt.Sprintf("SELECT * FROM %s WHERE id = $1 AND friend_id = $2 AND user_id = $3", contactPointTable)
+
+	err := r.db.Get(&contact, query, contactID, friendID, userID)
+
+	return contact, err
+}
+
+func (r *ContactPointPostgres) Update(userID, contactID uuid.UUID, contact models.ContactPoint) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if contact.IsPrimary {
+		if err := resetPrimaryContactPoint(tx, contact.FriendID, contact.Kind); err != nil {
+			return err
+		}
+	}
+
+	query := fmt.Sprintf(`UPDATE %s SET kind = $1, label = $2, value = $3, is_primary = $4
+						WHERE id = $5 AND user_id = $6`, contactPointTable)
+
+	if _, err := tx.Exec(query, contact.Kind, contact.Label, contact.Value, contact.IsPrimary, contactID, userID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *ContactPointPostgres) DeleteByID(userID, contactID uuid.UUID) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE id = $1 AND user_id = $2", contactPointTable)
+
+	_, err := r.db.Exec(query, contactID, userID)
+
+	return err
+}
+
+func resetPrimaryContactPoint(tx *sqlx.Tx, friendID uuid.UUID, kind string) error {
+	query := fmt.Sprintf("UPDATE %s SET is_primary = false WHERE friend_id = $1 AND kind = $2 AND is_primary", contactPointTable)
+
+	_, err := tx.Exec(query, friendID, kind)
+
+	return err
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lunovoy/friendly/internal/models"
+)
+
+type DigestPostgres struct {
+	db *sqlx.DB
+}
+
+func NewDigestPostgres(db *sqlx.DB) *DigestPostgres {
+	return &DigestPostgres{
+		db: db,
+	}
+}
+
+func (r *DigestPostgres) GetByUserID(userID uuid.UUID) (models.DigestSetting, error) {
+	var setting models.DigestSetting
+
+	query := fmt.Sprintf("SELECT * FROM %s WHERE user_id = $1", digestSettingTable)
+
+	err := r.db.Get(&setting, query, userID)
+
+	return setting, err
+}
+
+func (r *DigestPostgres) Save(userID uuid.UUID, setting models.DigestSetting) error {
+	query := fmt.Sprintf(`INSERT INTO "%s" (is_active, frequency, time_of_day, weekday, timezone, days_ahead, user_id)
+						VALUES ($1, $2, $3, $4, $5, $6, $7)
+						ON CONFLICT (user_id) DO UPDATE SET
+							is_active = EXCLUDED.is_active,
+							frequency = EXCLUDED.frequency,
+							time_of_day = EXCLUDED.time_of_day,
+							weekday = EXCLUDED.weekday,
+							timezone = EXCLUDED.timezone,
+							days_ahead = EXCLUDED.days_ahead`, digestSettingTable)
+
+	_, err := r.db.Exec(query, setting.IsActive, setting.Frequency, setting.TimeOfDay, setting.Weekday, setting.Timezone, setting.DaysAhead, userID)
+
+	return err
+}
+
+func (r *DigestPostgres) GetAllActive() ([]models.DigestSetting, error) {
+	var settings []models.DigestSetting
+
+	query := fmt.Sprintf("SELECT * FROM %s WHERE is_active", digestSettingTable)
+
+	err := r.db.Select(&settings, query)
+
+	return settings, err
+}
+
+func (r *DigestPostgres) MarkSent(settingID uuid.UUID, sentAt time.Time) error {
+	query := fmt.Sprintf("UPDATE %s SET last_sent_at = $1 WHERE id = $2", digestSettingTable)
+
+	_, err := r.db.Exec(query, sentAt, settingID)
+
+	return err
+}
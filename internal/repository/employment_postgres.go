@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lunovoy/friendly/internal/models"
+)
+
+type EmploymentPostgres struct {
+	db *sqlx.DB
+}
+
+func NewEmploymentPostgres(db *sqlx.DB) *EmploymentPostgres {
+	return &EmploymentPostgres{
+		db: db,
+	}
+}
+
+func (r *EmploymentPostgres) Create(userID uuid.UUID, employment models.Employment) (uuid.UUID, error) {
+	var employmentID uuid.UUID
+	query := fmt.Sprintf(`INSERT INTO "%s" (company, position, profession, city, started_at, ended_at, friend_id, user_id)
+						VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id`, employmentTable)
+
+	row := r.db.QueryRow(query, employment.Company, employment.Position, employment.Profession, employment.City, employment.StartedAt, employment.EndedAt, employment.FriendID, userID)
+	if err := row.Scan(&employmentID); err != nil {
+		return uuid.Nil, err
+	}
+
+	return employmentID, nil
+}
+
+func (r *EmploymentPostgres) GetAllByFriendID(userID, friendID uuid.UUID) ([]models.Employment, error) {
+	var employments []models.Employment
+
+	query := fmt.Sprintf(`SELECT * FROM %s WHERE friend_id = $1 AND user_id = $2
+						ORDER BY started_at DESC NULLS LAST, ended_at DESC NULLS FIRST`, employmentTable)
+
+	err := r.db.Select(&employments, query, friendID, userID)
+
+	return employments, err
+}
+
+func (r *EmploymentPostgres) GetByID(userID, friendID, employmentID uuid.UUID) (models.Employment, error) {
+	var employment models.Employment
+
+	query := fmt.Sprintf("SELECT * FROM %s WHERE id = $1 AND friend_id = $2 AND user_id = $3", employmentTable)
+
+	err := r.db.Get(&employment, query, employmentID, friendID, userID)
+
+	return employment, err
+}
+
+func (r *EmploymentPostgres) Update(userID, employmentID uuid.UUID, employment models.Employment) error {
+	query := fmt.Sprintf(`UPDATE %s SET company = $1, position = $2, profession = $3, city = $4, started_at = $5, ended_at = $6
+						WHERE id = $7 AND user_id = $8`, employmentTable)
+
+	_, err := r.db.Exec(query, employment.Company, employment.Position, employment.Profession, employment.City, employment.StartedAt, employment.EndedAt, employmentID, userID)
+
+	return err
+}
+
+func (r *EmploymentPostgres) DeleteByID(userID, employmentID uuid.UUID) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE id = $1 AND user_id = $2", employmentTable)
+
+	_, err := r.db.Exec(query, employmentID, userID)
+
+	return err
+}
+
+// SyncWorkInfo copies the current employment into work_info, the zero value
+// clears the employment part of work_info.
+func (r *EmploymentPostgres) SyncWorkInfo(friendID uuid.UUID, current models.Employment) error {
+	query := fmt.Sprintf("UPDATE %s SET company = $1, position = $2, profession = $3, city = $4 WHERE friend_id = $5", workInfoTable)
+
+	_, err := r.db.Exec(query, current.Company, current.Position, current.Profession, current.City, friendID)
+
+	return err
+}
@@ -119,7 +119,7 @@ func (r *EventPostgres) GetEventsByFriendID(userID, friendID uuid.UUID) ([]model
 	query := fmt.Sprintf(`SELECT e.*
 						FROM %s e
 						JOIN %s fe ON fe.event_id = e.id
-						WHERE fe.friend_id = $1 AND e.user_id = $2`, eventTable, friendsEventsTable)
+						WHERE fe.friend_id = $1 AND e.user_id = $2 AND e.deleted_at IS NULL`, eventTable, friendsEventsTable)
 
 	err := r.db.Select(&events, query, friendID, userID)
 
@@ -129,7 +129,7 @@ func (r *EventPostgres) GetEventsByFriendID(userID, friendID uuid.UUID) ([]model
 func (r *EventPostgres) GetAll(userID uuid.UUID) ([]models.Event, error) {
 	var events []models.Event
 
-	query := fmt.Sprintf("SELECT * FROM %s WHERE user_id = $1", eventTable)
+	query := fmt.Sprintf("SELECT * FROM %s WHERE user_id = $1 AND deleted_at IS NULL ORDER BY start_date, id", eventTable)
 
 	err := r.db.Select(&events, query, userID)
 
@@ -140,7 +140,7 @@ func (r *EventPostgres) GetAll(userID uuid.UUID) ([]models.Event, error) {
 func (r *EventPostgres) GetByID(userID, eventID uuid.UUID) (models.Event, error) {
 	var event models.Event
 
-	query := fmt.Sprintf("SELECT * FROM %s WHERE id = $1 AND user_id = $2", eventTable)
+	query := fmt.Sprintf("SELECT * FROM %s WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL", eventTable)
 
 	fmt.Println("Event: ", eventID, "User: ", userID)
 	err := r.db.Get(&event, query, eventID, userID)
@@ -155,7 +155,7 @@ func (r *EventPostgres) GetByIDWithFriends(userID, eventID uuid.UUID) (models.Ev
 	}
 	defer tx.Rollback()
 
-	queryEvent := fmt.Sprintf("SELECT * FROM %s WHERE id = $1 AND user_id = $2", eventTable)
+	queryEvent := fmt.Sprintf("SELECT * FROM %s WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL", eventTable)
 
 	var event models.Event
 
@@ -167,7 +167,7 @@ func (r *EventPostgres) GetByIDWithFriends(userID, eventID uuid.UUID) (models.Ev
 	queryFriends := fmt.Sprintf(`SELECT f.* 
 								FROM %s f
 								JOIN %s fe ON f.id = fe.friend_id
-								WHERE fe.event_id = $1 AND f.user_id = $2`, friendTable, friendsEventsTable)
+								WHERE fe.event_id = $1 AND f.user_id = $2 AND f.deleted_at IS NULL`, friendTable, friendsEventsTable)
 
 	var friends []models.Friend
 
@@ -196,7 +196,7 @@ func (r *EventPostgres) GetAllWithFriends(userID uuid.UUID) ([]models.EventWithF
 	}
 	defer tx.Rollback()
 
-	queryEvents := fmt.Sprintf("SELECT * FROM %s WHERE user_id = $1", eventTable)
+	queryEvents := fmt.Sprintf("SELECT * FROM %s WHERE user_id = $1 AND deleted_at IS NULL", eventTable)
 
 	var events []models.Event
 	err = tx.Select(&events, queryEvents, userID)
@@ -209,7 +209,7 @@ func (r *EventPostgres) GetAllWithFriends(userID uuid.UUID) ([]models.EventWithF
 	queryFriends := fmt.Sprintf(`SELECT f.* 
 								FROM %s f
 								JOIN %s fe ON f.id = fe.friend_id
-								WHERE fe.event_id = $1 AND f.user_id = $2`, friendTable, friendsEventsTable)
+								WHERE fe.event_id = $1 AND f.user_id = $2 AND f.deleted_at IS NULL`, friendTable, friendsEventsTable)
 
 	friendsStmt, err := tx.Preparex(queryFriends)
 	if err != nil {
@@ -372,9 +372,53 @@ func (r *EventPostgres) UpdateFull(userID, eventID uuid.UUID, event models.Event
 }
 
 func (r *EventPostgres) DeleteByID(userID, eventID uuid.UUID) error {
-	query := fmt.Sprintf("DELETE FROM %s WHERE id=$1 AND user_id=$2", eventTable)
+	query := fmt.Sprintf("UPDATE %s SET deleted_at = now() WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL", eventTable)
 
 	_, err := r.db.Exec(query, eventID, userID)
 
 	return err
 }
+
+var eventSortKeys = map[string]sortKey[models.Event]{
+	"start_date": {column: "COALESCE(e.start_date, 'infinity')", cast: "timestamptz", value: func(e models.Event) string {
+		return cursorTime(e.StartDate.Valid, e.StartDate.Time)
+	}},
+	"title": {column: "e.title", cast: "text", value: func(e models.Event) string { return e.Title }},
+}
+
+func (r *EventPostgres) List(userID uuid.UUID, filter models.EventFilter, params models.ListParams) ([]models.Event, string, error) {
+	sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
+	sb.Select("e.*").From(fmt.Sprintf("%s e", eventTable))
+	sb.Where(sb.Equal("e.user_id", userID), sb.IsNull("e.deleted_at"))
+
+	if filter.FriendID != nil {
+		sb.Where(fmt.Sprintf("EXISTS (SELECT 1 FROM %s fe WHERE fe.event_id = e.id AND fe.friend_id = %s)", friendsEventsTable, sb.Var(*filter.FriendID)))
+	}
+	if filter.IsActive != nil {
+		sb.Where(sb.Equal("e.is_active", *filter.IsActive))
+	}
+	if filter.Frequency != "" {
+		sb.Where(sb.Equal("e.frequency", filter.Frequency))
+	}
+	if filter.StartFrom != nil {
+		sb.Where(sb.GreaterEqualThan("e.start_date", *filter.StartFrom))
+	}
+	if filter.StartTo != nil {
+		sb.Where(sb.LessEqualThan("e.start_date", *filter.StartTo))
+	}
+
+	key, err := applyListParams(sb, params, eventSortKeys, "start_date", "e.id")
+	if err != nil {
+		return nil, "", err
+	}
+
+	var events []models.Event
+	query, args := sb.Build()
+	if err := r.db.Select(&events, query, args...); err != nil {
+		return nil, "", err
+	}
+
+	events, next := cutListPage(events, params.Limit, key, func(e models.Event) uuid.UUID { return e.ID })
+
+	return events, next, nil
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lunovoy/friendly/internal/models"
+)
+
+type FriendChangePostgres struct {
+	db *sqlx.DB
+}
+
+func NewFriendChangePostgres(db *sqlx.DB) *FriendChangePostgres {
+	return &FriendChangePostgres{
+		db: db,
+	}
+}
+
+func (r *FriendChangePostgres) CreateBulk(userID, friendID uuid.UUID, changes []models.FriendChange) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := fmt.Sprintf(`INSERT INTO "%s" (entity, field, old_value, new_value, changed_by, friend_id, user_id)
+						VALUES ($1, $2, $3, $4, $5, $6, $7)`, friendChangeTable)
+
+	for _, change := range changes {
+		changedBy := change.ChangedBy
+		if !changedBy.Valid {
+			changedBy = uuid.NullUUID{UUID: userID, Valid: true}
+		}
+		if _, err := tx.Exec(query, change.Entity, change.Field, change.OldValue, change.NewValue, changedBy, friendID, userID); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *FriendChangePostgres) GetAllByFriendID(userID, friendID uuid.UUID) ([]models.FriendChange, error) {
+	var changes []models.FriendChange
+
+	query := fmt.Sprintf(`SELECT * FROM %s
+						WHERE friend_id = $1 AND user_id = $2
+						ORDER BY changed_at DESC, id`, friendChangeTable)
+
+	err := r.db.Select(&changes, query, friendID, userID)
+
+	return changes, err
+}
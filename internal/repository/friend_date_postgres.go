@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lunovoy/friendly/internal/models"
+)
+
+type FriendDatePostgres struct {
+	db *sqlx.DB
+}
+
+func NewFriendDatePostgres(db *sqlx.DB) *FriendDatePostgres {
+	return &FriendDatePostgres{
+		db: db,
+	}
+}
+
+func (r *FriendDatePostgres) Create(userID uuid.UUID, date models.FriendDate) (uuid.UUID, error) {
+	var dateID uuid.UUID
+	query := fmt.Sprintf(`INSERT INTO "%s" (label, kind, month, day, year, recurrence, reminder_minutes, friend_id, user_id)
+						VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id`, friendDateTable)
+
+	row := r.db.QueryRow(query, date.Label, date.Kind, date.Month, date.Day, date.Year, date.Recurrence, date.ReminderMinutes, date.FriendID, userID)
+	if err := row.Scan(&dateID); err != nil {
+		return uuid.Nil, err
+	}
+
+	return dateID, nil
+}
+
+func (r *FriendDatePostgres) GetAll(userID uuid.UUID) ([]models.FriendDate, error) {
+	var dates []models.FriendDate
+
+	query := fmt.Sprintf(`SELECT d.* FROM %s d
+						INNER JOIN %s f ON f.id = d.friend_id
+						WHERE d.user_id = $1 AND f.deleted_at IS NULL
+						ORDER BY d.month, d.day`, friendDateTable, friendTable)
+
+	err := r.db.Select(&dates, query, userID)
+
+	return dates, err
+}
+
+func (r *FriendDatePostgres) GetAllByFriendID(userID, friendID uuid.UUID) ([]models.FriendDate, error) {
+	var dates []models.FriendDate
+
+	query := fmt.Sprintf("SELECT * FROM %s WHERE friend_id = $1 AND user_id = $2 ORDER BY month, day", friendDateTable)
+
+	err := r.db.Select(&dates, query, friendID, userID)
+
+	return dates, err
+}
+
+func (r *FriendDatePostgres) GetByID(userID, friendID, dateID uuid.UUID) (models.FriendDate, error) {
+	var date models.FriendDate
+
+	query := fmt.Sprintf("SELECT * FROM %s WHERE id = $1 AND friend_id = $2 AND user_id = $3", friendDateTable)
+
+	err := r.db.Get(&date, query, dateID, friendID, userID)
+
+	return date, err
+}
+
+func (r *FriendDatePostgres) Update(userID, dateID uuid.UUID, date models.FriendDate) error {
+	query := fmt.Sprintf(`UPDATE %s SET label = $1, kind = $2, month = $3, day = $4, year = $5, recurrence = $6, reminder_minutes = $7
+						WHERE id = $8 AND user_id = $9`, friendDateTable)
+
+	_, err := r.db.Exec(query, date.Label, date.Kind, date.Month, date.Day, date.Year, date.Recurrence, date.ReminderMinutes, dateID, userID)
+
+	return err
+}
+
+func (r *FriendDatePostgres) DeleteByID(userID, dateID uuid.UUID) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE id = $1 AND user_id = $2", friendDateTable)
+
+	_, err := r.db.Exec(query, dateID, userID)
+
+	return err
+}
+
+func (r *FriendDatePostgres) GetAllWithReminders() ([]models.FriendDate, error) {
+	var dates []models.FriendDate
+
+	query := fmt.Sprintf(`SELECT d.* FROM %s d
+						INNER JOIN %s f ON f.id = d.friend_id
+						WHERE cardinality(d.reminder_minutes) > 0 AND f.deleted_at IS NULL`, friendDateTable, friendTable)
+
+	err := r.db.Select(&dates, query)
+
+	return dates, err
+}
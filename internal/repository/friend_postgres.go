@@ -3,10 +3,12 @@ package repository
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/huandu/go-sqlbuilder"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/lunovoy/friendly/internal/models"
 )
 
@@ -46,6 +48,14 @@ func (r *FriendPostgres) Create(userID uuid.UUID, friend models.UpdateFriendWork
 		friendFields = append(friendFields, "image_id")
 		friendValues = append(friendValues, *friend.Friend.ImageID)
 	}
+	if friend.Friend.TrackBirthday != nil {
+		friendFields = append(friendFields, "track_birthday")
+		friendValues = append(friendValues, *friend.Friend.TrackBirthday)
+	}
+	if friend.Friend.ContactIntervalDays != nil && *friend.Friend.ContactIntervalDays != 0 {
+		friendFields = append(friendFields, "contact_interval_days")
+		friendValues = append(friendValues, *friend.Friend.ContactIntervalDays)
+	}
 
 	builderFriend.Cols(friendFields...).Values(friendValues...)
 
@@ -131,7 +141,7 @@ func (r *FriendPostgres) GetAll(userID uuid.UUID) ([]models.FriendWorkInfoTags,
 	defer tx.Rollback()
 
 	var friends []models.Friend
-	friendQuery := fmt.Sprintf("SELECT * FROM %s WHERE user_id = $1", friendTable)
+	friendQuery := fmt.Sprintf("SELECT * FROM %s WHERE user_id = $1 AND deleted_at IS NULL ORDER BY first_name, id", friendTable)
 	err = tx.Select(&friends, friendQuery, userID)
 	if err != nil {
 		return nil, err
@@ -141,7 +151,7 @@ func (r *FriendPostgres) GetAll(userID uuid.UUID) ([]models.FriendWorkInfoTags,
 	workInfoQuery := fmt.Sprintf(`SELECT w.* 
 								FROM %s w
 								INNER JOIN %s f ON w.friend_id = f.id
-    							WHERE f.user_id = $1`, workInfoTable, friendTable)
+    							WHERE f.user_id = $1 AND f.deleted_at IS NULL`, workInfoTable, friendTable)
 	err = tx.Select(&workInfos, workInfoQuery, userID)
 	if err != nil {
 		return nil, err
@@ -150,7 +160,7 @@ func (r *FriendPostgres) GetAll(userID uuid.UUID) ([]models.FriendWorkInfoTags,
 	queryTags := fmt.Sprintf(`SELECT t.* 
 							FROM %s t
 							INNER JOIN %s ft ON ft.tag_id = t.id 
-							WHERE ft.friend_id = $1`, tagTable, friendsTagsTable)
+							WHERE ft.friend_id = $1 AND t.deleted_at IS NULL`, tagTable, friendsTagsTable)
 
 	tagStmt, err := tx.Preparex(queryTags)
 	if err != nil {
@@ -193,7 +203,7 @@ func (r *FriendPostgres) GetByID(userID, friendID uuid.UUID) (models.FriendWorkI
 	defer tx.Rollback()
 
 	var friend models.Friend
-	friendQuery := fmt.Sprintf("SELECT * FROM %s WHERE id = $1 AND user_id = $2", friendTable)
+	friendQuery := fmt.Sprintf("SELECT * FROM %s WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL", friendTable)
 	err = tx.Get(&friend, friendQuery, friendID, userID)
 	if err != nil {
 		return models.FriendWorkInfoTags{}, err
@@ -212,7 +222,7 @@ func (r *FriendPostgres) GetByID(userID, friendID uuid.UUID) (models.FriendWorkI
 	queryTags := fmt.Sprintf(`SELECT t.*
 	FROM %s t
 	INNER JOIN %s ft ON ft.tag_id = t.id 
-	WHERE ft.friend_id = $1`, tagTable, friendsTagsTable)
+	WHERE ft.friend_id = $1 AND t.deleted_at IS NULL`, tagTable, friendsTagsTable)
 
 	var tags []models.Tag
 	if err := tx.Select(&tags, queryTags, friend.ID); err != nil {
@@ -239,6 +249,14 @@ func (r *FriendPostgres) Update(userID, friendID uuid.UUID, friend models.Update
 	}
 	defer tx.Rollback()
 
+	if err := updateFriend(tx, userID, friendID, friend); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func updateFriend(tx *sqlx.Tx, userID, friendID uuid.UUID, friend models.UpdateFriendWorkInfoInput) error {
 	if friend.Friend != nil {
 		friendFieldsWithValues := []string{}
 		builderFriend := sqlbuilder.NewUpdateBuilder()
@@ -256,17 +274,30 @@ func (r *FriendPostgres) Update(userID, friendID uuid.UUID, friend models.Update
 			friendFieldsWithValues = append(friendFieldsWithValues, builderFriend.Assign("last_name", *friend.Friend.LastName))
 		}
 		if friend.Friend.DOB != nil {
-			friendFieldsWithValues = append(friendFieldsWithValues, builderFriend.Assign("dob", *friend.Friend.DOB))
+			if friend.Friend.DOB.IsZero() {
+				friendFieldsWithValues = append(friendFieldsWithValues, "dob = NULL")
+			} else {
+				friendFieldsWithValues = append(friendFieldsWithValues, builderFriend.Assign("dob", *friend.Friend.DOB))
+			}
 		}
 		if friend.Friend.ImageID != nil {
 			friendFieldsWithValues = append(friendFieldsWithValues, builderFriend.Assign("image_id", *friend.Friend.ImageID))
 		}
+		if friend.Friend.TrackBirthday != nil {
+			friendFieldsWithValues = append(friendFieldsWithValues, builderFriend.Assign("track_birthday", *friend.Friend.TrackBirthday))
+		}
+		if friend.Friend.ContactIntervalDays != nil {
+			if *friend.Friend.ContactIntervalDays == 0 {
+				friendFieldsWithValues = append(friendFieldsWithValues, "contact_interval_days = NULL")
+			} else {
+				friendFieldsWithValues = append(friendFieldsWithValues, builderFriend.Assign("contact_interval_days", *friend.Friend.ContactIntervalDays))
+			}
+		}
 
 		builderFriend.Set(friendFieldsWithValues...)
 
 		queryFriend, args := builderFriend.Build()
-		_, err = r.db.Exec(queryFriend, args...)
-		if err != nil {
+		if _, err := tx.Exec(queryFriend, args...); err != nil {
 			return err
 		}
 	}
@@ -303,21 +334,16 @@ func (r *FriendPostgres) Update(userID, friendID uuid.UUID, friend models.Update
 		builderWorkInfo.Set(workFieldsWithValues...)
 
 		queryWorkInfo, args := builderWorkInfo.Build()
-		_, err = r.db.Exec(queryWorkInfo, args...)
-		if err != nil {
+		if _, err := tx.Exec(queryWorkInfo, args...); err != nil {
 			return err
 		}
 	}
 
-	if err := tx.Commit(); err != nil {
-		return err
-	}
-
-	return err
+	return nil
 }
 
 func (r *FriendPostgres) DeleteByID(userID, FriendID uuid.UUID) error {
-	query := fmt.Sprintf("DELETE FROM %s where id=$1 AND user_id=$2", friendTable)
+	query := fmt.Sprintf("UPDATE %s SET deleted_at = now() WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL", friendTable)
 
 	_, err := r.db.Exec(query, FriendID, userID)
 	if err != nil {
@@ -402,3 +428,279 @@ func (r *FriendPostgres) DeleteTagFromFriend(friendID, tagID uuid.UUID) error {
 
 	return err
 }
+
+func (r *FriendPostgres) GetAllTrackingBirthdays() ([]models.Friend, error) {
+	var friends []models.Friend
+
+	query := fmt.Sprintf("SELECT * FROM %s WHERE dob IS NOT NULL AND track_birthday AND deleted_at IS NULL", friendTable)
+
+	err := r.db.Select(&friends, query)
+
+	return friends, err
+}
+
+func (r *FriendPostgres) MarkContacted(userID, friendID uuid.UUID, at time.Time) error {
+	query := fmt.Sprintf(`UPDATE %s SET last_contacted_at = GREATEST(last_contacted_at, $1)
+						WHERE id = $2 AND user_id = $3`, friendTable)
+
+	_, err := r.db.Exec(query, at, friendID, userID)
+
+	return err
+}
+
+// GetContactCadences returns friends having a keep-in-touch cadence, either
+// their own or the shortest one of their friendlists.
+func (r *FriendPostgres) GetContactCadences(userID uuid.UUID) ([]models.ContactCadence, error) {
+	var cadences []models.ContactCadence
+
+	query := fmt.Sprintf(`SELECT c.friend_id, c.contact_interval_days, c.last_contacted_at
+						FROM (
+							SELECT f.id AS friend_id, f.last_contacted_at,
+								COALESCE(f.contact_interval_days, (
+									SELECT MIN(fl.contact_interval_days)
+									FROM %s ff
+									INNER JOIN %s fl ON fl.id = ff.friendlist_id
+									WHERE ff.friend_id = f.id AND fl.deleted_at IS NULL
+								)) AS contact_interval_days
+							FROM %s f
+							WHERE f.user_id = $1 AND f.deleted_at IS NULL
+						) c
+						WHERE c.contact_interval_days IS NOT NULL`, friendlistsFriendsTable, friendlistTable, friendTable)
+
+	err := r.db.Select(&cadences, query, userID)
+
+	return cadences, err
+}
+
+func (r *FriendPostgres) GetContactCadenceUserIDs() ([]uuid.UUID, error) {
+	var userIDs []uuid.UUID
+
+	query := fmt.Sprintf(`SELECT user_id FROM %s WHERE contact_interval_days IS NOT NULL AND deleted_at IS NULL
+						UNION
+						SELECT user_id FROM %s WHERE contact_interval_days IS NOT NULL AND deleted_at IS NULL`, friendTable, friendlistTable)
+
+	err := r.db.Select(&userIDs, query)
+
+	return userIDs, err
+}
+
+var friendSortKeys = map[string]sortKey[models.Friend]{
+	"first_name": {column: "f.first_name", cast: "text", value: func(f models.Friend) string { return f.FirstName }},
+	"last_name":  {column: "f.last_name", cast: "text", value: func(f models.Friend) string { return f.LastName }},
+	"dob": {column: "COALESCE(f.dob, 'infinity')", cast: "timestamptz", value: func(f models.Friend) string {
+		return cursorTime(f.DOB.Valid, f.DOB.Time)
+	}},
+	"last_contacted_at": {column: "COALESCE(f.last_contacted_at, 'infinity')", cast: "timestamptz", value: func(f models.Friend) string {
+		return cursorTime(f.LastContactedAt.Valid, f.LastContactedAt.Time)
+	}},
+}
+
+// List returns a page of friends matching the filter with their work info and
+// tags, and the cursor of the next page.
+func (r *FriendPostgres) List(userID uuid.UUID, filter models.FriendFilter, params models.ListParams) ([]models.FriendWorkInfoTags, string, error) {
+	sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
+	sb.Select("f.*").From(fmt.Sprintf("%s f", friendTable))
+	sb.JoinWithOption(sqlbuilder.LeftJoin, fmt.Sprintf("%s w", workInfoTable), "w.friend_id = f.id")
+	sb.Where(sb.Equal("f.user_id", userID), sb.IsNull("f.deleted_at"))
+
+	if filter.TagID != nil {
+		sb.Where(fmt.Sprintf("EXISTS (SELECT 1 FROM %s ft WHERE ft.friend_id = f.id AND ft.tag_id = %s)", friendsTagsTable, sb.Var(*filter.TagID)))
+	}
+	if filter.FriendlistID != nil {
+		sb.Where(fmt.Sprintf("EXISTS (SELECT 1 FROM %s ff WHERE ff.friend_id = f.id AND ff.friendlist_id = %s)", friendlistsFriendsTable, sb.Var(*filter.FriendlistID)))
+	}
+	if filter.FriendIDs != nil {
+		sb.Where(fmt.Sprintf("f.id = ANY(%s)", sb.Var(pq.Array(filter.FriendIDs))))
+	}
+	if filter.City != "" {
+		sb.Where(fmt.Sprintf("w.city ILIKE %s", sb.Var(filter.City)))
+	}
+	if filter.Company != "" {
+		sb.Where(fmt.Sprintf("w.company ILIKE %s", sb.Var(filter.Company)))
+	}
+	if filter.DOBMonth != 0 {
+		sb.Where(fmt.Sprintf("EXTRACT(MONTH FROM f.dob) = %s", sb.Var(filter.DOBMonth)))
+	}
+	if filter.ContactedFrom != nil {
+		sb.Where(sb.GreaterEqualThan("f.last_contacted_at", *filter.ContactedFrom))
+	}
+	if filter.ContactedTo != nil {
+		sb.Where(sb.LessEqualThan("f.last_contacted_at", *filter.ContactedTo))
+	}
+
+	key, err := applyListParams(sb, params, friendSortKeys, "first_name", "f.id")
+	if err != nil {
+		return nil, "", err
+	}
+
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, "", err
+	}
+	defer tx.Rollback()
+
+	var friends []models.Friend
+	query, args := sb.Build()
+	if err := tx.Select(&friends, query, args...); err != nil {
+		return nil, "", err
+	}
+
+	friends, next := cutListPage(friends, params.Limit, key, func(f models.Friend) uuid.UUID { return f.ID })
+
+	friendIDs := make([]uuid.UUID, 0, len(friends))
+	for _, friend := range friends {
+		friendIDs = append(friendIDs, friend.ID)
+	}
+
+	var workInfos []models.WorkInfo
+	workInfoQuery := fmt.Sprintf("SELECT * FROM %s WHERE friend_id = ANY($1)", workInfoTable)
+	if err := tx.Select(&workInfos, workInfoQuery, pq.Array(friendIDs)); err != nil {
+		return nil, "", err
+	}
+
+	var friendTags []struct {
+		models.Tag
+		FriendID uuid.UUID `db:"friend_id"`
+	}
+	queryTags := fmt.Sprintf(`SELECT t.*, ft.friend_id
+							FROM %s t
+							INNER JOIN %s ft ON ft.tag_id = t.id
+							WHERE ft.friend_id = ANY($1) AND t.deleted_at IS NULL`, tagTable, friendsTagsTable)
+	if err := tx.Select(&friendTags, queryTags, pq.Array(friendIDs)); err != nil {
+		return nil, "", err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, "", err
+	}
+
+	workInfoByFriend := make(map[uuid.UUID]models.WorkInfo, len(workInfos))
+	for _, workInfo := range workInfos {
+		workInfoByFriend[workInfo.FriendID] = workInfo
+	}
+	tagsByFriend := make(map[uuid.UUID][]models.Tag)
+	for _, tag := range friendTags {
+		tagsByFriend[tag.FriendID] = append(tagsByFriend[tag.FriendID], tag.Tag)
+	}
+
+	result := make([]models.FriendWorkInfoTags, 0, len(friends))
+	for _, friend := range friends {
+		result = append(result, models.FriendWorkInfoTags{
+			Friend:   friend,
+			WorkInfo: workInfoByFriend[friend.ID],
+			Tags:     tagsByFriend[friend.ID],
+		})
+	}
+
+	return result, next, nil
+}
+
+// Merge moves everything linked to source friend to target friend and deletes
+// source. Friend and work info of target are updated with the merged values,
+// values of source custom fields in sourceFieldIDs replace the values of target.
+func (r *FriendPostgres) Merge(userID, targetID, sourceID uuid.UUID, merged models.UpdateFriendWorkInfoInput, sourceFieldIDs []uuid.UUID) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := updateFriend(tx, userID, targetID, merged); err != nil {
+		return err
+	}
+
+	queries := []string{
+		fmt.Sprintf(`UPDATE %s t SET last_contacted_at = GREATEST(t.last_contacted_at, s.last_contacted_at)
+					FROM %s s WHERE t.id = $1 AND s.id = $2`, friendTable, friendTable),
+
+		// Links without unique key are copied unless target already has them
+		fmt.Sprintf(`INSERT INTO %s (friend_id, tag_id)
+					SELECT $1::uuid, tag_id FROM %s WHERE friend_id = $2
+					EXCEPT SELECT $1::uuid, tag_id FROM %s WHERE friend_id = $1`, friendsTagsTable, friendsTagsTable, friendsTagsTable),
+		fmt.Sprintf(`INSERT INTO %s (friendlist_id, friend_id)
+					SELECT friendlist_id, $1::uuid FROM %s WHERE friend_id = $2
+					EXCEPT SELECT friendlist_id, $1::uuid FROM %s WHERE friend_id = $1`, friendlistsFriendsTable, friendlistsFriendsTable, friendlistsFriendsTable),
+		fmt.Sprintf(`INSERT INTO %s (friend_id, event_id)
+					SELECT $1::uuid, event_id FROM %s WHERE friend_id = $2
+					EXCEPT SELECT $1::uuid, event_id FROM %s WHERE friend_id = $1`, friendsEventsTable, friendsEventsTable, friendsEventsTable),
+		fmt.Sprintf(`INSERT INTO %s (friend_id, additional_info_field_id)
+					SELECT $1::uuid, additional_info_field_id FROM %s WHERE friend_id = $2
+					ON CONFLICT (additional_info_field_id, friend_id) DO NOTHING`, friendsAdditionalInfoFieldsTable, friendsAdditionalInfoFieldsTable),
+
+		fmt.Sprintf(`UPDATE %s SET friend_id = $1 WHERE friend_id = $2`, friendDateTable),
+		fmt.Sprintf(`UPDATE %s SET friend_id = $1 WHERE friend_id = $2`, giftIdeaTable),
+		fmt.Sprintf(`UPDATE %s SET friend_id = $1 WHERE friend_id = $2`, interactionTable),
+		fmt.Sprintf(`UPDATE %s SET friend_id = $1 WHERE friend_id = $2`, friendChangeTable),
+		fmt.Sprintf(`UPDATE %s SET friend_id = $1 WHERE friend_id = $2`, noteTable),
+		fmt.Sprintf(`UPDATE %s SET friend_id = $1 WHERE friend_id = $2`, attachmentTable),
+
+		// Mentions of source in note bodies point to target
+		fmt.Sprintf(`UPDATE %s SET body = replace(body, '](' || $2::text || ')', '](' || $1::text || ')')
+					WHERE id IN (SELECT note_id FROM %s WHERE friend_id = $2)`, noteTable, noteMentionTable),
+		fmt.Sprintf(`INSERT INTO %s (note_id, friend_id)
+					SELECT note_id, $1::uuid FROM %s WHERE friend_id = $2
+					ON CONFLICT (note_id, friend_id) DO NOTHING`, noteMentionTable, noteMentionTable),
+
+		// Source photos go after target photos, the same image is kept once
+		fmt.Sprintf(`DELETE FROM %s s USING %s t
+					WHERE s.friend_id = $2 AND t.friend_id = $1 AND s.image_id = t.image_id`, photoTable, photoTable),
+		fmt.Sprintf(`UPDATE %s SET friend_id = $1,
+						position = position + (SELECT COALESCE(MAX(position) + 1, 0) FROM %s WHERE friend_id = $1)
+					WHERE friend_id = $2`, photoTable, photoTable),
+
+		fmt.Sprintf(`DELETE FROM %s s USING %s t
+					WHERE s.friend_id = $2 AND t.friend_id = $1 AND s.kind = t.kind AND lower(s.value) = lower(t.value)`, contactPointTable, contactPointTable),
+		fmt.Sprintf(`UPDATE %s s SET is_primary = false
+					WHERE s.friend_id = $2 AND s.is_primary
+						AND EXISTS (SELECT 1 FROM %s t WHERE t.friend_id = $1 AND t.kind = s.kind AND t.is_primary)`, contactPointTable, contactPointTable),
+		fmt.Sprintf(`UPDATE %s SET friend_id = $1 WHERE friend_id = $2`, contactPointTable),
+
+		fmt.Sprintf(`DELETE FROM %s s USING %s t
+					WHERE s.friend_id = $2 AND t.friend_id = $1
+						AND lower(s.company) = lower(t.company) AND lower(s.position) = lower(t.position)`, employmentTable, employmentTable),
+		fmt.Sprintf(`UPDATE %s s SET ended_at = GREATEST(CURRENT_DATE, s.started_at)
+					WHERE s.friend_id = $2 AND s.ended_at IS NULL
+						AND EXISTS (SELECT 1 FROM %s t WHERE t.friend_id = $1 AND t.ended_at IS NULL)`, employmentTable, employmentTable),
+		fmt.Sprintf(`UPDATE %s SET friend_id = $1 WHERE friend_id = $2`, employmentTable),
+	}
+	for _, query := range queries {
+		if _, err := tx.Exec(query, targetID, sourceID); err != nil {
+			return err
+		}
+	}
+
+	queryFieldValues := fmt.Sprintf(`INSERT INTO %s (content, additional_info_field_id, friend_id)
+					SELECT content, additional_info_field_id, $1::uuid FROM %s WHERE friend_id = $2
+					ON CONFLICT (additional_info_field_id, friend_id) DO UPDATE SET content = EXCLUDED.content
+					WHERE EXCLUDED.additional_info_field_id = ANY($3::uuid[]) OR %s.content = ''`, additionalInfoFieldTextTable, additionalInfoFieldTextTable, additionalInfoFieldTextTable)
+	if _, err := tx.Exec(queryFieldValues, targetID, sourceID, pq.Array(sourceFieldIDs)); err != nil {
+		return err
+	}
+
+	// Undirected kinds are stored with ordered friends, relationships between
+	// source and target go away with source
+	queryRelationships := fmt.Sprintf(`INSERT INTO %s (kind, friend_id, related_friend_id, user_id)
+					SELECT kind,
+						CASE WHEN kind = ANY($3) THEN LEAST(m.friend_id, m.related_friend_id) ELSE m.friend_id END,
+						CASE WHEN kind = ANY($3) THEN GREATEST(m.friend_id, m.related_friend_id) ELSE m.related_friend_id END,
+						user_id
+					FROM (
+						SELECT kind, user_id,
+							CASE WHEN friend_id = $2 THEN $1::uuid ELSE friend_id END AS friend_id,
+							CASE WHEN related_friend_id = $2 THEN $1::uuid ELSE related_friend_id END AS related_friend_id
+						FROM %s WHERE friend_id = $2 OR related_friend_id = $2
+					) m
+					WHERE m.friend_id <> m.related_friend_id
+					ON CONFLICT (kind, friend_id, related_friend_id) DO NOTHING`, friendRelationshipTable, friendRelationshipTable)
+	undirected := []string{models.RelationshipKindSpouse, models.RelationshipKindSibling, models.RelationshipKindColleague}
+	if _, err := tx.Exec(queryRelationships, targetID, sourceID, pq.Array(undirected)); err != nil {
+		return err
+	}
+
+	queryDelete := fmt.Sprintf("DELETE FROM %s WHERE id = $1 AND user_id = $2", friendTable)
+	if _, err := tx.Exec(queryDelete, sourceID, userID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/huandu/go-sqlbuilder"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/lunovoy/friendly/internal/models"
 )
 
@@ -46,6 +47,14 @@ func (r *FriendlistPostgres) Create(userID uuid.UUID, friendlist models.UpdateFr
 		friendlistFields = append(friendlistFields, "image_id")
 		friendlistValues = append(friendlistValues, *friendlist.ImageID)
 	}
+	if friendlist.ContactIntervalDays != nil && *friendlist.ContactIntervalDays != 0 {
+		friendlistFields = append(friendlistFields, "contact_interval_days")
+		friendlistValues = append(friendlistValues, *friendlist.ContactIntervalDays)
+	}
+	if friendlist.Rule != nil && *friendlist.Rule != "" {
+		friendlistFields = append(friendlistFields, "rule")
+		friendlistValues = append(friendlistValues, *friendlist.Rule)
+	}
 
 	builderFriendlist.Cols(friendlistFields...).Values(friendlistValues...)
 
@@ -67,7 +76,7 @@ func (r *FriendlistPostgres) Create(userID uuid.UUID, friendlist models.UpdateFr
 func (r *FriendlistPostgres) GetAll(userID uuid.UUID) ([]models.Friendlist, error) {
 	var friendlists []models.Friendlist
 
-	query := fmt.Sprintf("SELECT * FROM %s where user_id = $1", friendlistTable)
+	query := fmt.Sprintf("SELECT * FROM %s where user_id = $1 AND deleted_at IS NULL ORDER BY title, id", friendlistTable)
 
 	err := r.db.Select(&friendlists, query, userID)
 
@@ -78,7 +87,7 @@ func (r *FriendlistPostgres) GetAll(userID uuid.UUID) ([]models.Friendlist, erro
 func (r *FriendlistPostgres) GetByID(userID, friendlistID uuid.UUID) (models.Friendlist, error) {
 	var friendlist models.Friendlist
 
-	query := fmt.Sprintf("SELECT * FROM %s WHERE id = $1 AND user_id = $2", friendlistTable)
+	query := fmt.Sprintf("SELECT * FROM %s WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL", friendlistTable)
 
 	err := r.db.Get(&friendlist, query, friendlistID, userID)
 
@@ -94,7 +103,7 @@ func (r *FriendlistPostgres) GetAllWithTags(userID uuid.UUID) ([]models.Friendli
 
 	var friendlists []models.Friendlist
 
-	queryFriendlists := fmt.Sprintf("SELECT * FROM %s WHERE user_id = $1", friendlistTable)
+	queryFriendlists := fmt.Sprintf("SELECT * FROM %s WHERE user_id = $1 AND deleted_at IS NULL", friendlistTable)
 
 	if err := tx.Select(&friendlists, queryFriendlists, userID); err != nil {
 		return nil, err
@@ -103,7 +112,7 @@ func (r *FriendlistPostgres) GetAllWithTags(userID uuid.UUID) ([]models.Friendli
 	queryTags := fmt.Sprintf(`SELECT t.* 
 							FROM %s t
 							INNER JOIN %s ft ON ft.tag_id = t.id 
-							WHERE ft.friendlist_id = $1`, tagTable, friendlistsTagsTable)
+							WHERE ft.friendlist_id = $1 AND t.deleted_at IS NULL`, tagTable, friendlistsTagsTable)
 
 	tagStmt, err := tx.Preparex(queryTags)
 	if err != nil {
@@ -138,7 +147,7 @@ func (r *FriendlistPostgres) GetByIDWithTags(userID, friendlistID uuid.UUID) (mo
 
 	var friendlist models.Friendlist
 
-	queryFriendlist := fmt.Sprintf("SELECT * FROM %s WHERE id = $1 AND user_id = $2", friendlistTable)
+	queryFriendlist := fmt.Sprintf("SELECT * FROM %s WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL", friendlistTable)
 
 	if err := tx.Get(&friendlist, queryFriendlist, friendlistID, userID); err != nil {
 		return models.FriendlistWithTags{}, err
@@ -147,7 +156,7 @@ func (r *FriendlistPostgres) GetByIDWithTags(userID, friendlistID uuid.UUID) (mo
 	queryTags := fmt.Sprintf(`SELECT t.* 
 							FROM %s t
 							INNER JOIN %s ft ON ft.tag_id = t.id 
-							WHERE ft.friendlist_id = $1`, tagTable, friendlistsTagsTable)
+							WHERE ft.friendlist_id = $1 AND t.deleted_at IS NULL`, tagTable, friendlistsTagsTable)
 
 	var tags []models.Tag
 	if err := tx.Select(&tags, queryTags, friendlist.ID); err != nil {
@@ -175,7 +184,7 @@ func (r *FriendlistPostgres) GetAllWithFriends(userID uuid.UUID) ([]models.Frien
 
 	var friendlists []models.Friendlist
 
-	queryFriendlists := fmt.Sprintf("SELECT * FROM %s WHERE user_id = $1", friendlistTable)
+	queryFriendlists := fmt.Sprintf("SELECT * FROM %s WHERE user_id = $1 AND deleted_at IS NULL", friendlistTable)
 
 	if err := tx.Select(&friendlists, queryFriendlists, userID); err != nil {
 		return nil, err
@@ -184,7 +193,7 @@ func (r *FriendlistPostgres) GetAllWithFriends(userID uuid.UUID) ([]models.Frien
 	queryFriends := fmt.Sprintf(`SELECT f.* 
 							FROM %s f
 							INNER JOIN %s ff ON ff.friend_id = f.id 
-							WHERE ff.friendlist_id = $1`, friendTable, friendlistsFriendsTable)
+							WHERE ff.friendlist_id = $1 AND f.deleted_at IS NULL`, friendTable, friendlistsFriendsTable)
 
 	stmt, err := tx.Preparex(queryFriends)
 	if err != nil {
@@ -219,7 +228,7 @@ func (r *FriendlistPostgres) GetByIDWithFriends(userID, friendlistID uuid.UUID)
 
 	var friendlist models.Friendlist
 
-	queryFriendlist := fmt.Sprintf("SELECT * FROM %s WHERE id = $1 AND user_id = $2", friendlistTable)
+	queryFriendlist := fmt.Sprintf("SELECT * FROM %s WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL", friendlistTable)
 
 	if err := tx.Get(&friendlist, queryFriendlist, friendlistID, userID); err != nil {
 		return models.FriendlistWithFriends{}, err
@@ -228,7 +237,7 @@ func (r *FriendlistPostgres) GetByIDWithFriends(userID, friendlistID uuid.UUID)
 	queryFriends := fmt.Sprintf(`SELECT f.* 
 							FROM %s f
 							INNER JOIN %s ff ON ff.friend_id = f.id 
-							WHERE ff.friendlist_id = $1`, friendTable, friendlistsFriendsTable)
+							WHERE ff.friendlist_id = $1 AND f.deleted_at IS NULL`, friendTable, friendlistsFriendsTable)
 
 	var friends []models.Friend
 	if err := tx.Select(&friends, queryFriends, friendlist.ID); err != nil {
@@ -270,6 +279,20 @@ func (r *FriendlistPostgres) Update(userID, friendlistID uuid.UUID, friendlist m
 	if friendlist.ImageID != nil {
 		friendlistFieldsWithValues = append(friendlistFieldsWithValues, builderFriendlist.Assign("image_id", *friendlist.ImageID))
 	}
+	if friendlist.ContactIntervalDays != nil {
+		if *friendlist.ContactIntervalDays == 0 {
+			friendlistFieldsWithValues = append(friendlistFieldsWithValues, "contact_interval_days = NULL")
+		} else {
+			friendlistFieldsWithValues = append(friendlistFieldsWithValues, builderFriendlist.Assign("contact_interval_days", *friendlist.ContactIntervalDays))
+		}
+	}
+	if friendlist.Rule != nil {
+		if *friendlist.Rule == "" {
+			friendlistFieldsWithValues = append(friendlistFieldsWithValues, "rule = NULL")
+		} else {
+			friendlistFieldsWithValues = append(friendlistFieldsWithValues, builderFriendlist.Assign("rule", *friendlist.Rule))
+		}
+	}
 
 	builderFriendlist.Set(friendlistFieldsWithValues...)
 
@@ -281,7 +304,7 @@ func (r *FriendlistPostgres) Update(userID, friendlistID uuid.UUID, friendlist m
 }
 
 func (r *FriendlistPostgres) DeleteByID(userID, friendlistID uuid.UUID) error {
-	query := fmt.Sprintf("DELETE FROM %s where id=$1 AND user_id=$2", friendlistTable)
+	query := fmt.Sprintf("UPDATE %s SET deleted_at = now() WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL", friendlistTable)
 
 	_, err := r.db.Exec(query, friendlistID, userID)
 	if err != nil {
@@ -357,11 +380,28 @@ func (r *FriendlistPostgres) AddFriendToFriendlist(friendlistID, friendID uuid.U
 		return errors.New("friend already exists in friendlist")
 	}
 
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
 	queryAdd := fmt.Sprintf("INSERT INTO \"%s\" (friendlist_id, friend_id) VALUES ($1, $2)", friendlistsFriendsTable)
 
-	_, err := r.db.Exec(queryAdd, friendlistID, friendID)
+	if _, err := tx.Exec(queryAdd, friendlistID, friendID); err != nil {
+		return err
+	}
 
-	return err
+	// Friend gets the fields of friendlist template
+	queryFields := fmt.Sprintf(`INSERT INTO "%s" (friend_id, additional_info_field_id)
+						SELECT $1, additional_info_field_id FROM %s WHERE friendlist_id = $2
+						ON CONFLICT (additional_info_field_id, friend_id) DO NOTHING`, friendsAdditionalInfoFieldsTable, friendlistsAdditionalInfoFieldsTable)
+
+	if _, err := tx.Exec(queryFields, friendID, friendlistID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 func (r *FriendlistPostgres) DeleteTagFromFriendlist(friendlistID, tagID uuid.UUID) error {
@@ -405,3 +445,115 @@ func (r *FriendlistPostgres) DeleteFriendFromFriendlist(friendlistID, friendID u
 
 	return err
 }
+
+func (r *FriendlistPostgres) GetTemplateFields(friendlistID uuid.UUID) ([]models.AdditionalInfoField, error) {
+	var fields []models.AdditionalInfoField
+
+	query := fmt.Sprintf(`SELECT aif.*
+						FROM %s aif
+						INNER JOIN %s flaif ON flaif.additional_info_field_id = aif.id
+						WHERE flaif.friendlist_id = $1
+						ORDER BY flaif.position, aif.title`, additionalInfoFieldTable, friendlistsAdditionalInfoFieldsTable)
+
+	err := r.db.Select(&fields, query, friendlistID)
+
+	return fields, err
+}
+
+// GetAllTemplateFields returns template fields of all friendlists of the user
+// ordered by friendlist.
+func (r *FriendlistPostgres) GetAllTemplateFields(userID uuid.UUID) ([]models.FriendlistTemplateField, error) {
+	var fields []models.FriendlistTemplateField
+
+	query := fmt.Sprintf(`SELECT flaif.friendlist_id, aif.*
+						FROM %s aif
+						INNER JOIN %s flaif ON flaif.additional_info_field_id = aif.id
+						INNER JOIN %s fl ON fl.id = flaif.friendlist_id
+						WHERE fl.user_id = $1 AND fl.deleted_at IS NULL
+						ORDER BY flaif.friendlist_id, flaif.position, aif.title`, additionalInfoFieldTable, friendlistsAdditionalInfoFieldsTable, friendlistTable)
+
+	err := r.db.Select(&fields, query, userID)
+
+	return fields, err
+}
+
+// AddFieldToTemplate appends the field to friendlist template and attaches
+// it to friends already in the friendlist.
+func (r *FriendlistPostgres) AddFieldToTemplate(friendlistID, fieldID uuid.UUID) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var exists bool
+	queryCheck := fmt.Sprintf("SELECT EXISTS(SELECT 1 FROM %s WHERE friendlist_id = $1 AND additional_info_field_id = $2)", friendlistsAdditionalInfoFieldsTable)
+
+	if err := tx.Get(&exists, queryCheck, friendlistID, fieldID); err != nil {
+		return err
+	}
+	if exists {
+		return errors.New("field already exists in friendlist template")
+	}
+
+	queryAdd := fmt.Sprintf(`INSERT INTO "%s" (position, friendlist_id, additional_info_field_id)
+						SELECT COALESCE(MAX(position) + 1, 0), $1, $2 FROM %s WHERE friendlist_id = $1`, friendlistsAdditionalInfoFieldsTable, friendlistsAdditionalInfoFieldsTable)
+
+	if _, err := tx.Exec(queryAdd, friendlistID, fieldID); err != nil {
+		return err
+	}
+
+	queryAttach := fmt.Sprintf(`INSERT INTO "%s" (friend_id, additional_info_field_id)
+						SELECT friend_id, $1 FROM %s WHERE friendlist_id = $2
+						ON CONFLICT (additional_info_field_id, friend_id) DO NOTHING`, friendsAdditionalInfoFieldsTable, friendlistsFriendsTable)
+
+	if _, err := tx.Exec(queryAttach, fieldID, friendlistID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// DeleteFieldFromTemplate keeps the field and its values on friends.
+func (r *FriendlistPostgres) DeleteFieldFromTemplate(friendlistID, fieldID uuid.UUID) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE friendlist_id = $1 AND additional_info_field_id = $2", friendlistsAdditionalInfoFieldsTable)
+
+	_, err := r.db.Exec(query, friendlistID, fieldID)
+
+	return err
+}
+
+var friendlistSortKeys = map[string]sortKey[models.Friendlist]{
+	"title": {column: "fl.title", cast: "text", value: func(f models.Friendlist) string { return f.Title }},
+}
+
+func (r *FriendlistPostgres) List(userID uuid.UUID, filter models.FriendlistFilter, params models.ListParams) ([]models.Friendlist, string, error) {
+	sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
+	sb.Select("fl.*").From(fmt.Sprintf("%s fl", friendlistTable))
+	sb.Where(sb.Equal("fl.user_id", userID), sb.IsNull("fl.deleted_at"))
+
+	if filter.TagID != nil {
+		sb.Where(fmt.Sprintf("EXISTS (SELECT 1 FROM %s ft WHERE ft.friendlist_id = fl.id AND ft.tag_id = %s)", friendlistsTagsTable, sb.Var(*filter.TagID)))
+	}
+	if filter.FriendID != nil {
+		sb.Where(sb.Or(
+			fmt.Sprintf("(fl.rule IS NULL AND EXISTS (SELECT 1 FROM %s ff WHERE ff.friendlist_id = fl.id AND ff.friend_id = %s))", friendlistsFriendsTable, sb.Var(*filter.FriendID)),
+			fmt.Sprintf("fl.id = ANY(%s)", sb.Var(pq.Array(filter.SmartIDs))),
+		))
+	}
+
+	key, err := applyListParams(sb, params, friendlistSortKeys, "title", "fl.id")
+	if err != nil {
+		return nil, "", err
+	}
+
+	var friendlists []models.Friendlist
+	query, args := sb.Build()
+	if err := r.db.Select(&friendlists, query, args...); err != nil {
+		return nil, "", err
+	}
+
+	friendlists, next := cutListPage(friendlists, params.Limit, key, func(f models.Friendlist) uuid.UUID { return f.ID })
+
+	return friendlists, next, nil
+}
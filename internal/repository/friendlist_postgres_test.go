@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/lunovoy/friendly/internal/models"
+)
+
+func TestFriendlistTemplateFields(t *testing.T) {
+	db := newTestDB(t)
+	friendlists := NewFriendlistPostgres(db)
+	fields := NewAdditionalInfoFieldPostgres(db)
+
+	userID, err := NewAuthPostgres(db).CreateUser(models.User{Mail: "user@example.com", Password: "hash", Salt: "salt"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	firstName := "Иван"
+	friend, err := NewFriendPostgres(db).Create(userID, models.UpdateFriendWorkInfoInput{
+		Friend: &models.UpdateFriendInput{FirstName: &firstName},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	title := "Клиенты"
+	friendlistID, err := friendlists.Create(userID, models.UpdateFriendlist{Title: &title})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fieldIDs []uuid.UUID
+	for _, title := range []string{"Договор до", "Размер одежды"} {
+		fieldID, err := fields.Create(userID, models.AdditionalInfoField{Title: title, Type: models.AdditionalInfoFieldTypeText, Options: pq.StringArray{}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		fieldIDs = append(fieldIDs, fieldID)
+	}
+
+	friendFields := func(fieldID uuid.UUID) int {
+		t.Helper()
+		var count int
+		query := fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE friend_id = $1 AND additional_info_field_id = $2", friendsAdditionalInfoFieldsTable)
+		if err := db.Get(&count, query, friend.FriendID, fieldID); err != nil {
+			t.Fatal(err)
+		}
+		return count
+	}
+
+	if err := friendlists.AddFieldToTemplate(friendlistID, fieldIDs[0]); err != nil {
+		t.Fatal(err)
+	}
+	if err := friendlists.AddFriendToFriendlist(friendlistID, friend.FriendID); err != nil {
+		t.Fatal(err)
+	}
+	if got := friendFields(fieldIDs[0]); got != 1 {
+		t.Errorf("added friend has the template field %d times, want 1", got)
+	}
+
+	if err := friendlists.DeleteFriendFromFriendlist(friendlistID, friend.FriendID); err != nil {
+		t.Fatal(err)
+	}
+	if err := friendlists.AddFriendToFriendlist(friendlistID, friend.FriendID); err != nil {
+		t.Fatal(err)
+	}
+	if got := friendFields(fieldIDs[0]); got != 1 {
+		t.Errorf("re-added friend has the template field %d times, want 1", got)
+	}
+
+	if err := friendlists.AddFieldToTemplate(friendlistID, fieldIDs[1]); err != nil {
+		t.Fatal(err)
+	}
+	if got := friendFields(fieldIDs[1]); got != 1 {
+		t.Errorf("friend has the field added to the template %d times, want 1", got)
+	}
+	if err := friendlists.AddFieldToTemplate(friendlistID, fieldIDs[1]); err == nil {
+		t.Error("AddFieldToTemplate() of a field in the template succeeded, want error")
+	}
+
+	template, err := friendlists.GetTemplateFields(friendlistID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []uuid.UUID
+	for _, field := range template {
+		got = append(got, field.ID)
+	}
+	if !reflect.DeepEqual(got, fieldIDs) {
+		t.Errorf("GetTemplateFields() = %v, want %v in the order added", got, fieldIDs)
+	}
+
+	otherTitle := "Семья"
+	otherID, err := friendlists.Create(userID, models.UpdateFriendlist{Title: &otherTitle})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := friendlists.AddFieldToTemplate(otherID, fieldIDs[1]); err != nil {
+		t.Fatal(err)
+	}
+	all, err := friendlists.GetAllTemplateFields(userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	byFriendlist := make(map[uuid.UUID][]uuid.UUID)
+	for _, field := range all {
+		byFriendlist[field.FriendlistID] = append(byFriendlist[field.FriendlistID], field.ID)
+	}
+	want := map[uuid.UUID][]uuid.UUID{friendlistID: fieldIDs, otherID: {fieldIDs[1]}}
+	if !reflect.DeepEqual(byFriendlist, want) {
+		t.Errorf("GetAllTemplateFields() = %v, want %v", byFriendlist, want)
+	}
+}
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lunovoy/friendly/internal/models"
+)
+
+type FriendlistSharePostgres struct {
+	db *sqlx.DB
+}
+
+func NewFriendlistSharePostgres(db *sqlx.DB) *FriendlistSharePostgres {
+	return &FriendlistSharePostgres{
+		db: db,
+	}
+}
+
+// Create shares the friendlist with the user, sharing twice keeps the first
+// share.
+func (r *FriendlistSharePostgres) Create(friendlistID, userID uuid.UUID) (uuid.UUID, error) {
+	var shareID uuid.UUID
+	query := fmt.Sprintf(`INSERT INTO %s (friendlist_id, user_id) VALUES ($1, $2)
+						ON CONFLICT (friendlist_id, user_id) DO UPDATE SET friendlist_id = EXCLUDED.friendlist_id
+						RETURNING id`, friendlistShareTable)
+
+	row := r.db.QueryRow(query, friendlistID, userID)
+	if err := row.Scan(&shareID); err != nil {
+		return uuid.Nil, err
+	}
+
+	return shareID, nil
+}
+
+func (r *FriendlistSharePostgres) GetAllByFriendlistID(friendlistID uuid.UUID) ([]models.FriendlistShare, error) {
+	var shares []models.FriendlistShare
+
+	query := fmt.Sprintf(`SELECT s.id, s.friendlist_id, s.user_id, u.username, u.mail, s.created_at
+						FROM %s s
+						INNER JOIN "%s" u ON u.id = s.user_id
+						WHERE s.friendlist_id = $1
+						ORDER BY s.created_at, s.id`, friendlistShareTable, userTable)
+
+	err := r.db.Select(&shares, query, friendlistID)
+
+	return shares, err
+}
+
+func (r *FriendlistSharePostgres) Delete(friendlistID, userID uuid.UUID) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE friendlist_id = $1 AND user_id = $2", friendlistShareTable)
+
+	_, err := r.db.Exec(query, friendlistID, userID)
+
+	return err
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/lunovoy/friendly/internal/models"
+)
+
+type GiftIdeaPostgres struct {
+	db *sqlx.DB
+}
+
+func NewGiftIdeaPostgres(db *sqlx.DB) *GiftIdeaPostgres {
+	return &GiftIdeaPostgres{
+		db: db,
+	}
+}
+
+func (r *GiftIdeaPostgres) Create(userID uuid.UUID, idea models.GiftIdea) (uuid.UUID, error) {
+	var ideaID uuid.UUID
+	query := fmt.Sprintf(`INSERT INTO "%s" (title, link, price, status, occasion, occasion_date, event_id, friend_id, user_id)
+						VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id`, giftIdeaTable)
+
+	row := r.db.QueryRow(query, idea.Title, idea.Link, idea.Price, idea.Status, idea.Occasion, idea.OccasionDate, idea.EventID, idea.FriendID, userID)
+	if err := row.Scan(&ideaID); err != nil {
+		return uuid.Nil, err
+	}
+
+	return ideaID, nil
+}
+
+func (r *GiftIdeaPostgres) GetAllByFriendID(userID, friendID uuid.UUID) ([]models.GiftIdea, error) {
+	var ideas []models.GiftIdea
+
+	query := fmt.Sprintf("SELECT * FROM %s WHERE friend_id = $1 AND user_id = $2 ORDER BY created_at DESC, id", giftIdeaTable)
+
+	err := r.db.Select(&ideas, query, friendID, userID)
+
+	return ideas, err
+}
+
+func (r *GiftIdeaPostgres) GetByID(userID, friendID, ideaID uuid.UUID) (models.GiftIdea, error) {
+	var idea models.GiftIdea
+
+	query := fmt.Sprintf("SELECT * FROM %s WHERE id = $1 AND friend_id = $2 AND user_id = $3", giftIdeaTable)
+
+	err := r.db.Get(&idea, query, ideaID, friendID, userID)
+
+	return idea, err
+}
+
+func (r *GiftIdeaPostgres) Update(userID, ideaID uuid.UUID, idea models.GiftIdea) error {
+	query := fmt.Sprintf(`UPDATE %s SET title = $1, link = $2, price = $3, status = $4, occasion = $5, occasion_date = $6, event_id = $7
+						WHERE id = $8 AND user_id = $9`, giftIdeaTable)
+
+	_, err := r.db.Exec(query, idea.Title, idea.Link, idea.Price, idea.Status, idea.Occasion, idea.OccasionDate, idea.EventID, ideaID, userID)
+
+	return err
+}
+
+func (r *GiftIdeaPostgres) DeleteByID(userID, ideaID uuid.UUID) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE id = $1 AND user_id = $2", giftIdeaTable)
+
+	_, err := r.db.Exec(query, ideaID, userID)
+
+	return err
+}
+
+// GetOpenByFriendIDs returns gift ideas of the friends which were not given yet.
+func (r *GiftIdeaPostgres) GetOpenByFriendIDs(friendIDs []uuid.UUID) ([]models.GiftIdea, error) {
+	var ideas []models.GiftIdea
+
+	query := fmt.Sprintf(`SELECT * FROM %s WHERE friend_id = ANY($1) AND status <> $2
+						ORDER BY created_at, id`, giftIdeaTable)
+
+	err := r.db.Select(&ideas, query, pq.Array(friendIDs), models.GiftIdeaStatusGiven)
+
+	return ideas, err
+}
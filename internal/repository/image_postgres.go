@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/lunovoy/friendly/internal/models"
+)
+
+type ImagePostgres struct {
+	db *sqlx.DB
+}
+
+func NewImagePostgres(db *sqlx.DB) *ImagePostgres {
+	return &ImagePostgres{
+		db: db,
+	}
+}
+
+func (r *ImagePostgres) Create(userID uuid.UUID, image models.Image) error {
+	query := fmt.Sprintf(`INSERT INTO "%s" (id, mime_type, width, height, size, user_id)
+						VALUES ($1, $2, $3, $4, $5, $6)`, imageTable)
+
+	_, err := r.db.Exec(query, image.ID, image.MimeType, image.Width, image.Height, image.Size, userID)
+
+	return err
+}
+
+// GetByID returns the image only to its owner.
+func (r *ImagePostgres) GetByID(userID, imageID uuid.UUID) (models.Image, error) {
+	var image models.Image
+
+	query := fmt.Sprintf(`SELECT * FROM "%s" WHERE id = $1 AND user_id = $2`, imageTable)
+
+	err := r.db.Get(&image, query, imageID, userID)
+
+	return image, err
+}
+
+// GetShared returns the image to its owner and to users a friendlist of the
+// owner is shared with, when the image belongs to the friendlist or to one of
+// its friends.
+func (r *ImagePostgres) GetShared(userID, imageID uuid.UUID) (models.Image, error) {
+	var image models.Image
+
+	query := fmt.Sprintf(`SELECT i.* FROM "%s" i
+						WHERE i.id = $1 AND (i.user_id = $2 OR EXISTS (
+							SELECT 1 FROM %s s
+							INNER JOIN %s fl ON fl.id = s.friendlist_id
+							WHERE s.user_id = $2 AND fl.user_id = i.user_id AND fl.deleted_at IS NULL
+								AND (fl.image_id = i.id OR EXISTS (
+									SELECT 1 FROM %s ff
+									INNER JOIN %s f ON f.id = ff.friend_id
+									WHERE ff.friendlist_id = fl.id AND f.deleted_at IS NULL
+										AND (f.image_id = i.id OR EXISTS (SELECT 1 FROM %s p WHERE p.friend_id = f.id AND p.image_id = i.id))
+								))
+						))`, imageTable, friendlistShareTable, friendlistTable, friendlistsFriendsTable, friendTable, photoTable)
+
+	err := r.db.Get(&image, query, imageID, userID)
+
+	return image, err
+}
+
+func (r *ImagePostgres) DeleteByID(userID, imageID uuid.UUID) error {
+	query := fmt.Sprintf(`DELETE FROM "%s" WHERE id = $1 AND user_id = $2`, imageTable)
+
+	_, err := r.db.Exec(query, imageID, userID)
+
+	return err
+}
+
+// DeleteUnreferenced deletes those of the images no user, friendlist, friend
+// or gallery photo refers to, trashed ones included, and returns the ids of
+// deleted images. An avatar shares the image with its gallery photo.
+func (r *ImagePostgres) DeleteUnreferenced(imageIDs []uuid.UUID) ([]uuid.UUID, error) {
+	var deleted []uuid.UUID
+
+	query := fmt.Sprintf(`DELETE FROM "%s" i
+						WHERE i.id = ANY($1)
+							AND NOT EXISTS (SELECT 1 FROM "%s" u WHERE u.image_id = i.id)
+							AND NOT EXISTS (SELECT 1 FROM %s fl WHERE fl.image_id = i.id)
+							AND NOT EXISTS (SELECT 1 FROM %s f WHERE f.image_id = i.id)
+							AND NOT EXISTS (SELECT 1 FROM %s p WHERE p.image_id = i.id)
+						RETURNING i.id`, imageTable, userTable, friendlistTable, friendTable, photoTable)
+
+	err := r.db.Select(&deleted, query, pq.Array(imageIDs))
+
+	return deleted, err
+}
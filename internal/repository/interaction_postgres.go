@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lunovoy/friendly/internal/models"
+)
+
+type InteractionPostgres struct {
+	db *sqlx.DB
+}
+
+func NewInteractionPostgres(db *sqlx.DB) *InteractionPostgres {
+	return &InteractionPostgres{
+		db: db,
+	}
+}
+
+func (r *InteractionPostgres) Create(userID uuid.UUID, interaction models.Interaction) (uuid.UUID, error) {
+	var interactionID uuid.UUID
+	query := fmt.Sprintf(`INSERT INTO "%s" (kind, occurred_at, channel, text, event_id, friend_id, user_id)
+						VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`, interactionTable)
+
+	row := r.db.QueryRow(query, interaction.Kind, interaction.OccurredAt, interaction.Channel, interaction.Text, interaction.EventID, interaction.FriendID, userID)
+	if err := row.Scan(&interactionID); err != nil {
+		return uuid.Nil, err
+	}
+
+	return interactionID, nil
+}
+
+func (r *InteractionPostgres) GetAllByFriendID(userID, friendID uuid.UUID) ([]models.Interaction, error) {
+	var interactions []models.Interaction
+
+	query := fmt.Sprintf(`SELECT * FROM %s
+						WHERE friend_id = $1 AND user_id = $2
+						ORDER BY occurred_at DESC`, interactionTable)
+
+	err := r.db.Select(&interactions, query, friendID, userID)
+
+	return interactions, err
+}
+
+func (r *InteractionPostgres) GetByID(userID, friendID, interactionID uuid.UUID) (models.Interaction, error) {
+	var interaction models.Interaction
+
+	query := fmt.Sprintf("SELECT * FROM %s WHERE id = $1 AND friend_id = $2 AND user_id = $3", interactionTable)
+
+	err := r.db.Get(&interaction, query, interactionID, friendID, userID)
+
+	return interaction, err
+}
+
+func (r *InteractionPostgres) Update(userID, interactionID uuid.UUID, interaction models.Interaction) error {
+	query := fmt.Sprintf(`UPDATE %s SET kind = $1, occurred_at = $2, channel = $3, text = $4, event_id = $5
+						WHERE id = $6 AND user_id = $7`, interactionTable)
+
+	_, err := r.db.Exec(query, interaction.Kind, interaction.OccurredAt, interaction.Channel, interaction.Text, interaction.EventID, interactionID, userID)
+
+	return err
+}
+
+func (r *InteractionPostgres) DeleteByID(userID, interactionID uuid.UUID) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE id = $1 AND user_id = $2", interactionTable)
+
+	_, err := r.db.Exec(query, interactionID, userID)
+
+	return err
+}
+
+// GetLastContacts returns the latest interaction per friend, notes are not
+// counted as a contact.
+func (r *InteractionPostgres) GetLastContacts(userID uuid.UUID, now time.Time) ([]models.LastInteraction, error) {
+	var lastInteractions []models.LastInteraction
+
+	query := fmt.Sprintf(`SELECT i.friend_id, MAX(i.occurred_at) AS occurred_at
+						FROM %s i
+						INNER JOIN %s f ON f.id = i.friend_id
+						WHERE i.user_id = $1 AND i.kind <> $2 AND i.occurred_at <= $3 AND f.deleted_at IS NULL
+						GROUP BY i.friend_id`, interactionTable, friendTable)
+
+	err := r.db.Select(&lastInteractions, query, userID, models.InteractionKindNote, now)
+
+	return lastInteractions, err
+}
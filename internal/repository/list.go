@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/lunovoy/friendly/internal/models"
+)
+
+var errInvalidCursor = errors.New("cursor is not valid")
+
+// sortKey is a column a list can be ordered by. value renders the column of a
+// row the way cast parses it back, so a cursor continues after the row.
+type sortKey[T any] struct {
+	column string
+	cast   string
+	value  func(T) string
+}
+
+type listCursor struct {
+	Value string    `json:"v"`
+	ID    uuid.UUID `json:"id"`
+}
+
+// applyListParams orders the query by the sort key and id, continues it after
+// the cursor and fetches one row more than the limit to tell whether there is
+// a next page.
+func applyListParams[T any](sb *sqlbuilder.SelectBuilder, params models.ListParams, keys map[string]sortKey[T], defaultSort, idColumn string) (sortKey[T], error) {
+	if params.Sort == "" {
+		params.Sort = defaultSort
+	}
+	key, ok := keys[params.Sort]
+	if !ok {
+		return sortKey[T]{}, fmt.Errorf("sort %s is not supported", params.Sort)
+	}
+
+	direction, operator := "ASC", ">"
+	if params.Desc {
+		direction, operator = "DESC", "<"
+	}
+
+	if params.Cursor != "" {
+		cursor, err := decodeListCursor(params.Cursor)
+		if err != nil {
+			return sortKey[T]{}, err
+		}
+		sb.Where(fmt.Sprintf("(%s, %s) %s (%s::%s, %s)", key.column, idColumn, operator, sb.Var(cursor.Value), key.cast, sb.Var(cursor.ID)))
+	}
+
+	sb.OrderBy(key.column+" "+direction, idColumn+" "+direction)
+	sb.Limit(params.Limit + 1)
+
+	return key, nil
+}
+
+// cutListPage drops the extra row fetched by applyListParams and returns the
+// cursor of the next page, empty on the last one.
+func cutListPage[T any](rows []T, limit int, key sortKey[T], id func(T) uuid.UUID) ([]T, string) {
+	if len(rows) <= limit {
+		return rows, ""
+	}
+
+	rows = rows[:limit]
+	last := rows[len(rows)-1]
+
+	return rows, encodeListCursor(listCursor{Value: key.value(last), ID: id(last)})
+}
+
+func encodeListCursor(cursor listCursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeListCursor(value string) (listCursor, error) {
+	var cursor listCursor
+
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return cursor, errInvalidCursor
+	}
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return cursor, errInvalidCursor
+	}
+
+	return cursor, nil
+}
+
+// cursorTime renders a nullable timestamp, NULL sorts as infinity.
+func cursorTime(valid bool, t time.Time) string {
+	if !valid {
+		return "infinity"
+	}
+	return t.Format(time.RFC3339Nano)
+}
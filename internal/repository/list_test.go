@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/lunovoy/friendly/internal/models"
+)
+
+type listRow struct {
+	ID   uuid.UUID
+	Name string
+}
+
+var listRowSortKeys = map[string]sortKey[listRow]{
+	"name": {column: "r.name", cast: "text", value: func(row listRow) string { return row.Name }},
+}
+
+func TestApplyListParams(t *testing.T) {
+	cursorID := uuid.MustParse("11111111-1111-1111-1111-111111111111")
+	cursor := encodeListCursor(listCursor{Value: "Иван", ID: cursorID})
+
+	tests := []struct {
+		name     string
+		params   models.ListParams
+		wantSQL  string
+		wantArgs []interface{}
+		wantErr  bool
+	}{
+		{
+			name:    "first page",
+			params:  models.ListParams{Limit: 2},
+			wantSQL: "SELECT r.id FROM rows r ORDER BY r.name ASC, r.id ASC LIMIT 3",
+		},
+		{
+			name:     "next page in descending order",
+			params:   models.ListParams{Sort: "name", Desc: true, Cursor: cursor, Limit: 2},
+			wantSQL:  "SELECT r.id FROM rows r WHERE (r.name, r.id) < ($1::text, $2) ORDER BY r.name DESC, r.id DESC LIMIT 3",
+			wantArgs: []interface{}{"Иван", cursorID},
+		},
+		{
+			name:    "unknown sort",
+			params:  models.ListParams{Sort: "age", Limit: 2},
+			wantErr: true,
+		},
+		{
+			name:    "invalid cursor",
+			params:  models.ListParams{Cursor: "not a cursor", Limit: 2},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
+			sb.Select("r.id").From("rows r")
+
+			_, err := applyListParams(sb, tt.params, listRowSortKeys, "name", "r.id")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("applyListParams() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			sql, args := sb.Build()
+			if sql != tt.wantSQL {
+				t.Errorf("sql = %s, want %s", sql, tt.wantSQL)
+			}
+			if (len(args) != 0 || len(tt.wantArgs) != 0) && !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("args = %v, want %v", args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestCutListPage(t *testing.T) {
+	rows := []listRow{
+		{ID: uuid.New(), Name: "Анна"},
+		{ID: uuid.New(), Name: "Иван"},
+		{ID: uuid.New(), Name: "Олег"},
+	}
+	id := func(row listRow) uuid.UUID { return row.ID }
+
+	page, next := cutListPage(rows, 3, listRowSortKeys["name"], id)
+	if len(page) != 3 || next != "" {
+		t.Errorf("last page = %d rows, cursor %q, want 3 rows without cursor", len(page), next)
+	}
+
+	page, next = cutListPage(rows, 2, listRowSortKeys["name"], id)
+	if len(page) != 2 {
+		t.Fatalf("page = %d rows, want 2", len(page))
+	}
+	cursor, err := decodeListCursor(next)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (listCursor{Value: "Иван", ID: rows[1].ID}); cursor != want {
+		t.Errorf("cursor = %+v, want %+v", cursor, want)
+	}
+}
+
+func TestDecodeListCursor(t *testing.T) {
+	for _, value := range []string{"%%%", "bm90IGpzb24"} {
+		if _, err := decodeListCursor(value); !errors.Is(err, errInvalidCursor) {
+			t.Errorf("decodeListCursor(%q) error = %v, want %v", value, err, errInvalidCursor)
+		}
+	}
+}
+
+func TestCursorTime(t *testing.T) {
+	if got := cursorTime(false, time.Time{}); got != "infinity" {
+		t.Errorf("cursorTime() of NULL = %s, want infinity", got)
+	}
+	at := time.Date(2024, time.March, 10, 12, 30, 0, 500, time.UTC)
+	if got, want := cursorTime(true, at), "2024-03-10T12:30:00.0000005Z"; got != want {
+		t.Errorf("cursorTime() = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/lunovoy/friendly/internal/models"
+)
+
+type NotePostgres struct {
+	db *sqlx.DB
+}
+
+func NewNotePostgres(db *sqlx.DB) *NotePostgres {
+	return &NotePostgres{
+		db: db,
+	}
+}
+
+func (r *NotePostgres) Create(userID uuid.UUID, note models.Note, mentionIDs []uuid.UUID) (uuid.UUID, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return uuid.Nil, err
+	}
+	defer tx.Rollback()
+
+	var noteID uuid.UUID
+	query := fmt.Sprintf(`INSERT INTO "%s" (body, friend_id, event_id, friendlist_id, user_id)
+						VALUES ($1, $2, $3, $4, $5) RETURNING id`, noteTable)
+
+	row := tx.QueryRow(query, note.Body, note.FriendID, note.EventID, note.FriendlistID, userID)
+	if err := row.Scan(&noteID); err != nil {
+		return uuid.Nil, err
+	}
+
+	if err := setNoteMentions(tx, noteID, mentionIDs); err != nil {
+		return uuid.Nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return uuid.Nil, err
+	}
+	return noteID, nil
+}
+
+// GetAll returns notes of the user whose friend, event or friendlist is not
+// in the trash, the latest first.
+func (r *NotePostgres) GetAll(userID uuid.UUID, filter models.NoteFilter) ([]models.Note, error) {
+	sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
+	sb.Select("n.*").From(fmt.Sprintf("%s n", noteTable))
+	sb.JoinWithOption(sqlbuilder.LeftJoin, fmt.Sprintf("%s f", friendTable), "f.id = n.friend_id")
+	sb.JoinWithOption(sqlbuilder.LeftJoin, fmt.Sprintf("%s e", eventTable), "e.id = n.event_id")
+	sb.JoinWithOption(sqlbuilder.LeftJoin, fmt.Sprintf("%s fl", friendlistTable), "fl.id = n.friendlist_id")
+	sb.Where(
+		sb.Equal("n.user_id", userID),
+		sb.IsNull("f.deleted_at"),
+		sb.IsNull("e.deleted_at"),
+		sb.IsNull("fl.deleted_at"),
+	)
+
+	if filter.FriendID != nil {
+		sb.Where(sb.Equal("n.friend_id", *filter.FriendID))
+	}
+	if filter.EventID != nil {
+		sb.Where(sb.Equal("n.event_id", *filter.EventID))
+	}
+	if filter.FriendlistID != nil {
+		sb.Where(sb.Equal("n.friendlist_id", *filter.FriendlistID))
+	}
+	if filter.MentionedFriendID != nil {
+		sb.Where(fmt.Sprintf("EXISTS (SELECT 1 FROM %s m WHERE m.note_id = n.id AND m.friend_id = %s)", noteMentionTable, sb.Var(*filter.MentionedFriendID)))
+	}
+	sb.OrderBy("n.created_at DESC", "n.id")
+
+	var notes []models.Note
+	query, args := sb.Build()
+	err := r.db.Select(&notes, query, args...)
+
+	return notes, err
+}
+
+// GetAllByFriendID returns notes attached to the friend or mentioning it.
+func (r *NotePostgres) GetAllByFriendID(userID, friendID uuid.UUID) ([]models.Note, error) {
+	var notes []models.Note
+
+	query := fmt.Sprintf(`SELECT n.* FROM %s n
+						LEFT JOIN %s e ON e.id = n.event_id
+						LEFT JOIN %s fl ON fl.id = n.friendlist_id
+						WHERE n.user_id = $2 AND e.deleted_at IS NULL AND fl.deleted_at IS NULL
+							AND (n.friend_id = $1 OR EXISTS (SELECT 1 FROM %s m WHERE m.note_id = n.id AND m.friend_id = $1))
+						ORDER BY n.created_at DESC, n.id`, noteTable, eventTable, friendlistTable, noteMentionTable)
+
+	err := r.db.Select(&notes, query, friendID, userID)
+
+	return notes, err
+}
+
+func (r *NotePostgres) GetByID(userID, noteID uuid.UUID) (models.Note, error) {
+	var note models.Note
+
+	query := fmt.Sprintf("SELECT * FROM %s WHERE id = $1 AND user_id = $2", noteTable)
+
+	err := r.db.Get(&note, query, noteID, userID)
+
+	return note, err
+}
+
+func (r *NotePostgres) Update(userID, noteID uuid.UUID, body string, mentionIDs []uuid.UUID) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := fmt.Sprintf("UPDATE %s SET body = $1, updated_at = now() WHERE id = $2 AND user_id = $3", noteTable)
+	if _, err := tx.Exec(query, body, noteID, userID); err != nil {
+		return err
+	}
+
+	if err := setNoteMentions(tx, noteID, mentionIDs); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *NotePostgres) DeleteByID(userID, noteID uuid.UUID) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE id = $1 AND user_id = $2", noteTable)
+
+	_, err := r.db.Exec(query, noteID, userID)
+
+	return err
+}
+
+// GetMentions returns friends mentioned in the notes with their current
+// names, friends in the trash are left out.
+func (r *NotePostgres) GetMentions(noteIDs []uuid.UUID) ([]models.NoteMention, error) {
+	var mentions []models.NoteMention
+
+	query := fmt.Sprintf(`SELECT m.note_id, m.friend_id, f.first_name, f.last_name
+						FROM %s m
+						INNER JOIN %s f ON f.id = m.friend_id
+						WHERE m.note_id = ANY($1) AND f.deleted_at IS NULL
+						ORDER BY f.first_name, f.last_name, f.id`, noteMentionTable, friendTable)
+
+	err := r.db.Select(&mentions, query, pq.Array(noteIDs))
+
+	return mentions, err
+}
+
+func setNoteMentions(tx *sqlx.Tx, noteID uuid.UUID, mentionIDs []uuid.UUID) error {
+	queryDelete := fmt.Sprintf("DELETE FROM %s WHERE note_id = $1 AND NOT friend_id = ANY($2)", noteMentionTable)
+	if _, err := tx.Exec(queryDelete, noteID, pq.Array(mentionIDs)); err != nil {
+		return err
+	}
+
+	queryInsert := fmt.Sprintf(`INSERT INTO %s (note_id, friend_id) VALUES ($1, $2)
+						ON CONFLICT (note_id, friend_id) DO NOTHING`, noteMentionTable)
+	for _, friendID := range mentionIDs {
+		if _, err := tx.Exec(queryInsert, noteID, friendID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
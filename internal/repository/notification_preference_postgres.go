@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lunovoy/friendly/internal/models"
+)
+
+type NotificationPreferencePostgres struct {
+	db *sqlx.DB
+}
+
+func NewNotificationPreferencePostgres(db *sqlx.DB) *NotificationPreferencePostgres {
+	return &NotificationPreferencePostgres{
+		db: db,
+	}
+}
+
+func (r *NotificationPreferencePostgres) GetByUserID(userID uuid.UUID) (models.NotificationPreference, error) {
+	var preference models.NotificationPreference
+
+	query := fmt.Sprintf("SELECT * FROM %s WHERE user_id = $1", notificationPreferenceTable)
+
+	err := r.db.Get(&preference, query, userID)
+
+	return preference, err
+}
+
+func (r *NotificationPreferencePostgres) Save(userID uuid.UUID, preference models.NotificationPreference) error {
+	query := fmt.Sprintf(`INSERT INTO "%s" (channels, quiet_hours_start, quiet_hours_end, timezone, birthdays, events, digests, keep_in_touch, birthday_reminder_minutes, user_id)
+						VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+						ON CONFLICT (user_id) DO UPDATE SET
+							channels = EXCLUDED.channels,
+							quiet_hours_start = EXCLUDED.quiet_hours_start,
+							quiet_hours_end = EXCLUDED.quiet_hours_end,
+							timezone = EXCLUDED.timezone,
+							birthdays = EXCLUDED.birthdays,
+							events = EXCLUDED.events,
+							digests = EXCLUDED.digests,
+							keep_in_touch = EXCLUDED.keep_in_touch,
+							birthday_reminder_minutes = EXCLUDED.birthday_reminder_minutes`, notificationPreferenceTable)
+
+	_, err := r.db.Exec(query, preference.Channels, preference.QuietHoursStart, preference.QuietHoursEnd, preference.Timezone, preference.Birthdays, preference.Events, preference.Digests, preference.KeepInTouch, preference.BirthdayMinutes, userID)
+
+	return err
+}
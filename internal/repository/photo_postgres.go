@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lunovoy/friendly/internal/models"
+)
+
+type PhotoPostgres struct {
+	db *sqlx.DB
+}
+
+func NewPhotoPostgres(db *sqlx.DB) *PhotoPostgres {
+	return &PhotoPostgres{
+		db: db,
+	}
+}
+
+// Create puts the photo at the end of the friend gallery.
+func (r *PhotoPostgres) Create(userID uuid.UUID, photo models.Photo) (uuid.UUID, error) {
+	var photoID uuid.UUID
+	query := fmt.Sprintf(`INSERT INTO "%s" (image_id, caption, taken_at, position, friend_id, event_id, user_id)
+						VALUES ($1, $2, $3, (SELECT COALESCE(MAX(position) + 1, 0) FROM %s WHERE friend_id = $4), $4, $5, $6)
+						RETURNING id`, photoTable, photoTable)
+
+	row := r.db.QueryRow(query, photo.ImageID, photo.Caption, photo.TakenAt, photo.FriendID, photo.EventID, userID)
+	if err := row.Scan(&photoID); err != nil {
+		return uuid.Nil, err
+	}
+
+	return photoID, nil
+}
+
+func (r *PhotoPostgres) GetAllByFriendID(userID, friendID uuid.UUID) ([]models.Photo, error) {
+	var photos []models.Photo
+
+	query := fmt.Sprintf(`SELECT p.*, COALESCE(p.image_id = f.image_id, false) AS is_avatar FROM %s p
+						INNER JOIN %s f ON f.id = p.friend_id
+						WHERE p.friend_id = $1 AND p.user_id = $2
+						ORDER BY p.position, p.created_at, p.id`, photoTable, friendTable)
+
+	err := r.db.Select(&photos, query, friendID, userID)
+
+	return photos, err
+}
+
+// GetAllByEventID returns photos of the event whose friend is not in the
+// trash, in the order they were taken.
+func (r *PhotoPostgres) GetAllByEventID(userID, eventID uuid.UUID) ([]models.Photo, error) {
+	var photos []models.Photo
+
+	query := fmt.Sprintf(`SELECT p.*, COALESCE(p.image_id = f.image_id, false) AS is_avatar FROM %s p
+						INNER JOIN %s f ON f.id = p.friend_id
+						WHERE p.event_id = $1 AND p.user_id = $2 AND f.deleted_at IS NULL
+						ORDER BY p.taken_at NULLS LAST, p.created_at, p.id`, photoTable, friendTable)
+
+	err := r.db.Select(&photos, query, eventID, userID)
+
+	return photos, err
+}
+
+func (r *PhotoPostgres) GetByID(userID, friendID, photoID uuid.UUID) (models.Photo, error) {
+	var photo models.Photo
+
+	query := fmt.Sprintf(`SELECT p.*, COALESCE(p.image_id = f.image_id, false) AS is_avatar FROM %s p
+						INNER JOIN %s f ON f.id = p.friend_id
+						WHERE p.id = $1 AND p.friend_id = $2 AND p.user_id = $3`, photoTable, friendTable)
+
+	err := r.db.Get(&photo, query, photoID, friendID, userID)
+
+	return photo, err
+}
+
+func (r *PhotoPostgres) Update(userID, photoID uuid.UUID, photo models.Photo) error {
+	query := fmt.Sprintf("UPDATE %s SET caption = $1, taken_at = $2, event_id = $3 WHERE id = $4 AND user_id = $5", photoTable)
+
+	_, err := r.db.Exec(query, photo.Caption, photo.TakenAt, photo.EventID, photoID, userID)
+
+	return err
+}
+
+func (r *PhotoPostgres) DeleteByID(userID, photoID uuid.UUID) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE id = $1 AND user_id = $2", photoTable)
+
+	_, err := r.db.Exec(query, photoID, userID)
+
+	return err
+}
+
+// Reorder sets positions of the friend photos to their index in photoIDs.
+func (r *PhotoPostgres) Reorder(userID, friendID uuid.UUID, photoIDs []uuid.UUID) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := fmt.Sprintf("UPDATE %s SET position = $1 WHERE id = $2 AND friend_id = $3 AND user_id = $4", photoTable)
+	for i, photoID := range photoIDs {
+		if _, err := tx.Exec(query, i, photoID, friendID, userID); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/lunovoy/friendly/internal/models"
+)
+
+func TestPhotoIsAvatar(t *testing.T) {
+	db := newTestDB(t)
+	photos := NewPhotoPostgres(db)
+
+	userID, err := NewAuthPostgres(db).CreateUser(models.User{Mail: "user@example.com", Password: "hash", Salt: "salt"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	firstName := "Иван"
+	friend, err := NewFriendPostgres(db).Create(userID, models.UpdateFriendWorkInfoInput{
+		Friend: &models.UpdateFriendInput{FirstName: &firstName},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	eventID, err := NewEventPostgres(db).Create(userID, models.Event{Title: "Поход", Frequency: "once"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	imageID := uuid.New()
+	if err := NewImagePostgres(db).Create(userID, models.Image{ID: imageID, MimeType: "image/png", Width: 1, Height: 1, Size: 1}); err != nil {
+		t.Fatal(err)
+	}
+	photoID, err := photos.Create(userID, models.Photo{
+		ImageID:  imageID,
+		FriendID: friend.FriendID,
+		EventID:  uuid.NullUUID{UUID: eventID, Valid: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	isAvatar := func() []bool {
+		t.Helper()
+		var got []bool
+		byFriend, err := photos.GetAllByFriendID(userID, friend.FriendID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		byEvent, err := photos.GetAllByEventID(userID, eventID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		photo, err := photos.GetByID(userID, friend.FriendID, photoID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, photo := range append(append(byFriend, byEvent...), photo) {
+			got = append(got, photo.IsAvatar)
+		}
+		return got
+	}
+
+	// friend image is NULL
+	for _, got := range isAvatar() {
+		if got {
+			t.Errorf("photo of friend without image is avatar")
+		}
+	}
+
+	if _, err := db.Exec(fmt.Sprintf("UPDATE %s SET image_id = $1 WHERE id = $2", friendTable), imageID, friend.FriendID); err != nil {
+		t.Fatal(err)
+	}
+	for _, got := range isAvatar() {
+		if !got {
+			t.Errorf("photo of friend image is not avatar")
+		}
+	}
+}
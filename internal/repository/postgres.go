@@ -7,20 +7,38 @@ import (
 )
 
 const (
-	userTable                        = "user"
-	tagTable                         = "tag"
-	friendlistTable                  = "friendlist"
-	friendlistsTagsTable             = "friendlists_tags"
-	friendlistsFriendsTable          = "friendlists_friends"
-	friendTable                      = "friend"
-	friendsTagsTable                 = "friends_tags"
-	workInfoTable                    = "work_info"
-	additionalInfoFieldTable         = "additional_info_field"
-	friendsAdditionalInfoFieldsTable = "friends_additional_info_fields"
-	additionalInfoFieldTextTable     = "additional_info_field_text"
-	eventTable                       = "event"
-	friendsEventsTable               = "friends_events"
-	reminderTable                    = "reminder"
+	userTable                            = "user"
+	tagTable                             = "tag"
+	friendlistTable                      = "friendlist"
+	friendlistsTagsTable                 = "friendlists_tags"
+	friendlistsFriendsTable              = "friendlists_friends"
+	friendTable                          = "friend"
+	friendsTagsTable                     = "friends_tags"
+	workInfoTable                        = "work_info"
+	additionalInfoFieldTable             = "additional_info_field"
+	friendsAdditionalInfoFieldsTable     = "friends_additional_info_fields"
+	friendlistsAdditionalInfoFieldsTable = "friendlists_additional_info_fields"
+	additionalInfoFieldTextTable         = "additional_info_field_text"
+	eventTable                           = "event"
+	friendsEventsTable                   = "friends_events"
+	reminderTable                        = "reminder"
+	reminderDeliveryTable                = "reminder_delivery"
+	tgChatTable                          = "tg_chat"
+	digestSettingTable                   = "digest_setting"
+	notificationPreferenceTable          = "notification_preference"
+	friendDateTable                      = "friend_date"
+	interactionTable                     = "interaction"
+	friendChangeTable                    = "friend_change"
+	contactPointTable                    = "contact_point"
+	employmentTable                      = "employment"
+	friendRelationshipTable              = "friend_relationship"
+	giftIdeaTable                        = "gift_idea"
+	noteTable                            = "note"
+	noteMentionTable                     = "note_mention"
+	attachmentTable                      = "attachment"
+	photoTable                           = "photo"
+	imageTable                           = "image"
+	friendlistShareTable                 = "friendlist_share"
 )
 
 type Config struct {
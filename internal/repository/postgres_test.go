@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// newTestDB migrates a new schema in the database of TEST_DATABASE_URL and
+// returns a connection using it, the schema is dropped when the test ends.
+// Tests needing the database are skipped when the variable is not set.
+func newTestDB(t *testing.T) *sqlx.DB {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	admin, err := sqlx.Connect("postgres", dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { admin.Close() })
+
+	// extensions are shared by the database, so they are kept in public
+	// instead of the schema dropped after the test
+	schema := "test_" + strings.ReplaceAll(uuid.NewString(), "-", "")
+	for _, query := range []string{
+		`CREATE EXTENSION IF NOT EXISTS "uuid-ossp" SCHEMA public`,
+		`CREATE EXTENSION IF NOT EXISTS "pg_trgm" SCHEMA public`,
+		fmt.Sprintf(`CREATE SCHEMA "%s"`, schema),
+	} {
+		if _, err := admin.Exec(query); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Cleanup(func() {
+		if _, err := admin.Exec(fmt.Sprintf(`DROP SCHEMA "%s" CASCADE`, schema)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	u, err := url.Parse(dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	params := u.Query()
+	params.Set("search_path", schema+",public")
+	u.RawQuery = params.Encode()
+
+	db, err := sqlx.Connect("postgres", u.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	migrations, err := filepath.Glob("../../db/migrations/*.up.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(migrations)
+	for _, migration := range migrations {
+		query, err := os.ReadFile(migration)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := db.Exec(string(query)); err != nil {
+			t.Fatalf("%s: %s", filepath.Base(migration), err)
+		}
+	}
+
+	return db
+}
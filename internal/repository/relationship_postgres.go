@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lunovoy/friendly/internal/models"
+)
+
+type RelationshipPostgres struct {
+	db *sqlx.DB
+}
+
+func NewRelationshipPostgres(db *sqlx.DB) *RelationshipPostgres {
+	return &RelationshipPostgres{
+		db: db,
+	}
+}
+
+func (r *RelationshipPostgres) Create(userID uuid.UUID, relationship models.Relationship) (uuid.UUID, error) {
+	var relationshipID uuid.UUID
+	query := fmt.Sprintf(`INSERT INTO "%s" (kind, friend_id, related_friend_id, user_id) VALUES ($1, $2, $3, $4) RETURNING id`, friendRelationshipTable)
+
+	row := r.db.QueryRow(query, relationship.Kind, relationship.FriendID, relationship.RelatedFriendID, userID)
+	if err := row.Scan(&relationshipID); err != nil {
+		return uuid.Nil, err
+	}
+
+	return relationshipID, nil
+}
+
+func (r *RelationshipPostgres) GetAll(userID uuid.UUID) ([]models.Relationship, error) {
+	var relationships []models.Relationship
+
+	query := fmt.Sprintf(`SELECT r.* FROM %s r
+						INNER JOIN %s f ON f.id = r.friend_id
+						INNER JOIN %s rf ON rf.id = r.related_friend_id
+						WHERE r.user_id = $1 AND f.deleted_at IS NULL AND rf.deleted_at IS NULL`, friendRelationshipTable, friendTable, friendTable)
+
+	err := r.db.Select(&relationships, query, userID)
+
+	return relationships, err
+}
+
+func (r *RelationshipPostgres) GetAllByFriendID(userID, friendID uuid.UUID) ([]models.Relationship, error) {
+	var relationships []models.Relationship
+
+	query := fmt.Sprintf("SELECT * FROM %s WHERE (friend_id = $1 OR related_friend_id = $1) AND user_id = $2 ORDER BY kind", friendRelationshipTable)
+
+	err := r.db.Select(&relationships, query, friendID, userID)
+
+	return relationships, err
+}
+
+func (r *RelationshipPostgres) GetByID(userID, friendID, relationshipID uuid.UUID) (models.Relationship, error) {
+	var relationship models.Relationship
+
+	query := fmt.Sprintf("SELECT * FROM %s WHERE id = $1 AND (friend_id = $2 OR related_friend_id = $2) AND user_id = $3", friendRelationshipTable)
+
+	err := r.db.Get(&relationship, query, relationshipID, friendID, userID)
+
+	return relationship, err
+}
+
+func (r *RelationshipPostgres) Update(userID, relationshipID uuid.UUID, relationship models.Relationship) error {
+	query := fmt.Sprintf("UPDATE %s SET kind = $1, friend_id = $2, related_friend_id = $3 WHERE id = $4 AND user_id = $5", friendRelationshipTable)
+
+	_, err := r.db.Exec(query, relationship.Kind, relationship.FriendID, relationship.RelatedFriendID, relationshipID, userID)
+
+	return err
+}
+
+func (r *RelationshipPostgres) DeleteByID(userID, relationshipID uuid.UUID) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE id = $1 AND user_id = $2", friendRelationshipTable)
+
+	_, err := r.db.Exec(query, relationshipID, userID)
+
+	return err
+}
@@ -0,0 +1,225 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lunovoy/friendly/internal/models"
+)
+
+type ReminderDeliveryPostgres struct {
+	db *sqlx.DB
+}
+
+func NewReminderDeliveryPostgres(db *sqlx.DB) *ReminderDeliveryPostgres {
+	return &ReminderDeliveryPostgres{
+		db: db,
+	}
+}
+
+func (r *ReminderDeliveryPostgres) CreatePending(delivery models.ReminderDelivery) (bool, error) {
+	conflictTarget := "(reminder_id, occurrence_date)"
+	switch {
+	case delivery.FriendDateID.Valid:
+		conflictTarget = "(friend_date_id, occurrence_date, offset_minutes) WHERE friend_date_id IS NOT NULL"
+	case delivery.FriendID.Valid:
+		conflictTarget = "(kind, friend_id, occurrence_date, offset_minutes) WHERE friend_id IS NOT NULL"
+	}
+
+	query := fmt.Sprintf(`INSERT INTO "%s" (kind, reminder_id, event_id, friend_id, friend_date_id, offset_minutes, occurrence_date, notify_at, status, user_id)
+						VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+						ON CONFLICT %s DO NOTHING`, reminderDeliveryTable, conflictTarget)
+
+	result, err := r.db.Exec(query, delivery.Kind, delivery.ReminderID, delivery.EventID, delivery.FriendID, delivery.FriendDateID, delivery.OffsetMinutes, delivery.OccurrenceDate, delivery.NotifyAt, models.DeliveryStatusPending, delivery.UserID)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected != 0, nil
+}
+
+// GetDue claims the due deliveries until claimUntil, so several dispatchers
+// never send the same delivery twice. A claim left by a crashed dispatcher
+// expires and the delivery is picked up again.
+func (r *ReminderDeliveryPostgres) GetDue(now, claimUntil time.Time) ([]models.ReminderDeliveryWithSource, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var deliveries []models.ReminderDelivery
+	// Reminders of trashed events and friends wait until they are restored or purged
+	queryDeliveries := fmt.Sprintf(`WITH claimed AS (
+										UPDATE %[1]s SET claimed_until = $4
+										WHERE id IN (
+											SELECT d.id FROM %[1]s d
+											LEFT JOIN %[2]s e ON e.id = d.event_id
+											LEFT JOIN %[3]s fd ON fd.id = d.friend_date_id
+											LEFT JOIN %[4]s f ON f.id = COALESCE(d.friend_id, fd.friend_id)
+											WHERE d.status IN ($1, $2) AND d.notify_at <= $3
+												AND (d.claimed_until IS NULL OR d.claimed_until <= $3)
+												AND e.deleted_at IS NULL AND f.deleted_at IS NULL
+											FOR UPDATE OF d SKIP LOCKED
+										)
+										RETURNING *
+									)
+									SELECT * FROM claimed ORDER BY notify_at`, reminderDeliveryTable, eventTable, friendDateTable, friendTable)
+	if err := tx.Select(&deliveries, queryDeliveries, models.DeliveryStatusPending, models.DeliveryStatusSnoozed, now, claimUntil); err != nil {
+		return nil, err
+	}
+
+	queryEvent := fmt.Sprintf("SELECT * FROM %s WHERE id = $1", eventTable)
+	eventStmt, err := tx.Preparex(queryEvent)
+	if err != nil {
+		return nil, err
+	}
+	defer eventStmt.Close()
+
+	queryFriend := fmt.Sprintf("SELECT * FROM %s WHERE id = $1", friendTable)
+	friendStmt, err := tx.Preparex(queryFriend)
+	if err != nil {
+		return nil, err
+	}
+	defer friendStmt.Close()
+
+	queryFriendDate := fmt.Sprintf("SELECT * FROM %s WHERE id = $1", friendDateTable)
+	friendDateStmt, err := tx.Preparex(queryFriendDate)
+	if err != nil {
+		return nil, err
+	}
+	defer friendDateStmt.Close()
+
+	dueDeliveries := make([]models.ReminderDeliveryWithSource, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		dueDelivery := models.ReminderDeliveryWithSource{
+			Delivery: delivery,
+		}
+		if delivery.EventID.Valid {
+			var event models.Event
+			if err := eventStmt.Get(&event, delivery.EventID.UUID); err != nil {
+				return nil, err
+			}
+			dueDelivery.Event = &event
+		}
+		friendID := delivery.FriendID
+		if delivery.FriendDateID.Valid {
+			var friendDate models.FriendDate
+			if err := friendDateStmt.Get(&friendDate, delivery.FriendDateID.UUID); err != nil {
+				return nil, err
+			}
+			dueDelivery.FriendDate = &friendDate
+			friendID = uuid.NullUUID{UUID: friendDate.FriendID, Valid: true}
+		}
+		if friendID.Valid {
+			var friend models.Friend
+			if err := friendStmt.Get(&friend, friendID.UUID); err != nil {
+				return nil, err
+			}
+			dueDelivery.Friend = &friend
+		}
+		dueDeliveries = append(dueDeliveries, dueDelivery)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return dueDeliveries, nil
+}
+
+func (r *ReminderDeliveryPostgres) MarkSent(deliveryID uuid.UUID, sentAt time.Time) error {
+	query := fmt.Sprintf("UPDATE %s SET status = $1, sent_at = $2, attempts = attempts + 1, claimed_until = NULL, updated_at = now() WHERE id = $3", reminderDeliveryTable)
+
+	_, err := r.db.Exec(query, models.DeliveryStatusSent, sentAt, deliveryID)
+
+	return err
+}
+
+// Retry releases the claim after a failed send and keeps the delivery in its
+// status until notifyAt.
+func (r *ReminderDeliveryPostgres) Retry(deliveryID uuid.UUID, notifyAt time.Time) error {
+	query := fmt.Sprintf("UPDATE %s SET notify_at = $1, attempts = attempts + 1, claimed_until = NULL, updated_at = now() WHERE id = $2", reminderDeliveryTable)
+
+	_, err := r.db.Exec(query, notifyAt, deliveryID)
+
+	return err
+}
+
+func (r *ReminderDeliveryPostgres) MarkFailed(deliveryID uuid.UUID) error {
+	query := fmt.Sprintf("UPDATE %s SET status = $1, attempts = attempts + 1, claimed_until = NULL, updated_at = now() WHERE id = $2", reminderDeliveryTable)
+
+	_, err := r.db.Exec(query, models.DeliveryStatusFailed, deliveryID)
+
+	return err
+}
+
+func (r *ReminderDeliveryPostgres) GetAll(userID uuid.UUID, status string) ([]models.ReminderDelivery, error) {
+	var deliveries []models.ReminderDelivery
+
+	if status != "" {
+		query := fmt.Sprintf("SELECT * FROM %s WHERE user_id = $1 AND status = $2 ORDER BY notify_at DESC", reminderDeliveryTable)
+		err := r.db.Select(&deliveries, query, userID, status)
+		return deliveries, err
+	}
+
+	query := fmt.Sprintf("SELECT * FROM %s WHERE user_id = $1 ORDER BY notify_at DESC", reminderDeliveryTable)
+	err := r.db.Select(&deliveries, query, userID)
+
+	return deliveries, err
+}
+
+func (r *ReminderDeliveryPostgres) GetByID(userID, deliveryID uuid.UUID) (models.ReminderDelivery, error) {
+	var delivery models.ReminderDelivery
+
+	query := fmt.Sprintf("SELECT * FROM %s WHERE id = $1 AND user_id = $2", reminderDeliveryTable)
+
+	err := r.db.Get(&delivery, query, deliveryID, userID)
+
+	return delivery, err
+}
+
+func (r *ReminderDeliveryPostgres) UpdateStatus(userID, deliveryID uuid.UUID, status string, snoozedUntil sql.NullTime) error {
+	if snoozedUntil.Valid {
+		query := fmt.Sprintf("UPDATE %s SET status = $1, snoozed_until = $2, notify_at = $2, attempts = 0, updated_at = now() WHERE id = $3 AND user_id = $4", reminderDeliveryTable)
+		_, err := r.db.Exec(query, status, snoozedUntil, deliveryID, userID)
+		return err
+	}
+
+	query := fmt.Sprintf("UPDATE %s SET status = $1, updated_at = now() WHERE id = $2 AND user_id = $3", reminderDeliveryTable)
+	_, err := r.db.Exec(query, status, deliveryID, userID)
+
+	return err
+}
+
+func (r *ReminderDeliveryPostgres) Defer(deliveryID uuid.UUID, until time.Time) error {
+	query := fmt.Sprintf("UPDATE %s SET notify_at = $1, claimed_until = NULL, updated_at = now() WHERE id = $2", reminderDeliveryTable)
+
+	_, err := r.db.Exec(query, until, deliveryID)
+
+	return err
+}
+
+func (r *ReminderDeliveryPostgres) DeletePendingByFriendID(friendID uuid.UUID, kind string) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE friend_id = $1 AND kind = $2 AND status IN ($3, $4)", reminderDeliveryTable)
+
+	_, err := r.db.Exec(query, friendID, kind, models.DeliveryStatusPending, models.DeliveryStatusSnoozed)
+
+	return err
+}
+
+func (r *ReminderDeliveryPostgres) DeletePendingByFriendDateID(dateID uuid.UUID) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE friend_date_id = $1 AND status IN ($2, $3)", reminderDeliveryTable)
+
+	_, err := r.db.Exec(query, dateID, models.DeliveryStatusPending, models.DeliveryStatusSnoozed)
+
+	return err
+}
@@ -57,7 +57,9 @@ func (r *ReminderPostgres) GetAll(userID uuid.UUID) ([]models.Reminder, error) {
 
 	var reminders []models.Reminder
 
-	query := fmt.Sprintf("SELECT * FROM %s WHERE user_id = $1", reminderTable)
+	query := fmt.Sprintf(`SELECT r.* FROM %s r
+						INNER JOIN %s e ON e.id = r.event_id
+						WHERE r.user_id = $1 AND e.deleted_at IS NULL`, reminderTable, eventTable)
 
 	err := r.db.Select(&reminders, query, userID)
 
@@ -100,3 +102,51 @@ func (r *ReminderPostgres) DeleteByID(userID, reminderID uuid.UUID) error {
 
 	return err
 }
+
+func (r *ReminderPostgres) GetAllActive() ([]models.ReminderWithEvent, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var reminders []models.Reminder
+	queryReminders := fmt.Sprintf(`SELECT r.*
+								FROM %s r
+								INNER JOIN %s e ON e.id = r.event_id
+								WHERE r.is_active AND e.is_active AND e.deleted_at IS NULL`, reminderTable, eventTable)
+	if err := tx.Select(&reminders, queryReminders); err != nil {
+		return nil, err
+	}
+
+	var events []models.Event
+	queryEvents := fmt.Sprintf(`SELECT e.*
+								FROM %s e
+								WHERE e.is_active AND e.deleted_at IS NULL AND EXISTS(SELECT 1 FROM %s r WHERE r.event_id = e.id AND r.is_active)`, eventTable, reminderTable)
+	if err := tx.Select(&events, queryEvents); err != nil {
+		return nil, err
+	}
+
+	eventsByID := make(map[uuid.UUID]models.Event, len(events))
+	for _, event := range events {
+		eventsByID[event.ID] = event
+	}
+
+	remindersWithEvents := make([]models.ReminderWithEvent, 0, len(reminders))
+	for _, reminder := range reminders {
+		event, ok := eventsByID[reminder.EventID]
+		if !ok {
+			continue
+		}
+		remindersWithEvents = append(remindersWithEvents, models.ReminderWithEvent{
+			Reminder: reminder,
+			Event:    event,
+		})
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return remindersWithEvents, nil
+}
@@ -1,6 +1,9 @@
 package repository
 
 import (
+	"database/sql"
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 	"github.com/lunovoy/friendly/internal/models"
@@ -23,6 +26,7 @@ type Tag interface {
 	GetByID(userID, tagID uuid.UUID) (models.Tag, error)
 	Update(userID, tagID uuid.UUID, tag models.Tag) error
 	DeleteByID(userID, tagID uuid.UUID) error
+	List(userID uuid.UUID, params models.ListParams) ([]models.Tag, string, error)
 }
 
 type Friendlist interface {
@@ -40,6 +44,11 @@ type Friendlist interface {
 	AddFriendToFriendlist(friendlistID, friendID uuid.UUID) error
 	DeleteFriendFromFriendlist(friendlistID, friendID uuid.UUID) error
 	DeleteByID(userID, friendlistID uuid.UUID) error
+	GetTemplateFields(friendlistID uuid.UUID) ([]models.AdditionalInfoField, error)
+	GetAllTemplateFields(userID uuid.UUID) ([]models.FriendlistTemplateField, error)
+	AddFieldToTemplate(friendlistID, fieldID uuid.UUID) error
+	DeleteFieldFromTemplate(friendlistID, fieldID uuid.UUID) error
+	List(userID uuid.UUID, filter models.FriendlistFilter, params models.ListParams) ([]models.Friendlist, string, error)
 }
 
 type Friend interface {
@@ -51,6 +60,12 @@ type Friend interface {
 	AddTagToFriend(friendID, tagID uuid.UUID) error
 	AddTagsToFriend(userID, friendID uuid.UUID, tagIDs []models.AdditionTag) ([]uuid.UUID, error)
 	DeleteTagFromFriend(friendID, tagID uuid.UUID) error
+	GetAllTrackingBirthdays() ([]models.Friend, error)
+	MarkContacted(userID, friendID uuid.UUID, at time.Time) error
+	GetContactCadences(userID uuid.UUID) ([]models.ContactCadence, error)
+	GetContactCadenceUserIDs() ([]uuid.UUID, error)
+	List(userID uuid.UUID, filter models.FriendFilter, params models.ListParams) ([]models.FriendWorkInfoTags, string, error)
+	Merge(userID, targetID, sourceID uuid.UUID, merged models.UpdateFriendWorkInfoInput, sourceFieldIDs []uuid.UUID) error
 }
 
 type Event interface {
@@ -65,6 +80,7 @@ type Event interface {
 	Update(userID, eventID uuid.UUID, event models.EventUpdate) error
 	UpdateFull(userID, eventID uuid.UUID, event models.EventFullUpdate) error
 	DeleteByID(userID, eventID uuid.UUID) error
+	List(userID uuid.UUID, filter models.EventFilter, params models.ListParams) ([]models.Event, string, error)
 }
 
 type Reminder interface {
@@ -75,9 +91,162 @@ type Reminder interface {
 	GetByID(userID, reminderID uuid.UUID) (models.Reminder, error)
 	Update(userID, reminderID uuid.UUID, reminder models.ReminderUpdate) error
 	DeleteByID(userID, reminderID uuid.UUID) error
+	GetAllActive() ([]models.ReminderWithEvent, error)
+}
+
+type ReminderDelivery interface {
+	CreatePending(delivery models.ReminderDelivery) (bool, error)
+	GetDue(now, claimUntil time.Time) ([]models.ReminderDeliveryWithSource, error)
+	MarkSent(deliveryID uuid.UUID, sentAt time.Time) error
+	Retry(deliveryID uuid.UUID, notifyAt time.Time) error
+	MarkFailed(deliveryID uuid.UUID) error
+	GetAll(userID uuid.UUID, status string) ([]models.ReminderDelivery, error)
+	GetByID(userID, deliveryID uuid.UUID) (models.ReminderDelivery, error)
+	UpdateStatus(userID, deliveryID uuid.UUID, status string, snoozedUntil sql.NullTime) error
+	Defer(deliveryID uuid.UUID, until time.Time) error
+	DeletePendingByFriendID(friendID uuid.UUID, kind string) error
+	DeletePendingByFriendDateID(dateID uuid.UUID) error
+}
+
+type FriendDate interface {
+	Create(userID uuid.UUID, date models.FriendDate) (uuid.UUID, error)
+	GetAll(userID uuid.UUID) ([]models.FriendDate, error)
+	GetAllByFriendID(userID, friendID uuid.UUID) ([]models.FriendDate, error)
+	GetByID(userID, friendID, dateID uuid.UUID) (models.FriendDate, error)
+	Update(userID, dateID uuid.UUID, date models.FriendDate) error
+	DeleteByID(userID, dateID uuid.UUID) error
+	GetAllWithReminders() ([]models.FriendDate, error)
+}
+
+type GiftIdea interface {
+	Create(userID uuid.UUID, idea models.GiftIdea) (uuid.UUID, error)
+	GetAllByFriendID(userID, friendID uuid.UUID) ([]models.GiftIdea, error)
+	GetByID(userID, friendID, ideaID uuid.UUID) (models.GiftIdea, error)
+	Update(userID, ideaID uuid.UUID, idea models.GiftIdea) error
+	DeleteByID(userID, ideaID uuid.UUID) error
+	GetOpenByFriendIDs(friendIDs []uuid.UUID) ([]models.GiftIdea, error)
+}
+
+type Note interface {
+	Create(userID uuid.UUID, note models.Note, mentionIDs []uuid.UUID) (uuid.UUID, error)
+	GetAll(userID uuid.UUID, filter models.NoteFilter) ([]models.Note, error)
+	GetAllByFriendID(userID, friendID uuid.UUID) ([]models.Note, error)
+	GetByID(userID, noteID uuid.UUID) (models.Note, error)
+	Update(userID, noteID uuid.UUID, body string, mentionIDs []uuid.UUID) error
+	DeleteByID(userID, noteID uuid.UUID) error
+	GetMentions(noteIDs []uuid.UUID) ([]models.NoteMention, error)
+}
+
+type Attachment interface {
+	Create(userID uuid.UUID, attachment models.Attachment) (uuid.UUID, bool, error)
+	GetAll(userID uuid.UUID, filter models.AttachmentFilter) ([]models.Attachment, error)
+	GetByID(userID, attachmentID uuid.UUID) (models.Attachment, error)
+	DeleteByID(userID, attachmentID uuid.UUID) error
+	GetUsage(userID uuid.UUID) (models.StorageUsage, error)
+}
+
+type Photo interface {
+	Create(userID uuid.UUID, photo models.Photo) (uuid.UUID, error)
+	GetAllByFriendID(userID, friendID uuid.UUID) ([]models.Photo, error)
+	GetAllByEventID(userID, eventID uuid.UUID) ([]models.Photo, error)
+	GetByID(userID, friendID, photoID uuid.UUID) (models.Photo, error)
+	Update(userID, photoID uuid.UUID, photo models.Photo) error
+	DeleteByID(userID, photoID uuid.UUID) error
+	Reorder(userID, friendID uuid.UUID, photoIDs []uuid.UUID) error
+}
+
+type Image interface {
+	Create(userID uuid.UUID, image models.Image) error
+	GetByID(userID, imageID uuid.UUID) (models.Image, error)
+	GetShared(userID, imageID uuid.UUID) (models.Image, error)
+	DeleteByID(userID, imageID uuid.UUID) error
+	DeleteUnreferenced(imageIDs []uuid.UUID) ([]uuid.UUID, error)
+}
+
+type FriendlistShare interface {
+	Create(friendlistID, userID uuid.UUID) (uuid.UUID, error)
+	GetAllByFriendlistID(friendlistID uuid.UUID) ([]models.FriendlistShare, error)
+	Delete(friendlistID, userID uuid.UUID) error
+}
+
+type ContactPoint interface {
+	Create(userID uuid.UUID, contact models.ContactPoint) (uuid.UUID, error)
+	GetAllByFriendID(userID, friendID uuid.UUID) ([]models.ContactPoint, error)
+	GetAllByFriendIDs(friendIDs []uuid.UUID) ([]models.ContactPoint, error)
+	GetByID(userID, friendID, contactID uuid.UUID) (models.ContactPoint, error)
+	Update(userID, contactID uuid.UUID, contact models.ContactPoint) error
+	DeleteByID(userID, contactID uuid.UUID) error
+}
+
+type Employment interface {
+	Create(userID uuid.UUID, employment models.Employment) (uuid.UUID, error)
+	GetAllByFriendID(userID, friendID uuid.UUID) ([]models.Employment, error)
+	GetByID(userID, friendID, employmentID uuid.UUID) (models.Employment, error)
+	Update(userID, employmentID uuid.UUID, employment models.Employment) error
+	DeleteByID(userID, employmentID uuid.UUID) error
+	SyncWorkInfo(friendID uuid.UUID, current models.Employment) error
+}
+
+type Relationship interface {
+	Create(userID uuid.UUID, relationship models.Relationship) (uuid.UUID, error)
+	GetAll(userID uuid.UUID) ([]models.Relationship, error)
+	GetAllByFriendID(userID, friendID uuid.UUID) ([]models.Relationship, error)
+	GetByID(userID, friendID, relationshipID uuid.UUID) (models.Relationship, error)
+	Update(userID, relationshipID uuid.UUID, relationship models.Relationship) error
+	DeleteByID(userID, relationshipID uuid.UUID) error
+}
+
+type Search interface {
+	Search(userID uuid.UUID, query string, limit int) ([]models.SearchResult, error)
+}
+
+type Trash interface {
+	GetAll(userID uuid.UUID) ([]models.TrashItem, error)
+	Restore(userID uuid.UUID, kind string, id uuid.UUID) error
+	Purge(before time.Time) (int64, []uuid.UUID, error)
+}
+
+type Interaction interface {
+	Create(userID uuid.UUID, interaction models.Interaction) (uuid.UUID, error)
+	GetAllByFriendID(userID, friendID uuid.UUID) ([]models.Interaction, error)
+	GetByID(userID, friendID, interactionID uuid.UUID) (models.Interaction, error)
+	Update(userID, interactionID uuid.UUID, interaction models.Interaction) error
+	DeleteByID(userID, interactionID uuid.UUID) error
+	GetLastContacts(userID uuid.UUID, now time.Time) ([]models.LastInteraction, error)
+}
+
+type FriendChange interface {
+	CreateBulk(userID, friendID uuid.UUID, changes []models.FriendChange) error
+	GetAllByFriendID(userID, friendID uuid.UUID) ([]models.FriendChange, error)
+}
+
+type Digest interface {
+	GetByUserID(userID uuid.UUID) (models.DigestSetting, error)
+	Save(userID uuid.UUID, setting models.DigestSetting) error
+	GetAllActive() ([]models.DigestSetting, error)
+	MarkSent(settingID uuid.UUID, sentAt time.Time) error
+}
+
+type NotificationPreference interface {
+	GetByUserID(userID uuid.UUID) (models.NotificationPreference, error)
+	Save(userID uuid.UUID, preference models.NotificationPreference) error
+}
+
+type TgChat interface {
+	GetByUserID(userID uuid.UUID) (models.TgChat, error)
 }
 
 type AdditionalInfoField interface {
+	Create(userID uuid.UUID, field models.AdditionalInfoField) (uuid.UUID, error)
+	GetAll(userID uuid.UUID) ([]models.AdditionalInfoField, error)
+	GetByID(userID, fieldID uuid.UUID) (models.AdditionalInfoField, error)
+	Update(userID, fieldID uuid.UUID, field models.AdditionalInfoField) error
+	DeleteByID(userID, fieldID uuid.UUID) error
+	GetContentsByFriendIDs(friendIDs []uuid.UUID) ([]models.AdditionalInfoFieldContent, error)
+	AttachToFriend(friendID, fieldID uuid.UUID) error
+	SetValue(friendID, fieldID uuid.UUID, content string) error
+	ClearValue(friendID, fieldID uuid.UUID) error
+	DetachFromFriend(friendID, fieldID uuid.UUID) error
 }
 
 type Repository struct {
@@ -88,16 +257,54 @@ type Repository struct {
 	Friend
 	Event
 	Reminder
+	ReminderDelivery
+	FriendDate
+	GiftIdea
+	Note
+	Attachment
+	Photo
+	Image
+	FriendlistShare
+	ContactPoint
+	Employment
+	Relationship
+	Search
+	Trash
+	Interaction
+	FriendChange
+	AdditionalInfoField
+	Digest
+	NotificationPreference
+	TgChat
 }
 
 func NewRepository(db *sqlx.DB) *Repository {
 	return &Repository{
-		Authorization: NewAuthPostgres(db),
-		User:          NewUserPostgres(db),
-		Tag:           NewTagPostgres(db),
-		Friendlist:    NewFriendlistPostgres(db),
-		Friend:        NewFriendPostgres(db),
-		Event:         NewEventPostgres(db),
-		Reminder:      NewReminderPostgres(db),
+		Authorization:          NewAuthPostgres(db),
+		User:                   NewUserPostgres(db),
+		Tag:                    NewTagPostgres(db),
+		Friendlist:             NewFriendlistPostgres(db),
+		Friend:                 NewFriendPostgres(db),
+		Event:                  NewEventPostgres(db),
+		Reminder:               NewReminderPostgres(db),
+		ReminderDelivery:       NewReminderDeliveryPostgres(db),
+		FriendDate:             NewFriendDatePostgres(db),
+		GiftIdea:               NewGiftIdeaPostgres(db),
+		Note:                   NewNotePostgres(db),
+		Attachment:             NewAttachmentPostgres(db),
+		Photo:                  NewPhotoPostgres(db),
+		Image:                  NewImagePostgres(db),
+		FriendlistShare:        NewFriendlistSharePostgres(db),
+		ContactPoint:           NewContactPointPostgres(db),
+		Employment:             NewEmploymentPostgres(db),
+		Relationship:           NewRelationshipPostgres(db),
+		Search:                 NewSearchPostgres(db),
+		Trash:                  NewTrashPostgres(db),
+		Interaction:            NewInteractionPostgres(db),
+		FriendChange:           NewFriendChangePostgres(db),
+		AdditionalInfoField:    NewAdditionalInfoFieldPostgres(db),
+		Digest:                 NewDigestPostgres(db),
+		NotificationPreference: NewNotificationPreferencePostgres(db),
+		TgChat:                 NewTgChatPostgres(db),
 	}
 }
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lunovoy/friendly/internal/models"
+)
+
+// searchSimilarity is the pg_trgm word similarity from which a result
+// without lexeme match is still taken as a misspelled match.
+const searchSimilarity = 0.4
+
+const searchHeadlineOptions = "StartSel=<b>, StopSel=</b>, MaxWords=20, MinWords=5, MaxFragments=2"
+
+type SearchPostgres struct {
+	db *sqlx.DB
+}
+
+func NewSearchPostgres(db *sqlx.DB) *SearchPostgres {
+	return &SearchPostgres{
+		db: db,
+	}
+}
+
+// Search ranks friends by names, work info, tags, custom field values and
+// contact points, events by title and description, interactions by text and
+// notes by body.
+// Every source is matched through the lexeme and trigram indexes of its own
+// table and friends are then ranked by the document of all their sources. A
+// friend is a candidate when any lexeme of the query is found in one of the
+// sources, so words spread over several sources still match.
+func (r *SearchPostgres) Search(userID uuid.UUID, query string, limit int) ([]models.SearchResult, error) {
+	var results []models.SearchResult
+
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// <% compares against this threshold and unlike word_similarity() is served
+	// by the trigram indexes
+	if _, err := tx.Exec("SELECT set_config('pg_trgm.word_similarity_threshold', $1, true)", strconv.FormatFloat(searchSimilarity, 'f', -1, 64)); err != nil {
+		return nil, err
+	}
+
+	querySearch := fmt.Sprintf(`WITH q AS (
+							SELECT websearch_to_tsquery('russian', $2) AS query,
+								(SELECT string_agg('''' || replace(l, '''', '''''') || '''', ' | ')::tsquery
+									FROM unnest(tsvector_to_array(to_tsvector('russian', $2))) l) AS any_query
+						), friend_match AS (
+							SELECT f.id AS friend_id FROM "%[5]s" f, q
+							WHERE f.user_id = $1 AND (search_vector(f.first_name || ' ' || COALESCE(f.last_name, '')) @@ q.any_query
+								OR $2 <%% (f.first_name || ' ' || COALESCE(f.last_name, '')))
+							UNION
+							SELECT w.friend_id FROM %[6]s w, q
+							WHERE search_vector(COALESCE(w.company, '') || ' ' || COALESCE(w.position, '') || ' ' || COALESCE(w.profession, '') || ' ' || COALESCE(w.city, '') || ' ' || COALESCE(w.country, '')) @@ q.any_query
+								OR $2 <%% (COALESCE(w.company, '') || ' ' || COALESCE(w.position, '') || ' ' || COALESCE(w.profession, '') || ' ' || COALESCE(w.city, '') || ' ' || COALESCE(w.country, ''))
+							UNION
+							SELECT ft.friend_id FROM %[1]s ft
+							INNER JOIN %[2]s t ON t.id = ft.tag_id, q
+							WHERE t.user_id = $1 AND t.deleted_at IS NULL AND (search_vector(t.title) @@ q.any_query OR $2 <%% t.title)
+							UNION
+							SELECT x.friend_id FROM %[3]s x, q
+							WHERE search_vector(x.content) @@ q.any_query OR $2 <%% x.content
+							UNION
+							SELECT cp.friend_id FROM %[4]s cp, q
+							WHERE cp.user_id = $1 AND (search_vector(cp.label || ' ' || cp.value) @@ q.any_query OR $2 <%% (cp.label || ' ' || cp.value))
+						), friend_doc AS (
+							SELECT f.id, concat_ws(' ', f.first_name, f.last_name) AS title,
+								concat_ws(' ', f.first_name, f.last_name, w.company, w.position, w.profession, w.city, w.country,
+									(SELECT string_agg(t.title, ' ') FROM %[1]s ft INNER JOIN %[2]s t ON t.id = ft.tag_id WHERE ft.friend_id = f.id AND t.deleted_at IS NULL),
+									(SELECT string_agg(x.content, ' ') FROM %[3]s x WHERE x.friend_id = f.id),
+									(SELECT string_agg(cp.label || ' ' || cp.value, ' ') FROM %[4]s cp WHERE cp.friend_id = f.id)) AS body
+							FROM "%[5]s" f
+							INNER JOIN friend_match m ON m.friend_id = f.id
+							LEFT JOIN %[6]s w ON w.friend_id = f.id
+							WHERE f.user_id = $1 AND f.deleted_at IS NULL
+						), results AS (
+							SELECT '%[9]s' AS kind, d.id, NULL::uuid AS friend_id, d.title,
+								ts_headline('russian', d.body, q.query, $4) AS snippet,
+								ts_rank(search_vector(d.body), q.query, 32) + word_similarity($2, d.body) AS rank
+							FROM friend_doc d, q
+							WHERE search_vector(d.body) @@ q.query OR word_similarity($2, d.body) >= $3
+							UNION ALL
+							SELECT '%[10]s', e.id, NULL::uuid, e.title,
+								ts_headline('russian', e.title || ' ' || COALESCE(e.description, ''), q.query, $4),
+								ts_rank(search_vector(e.title || ' ' || COALESCE(e.description, '')), q.query, 32) + word_similarity($2, e.title)
+							FROM "%[7]s" e, q
+							WHERE e.user_id = $1 AND e.deleted_at IS NULL AND (search_vector(e.title || ' ' || COALESCE(e.description, '')) @@ q.query OR $2 <%% e.title)
+							UNION ALL
+							SELECT '%[11]s', i.id, i.friend_id, concat_ws(' ', f.first_name, f.last_name),
+								ts_headline('russian', i.text, q.query, $4),
+								ts_rank(search_vector(i.text), q.query, 32) + word_similarity($2, i.text)
+							FROM "%[8]s" i
+							INNER JOIN "%[5]s" f ON f.id = i.friend_id, q
+							WHERE i.user_id = $1 AND f.deleted_at IS NULL AND (search_vector(i.text) @@ q.query OR $2 <%% i.text)
+							UNION ALL
+							SELECT '%[12]s', n.id, n.friend_id, COALESCE(NULLIF(concat_ws(' ', f.first_name, f.last_name), ''), e.title, fl.title),
+								ts_headline('russian', n.body, q.query, $4),
+								ts_rank(search_vector(n.body), q.query, 32) + word_similarity($2, n.body)
+							FROM %[13]s n
+							LEFT JOIN "%[5]s" f ON f.id = n.friend_id
+							LEFT JOIN "%[7]s" e ON e.id = n.event_id
+							LEFT JOIN %[14]s fl ON fl.id = n.friendlist_id, q
+							WHERE n.user_id = $1 AND f.deleted_at IS NULL AND e.deleted_at IS NULL AND fl.deleted_at IS NULL
+								AND (search_vector(n.body) @@ q.query OR $2 <%% n.body)
+						)
+						SELECT * FROM results ORDER BY rank DESC LIMIT $5`,
+		friendsTagsTable, tagTable, additionalInfoFieldTextTable, contactPointTable, friendTable, workInfoTable, eventTable, interactionTable,
+		models.SearchKindFriend, models.SearchKindEvent, models.SearchKindInteraction,
+		models.SearchKindNote, noteTable, friendlistTable)
+
+	if err := tx.Select(&results, querySearch, userID, query, searchSimilarity, searchHeadlineOptions, limit); err != nil {
+		return nil, err
+	}
+
+	return results, tx.Commit()
+}
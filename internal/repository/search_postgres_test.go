@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/lunovoy/friendly/internal/models"
+)
+
+func TestSearch(t *testing.T) {
+	db := newTestDB(t)
+	search := NewSearchPostgres(db)
+
+	userID, err := NewAuthPostgres(db).CreateUser(models.User{Mail: "user@example.com", Password: "hash", Salt: "salt"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// without last name
+	firstName := "Иван"
+	friend, err := NewFriendPostgres(db).Create(userID, models.UpdateFriendWorkInfoInput{
+		Friend: &models.UpdateFriendInput{FirstName: &firstName},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	noteID, err := NewNotePostgres(db).Create(userID, models.Note{
+		Body:     "Любит горные велосипеды",
+		FriendID: uuid.NullUUID{UUID: friend.FriendID, Valid: true},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		want  models.SearchResult
+	}{
+		{
+			name:  "friend without last name",
+			query: "Иван",
+			want:  models.SearchResult{Kind: models.SearchKindFriend, ID: friend.FriendID, Title: firstName},
+		},
+		{
+			name:  "note",
+			query: "велосипед",
+			want: models.SearchResult{
+				Kind:     models.SearchKindNote,
+				ID:       noteID,
+				FriendID: uuid.NullUUID{UUID: friend.FriendID, Valid: true},
+				Title:    firstName,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := search.Search(userID, tt.query, 10)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, result := range results {
+				if result.Kind == tt.want.Kind && result.ID == tt.want.ID {
+					if result.FriendID != tt.want.FriendID || result.Title != tt.want.Title {
+						t.Errorf("Search(%q) = %+v, want %+v", tt.query, result, tt.want)
+					}
+					return
+				}
+			}
+			t.Errorf("Search(%q) = %+v, want %s %s", tt.query, results, tt.want.Kind, tt.want.ID)
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/google/uuid"
+	"github.com/huandu/go-sqlbuilder"
 	"github.com/jmoiron/sqlx"
 	"github.com/lunovoy/friendly/internal/models"
 )
@@ -28,7 +29,7 @@ func (r *TagPostgres) Create(userID uuid.UUID, tag models.Tag) (uuid.UUID, error
 	defer tx.Rollback()
 
 	var exists bool
-	queryCheck := fmt.Sprintf("SELECT EXISTS(SELECT 1 FROM %s WHERE title = $1 AND user_id = $2)", tagTable)
+	queryCheck := fmt.Sprintf("SELECT EXISTS(SELECT 1 FROM %s WHERE title = $1 AND user_id = $2 AND deleted_at IS NULL)", tagTable)
 
 	if err := tx.Get(&exists, queryCheck, tag.Title, userID); err != nil {
 		return uuid.Nil, err
@@ -36,7 +37,7 @@ func (r *TagPostgres) Create(userID uuid.UUID, tag models.Tag) (uuid.UUID, error
 
 	if exists {
 		var existsTag models.Tag
-		queryCheck = fmt.Sprintf("SELECT * FROM %s WHERE title = $1 AND user_id = $2", tagTable)
+		queryCheck = fmt.Sprintf("SELECT * FROM %s WHERE title = $1 AND user_id = $2 AND deleted_at IS NULL", tagTable)
 		if err := tx.Get(&existsTag, queryCheck, tag.Title, userID); err != nil {
 			return uuid.Nil, err
 		}
@@ -59,7 +60,7 @@ func (r *TagPostgres) Create(userID uuid.UUID, tag models.Tag) (uuid.UUID, error
 func (r *TagPostgres) GetAll(userID uuid.UUID) ([]models.Tag, error) {
 	var tags []models.Tag
 
-	query := fmt.Sprintf("SELECT id, title, user_id FROM %s where user_id = $1", tagTable)
+	query := fmt.Sprintf("SELECT id, title, user_id FROM %s where user_id = $1 AND deleted_at IS NULL ORDER BY title, id", tagTable)
 
 	err := r.db.Select(&tags, query, userID)
 
@@ -70,7 +71,7 @@ func (r *TagPostgres) GetAll(userID uuid.UUID) ([]models.Tag, error) {
 func (r *TagPostgres) GetByID(userID, tagID uuid.UUID) (models.Tag, error) {
 	var tag models.Tag
 
-	query := fmt.Sprintf("SELECT id, title, user_id FROM %s WHERE id = $1 AND user_id = $2", tagTable)
+	query := fmt.Sprintf("SELECT id, title, user_id FROM %s WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL", tagTable)
 
 	err := r.db.Get(&tag, query, tagID, userID)
 
@@ -86,9 +87,34 @@ func (r *TagPostgres) Update(userID, tagID uuid.UUID, tag models.Tag) error {
 }
 
 func (r *TagPostgres) DeleteByID(userID, tagID uuid.UUID) error {
-	query := fmt.Sprintf("DELETE FROM %s WHERE id=$1 AND user_id=$2", tagTable)
+	query := fmt.Sprintf("UPDATE %s SET deleted_at = now() WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL", tagTable)
 
 	_, err := r.db.Exec(query, tagID, userID)
 
 	return err
 }
+
+var tagSortKeys = map[string]sortKey[models.Tag]{
+	"title": {column: "t.title", cast: "text", value: func(t models.Tag) string { return t.Title }},
+}
+
+func (r *TagPostgres) List(userID uuid.UUID, params models.ListParams) ([]models.Tag, string, error) {
+	sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
+	sb.Select("t.id", "t.title", "t.user_id").From(fmt.Sprintf("%s t", tagTable))
+	sb.Where(sb.Equal("t.user_id", userID), sb.IsNull("t.deleted_at"))
+
+	key, err := applyListParams(sb, params, tagSortKeys, "title", "t.id")
+	if err != nil {
+		return nil, "", err
+	}
+
+	var tags []models.Tag
+	query, args := sb.Build()
+	if err := r.db.Select(&tags, query, args...); err != nil {
+		return nil, "", err
+	}
+
+	tags, next := cutListPage(tags, params.Limit, key, func(t models.Tag) uuid.UUID { return t.ID })
+
+	return tags, next, nil
+}
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lunovoy/friendly/internal/models"
+)
+
+type TgChatPostgres struct {
+	db *sqlx.DB
+}
+
+func NewTgChatPostgres(db *sqlx.DB) *TgChatPostgres {
+	return &TgChatPostgres{
+		db: db,
+	}
+}
+
+func (r *TgChatPostgres) GetByUserID(userID uuid.UUID) (models.TgChat, error) {
+	var chat models.TgChat
+
+	query := fmt.Sprintf("SELECT * FROM %s WHERE user_id = $1", tgChatTable)
+
+	err := r.db.Get(&chat, query, userID)
+
+	return chat, err
+}
@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lunovoy/friendly/internal/models"
+)
+
+var trashTables = map[string]string{
+	models.TrashKindFriend:     friendTable,
+	models.TrashKindEvent:      eventTable,
+	models.TrashKindFriendlist: friendlistTable,
+	models.TrashKindTag:        tagTable,
+}
+
+type TrashPostgres struct {
+	db *sqlx.DB
+}
+
+func NewTrashPostgres(db *sqlx.DB) *TrashPostgres {
+	return &TrashPostgres{
+		db: db,
+	}
+}
+
+func (r *TrashPostgres) GetAll(userID uuid.UUID) ([]models.TrashItem, error) {
+	var items []models.TrashItem
+
+	query := fmt.Sprintf(`SELECT '%[5]s' AS kind, id, trim(first_name || ' ' || last_name) AS title, deleted_at
+						FROM "%[1]s" WHERE user_id = $1 AND deleted_at IS NOT NULL
+						UNION ALL
+						SELECT '%[6]s', id, title, deleted_at FROM "%[2]s" WHERE user_id = $1 AND deleted_at IS NOT NULL
+						UNION ALL
+						SELECT '%[7]s', id, title, deleted_at FROM "%[3]s" WHERE user_id = $1 AND deleted_at IS NOT NULL
+						UNION ALL
+						SELECT '%[8]s', id, title, deleted_at FROM "%[4]s" WHERE user_id = $1 AND deleted_at IS NOT NULL
+						ORDER BY deleted_at DESC`,
+		friendTable, eventTable, friendlistTable, tagTable,
+		models.TrashKindFriend, models.TrashKindEvent, models.TrashKindFriendlist, models.TrashKindTag)
+
+	err := r.db.Select(&items, query, userID)
+
+	return items, err
+}
+
+// Restore clears the deletion mark, links to tags, friendlists and events are
+// kept while in trash and come back with it.
+func (r *TrashPostgres) Restore(userID uuid.UUID, kind string, id uuid.UUID) error {
+	table, ok := trashTables[kind]
+	if !ok {
+		return fmt.Errorf("kind %s can't be restored", kind)
+	}
+
+	query := fmt.Sprintf(`UPDATE "%s" SET deleted_at = NULL WHERE id = $1 AND user_id = $2 AND deleted_at IS NOT NULL`, table)
+
+	result, err := r.db.Exec(query, id, userID)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// Purge deletes rows trashed before the time for good with everything linked
+// to them, it returns the number of deleted rows and the images the deleted
+// friends, their gallery photos and friendlists referred to.
+func (r *TrashPostgres) Purge(before time.Time) (int64, []uuid.UUID, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return 0, nil, err
+	}
+	defer tx.Rollback()
+
+	var purged int64
+	var imageIDs []uuid.UUID
+	// photos go away with their friends by cascade
+	queryPhotos := fmt.Sprintf(`SELECT p.image_id FROM %s p
+								INNER JOIN "%s" f ON f.id = p.friend_id
+								WHERE f.deleted_at < $1`, photoTable, friendTable)
+	if err := tx.Select(&imageIDs, queryPhotos, before); err != nil {
+		return 0, nil, err
+	}
+	for _, table := range []string{friendTable, friendlistTable} {
+		var deleted []uuid.NullUUID
+		query := fmt.Sprintf(`DELETE FROM "%s" WHERE deleted_at < $1 RETURNING image_id`, table)
+		if err := tx.Select(&deleted, query, before); err != nil {
+			return 0, nil, err
+		}
+		purged += int64(len(deleted))
+		for _, imageID := range deleted {
+			if imageID.Valid && imageID.UUID != uuid.Nil {
+				imageIDs = append(imageIDs, imageID.UUID)
+			}
+		}
+	}
+	for _, table := range []string{eventTable, tagTable} {
+		query := fmt.Sprintf(`DELETE FROM "%s" WHERE deleted_at < $1`, table)
+		result, err := tx.Exec(query, before)
+		if err != nil {
+			return 0, nil, err
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return 0, nil, err
+		}
+		purged += affected
+	}
+
+	return purged, imageIDs, tx.Commit()
+}
@@ -0,0 +1,456 @@
+// Package rule parses and evaluates friendlist membership rules.
+//
+// Grammar, keywords are case insensitive:
+//
+//	expr      = and { "or" and }
+//	and       = unary { "and" unary }
+//	unary     = "not" unary | "(" expr ")" | condition
+//	condition = field ( op value | "in" "(" value { "," value } ")" | "contains" value | "within" number "days" )
+//	op        = "=" | "!=" | "<" | "<=" | ">" | ">="
+//
+// Values are bare words or quoted strings, e.g.
+//
+//	tag in (work, "old friends") and city = Moscow and birthday within 30 days
+package rule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type fieldKind int
+
+const (
+	textField fieldKind = iota
+	numberField
+	anniversaryField
+	pastDateField
+)
+
+var fields = map[string]fieldKind{
+	"tag":         textField,
+	"first_name":  textField,
+	"last_name":   textField,
+	"city":        textField,
+	"country":     textField,
+	"company":     textField,
+	"position":    textField,
+	"profession":  textField,
+	"language":    textField,
+	"nationality": textField,
+	"age":         numberField,
+	"dob_month":   numberField,
+	"birthday":    anniversaryField,
+	"contacted":   pastDateField,
+}
+
+// Subject is a friend as seen by rules. Text holds values of text fields,
+// a field may have several values like tags. Fields missing in Numbers and
+// Dates are unknown and match no condition.
+type Subject struct {
+	Text    map[string][]string
+	Numbers map[string]int
+	Dates   map[string]time.Time
+	Now     time.Time
+}
+
+type Rule struct {
+	root node
+}
+
+type node interface {
+	match(s Subject) bool
+}
+
+// Parse validates the expression, errors point to the offending token.
+func Parse(src string) (*Rule, error) {
+	tokens, err := tokenize(src)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if !p.done() {
+		return nil, p.errorf("unexpected %q", p.peek().text)
+	}
+
+	return &Rule{root: root}, nil
+}
+
+func (r *Rule) Match(s Subject) bool {
+	return r.root.match(s)
+}
+
+type orNode struct{ left, right node }
+
+func (n orNode) match(s Subject) bool { return n.left.match(s) || n.right.match(s) }
+
+type andNode struct{ left, right node }
+
+func (n andNode) match(s Subject) bool { return n.left.match(s) && n.right.match(s) }
+
+type notNode struct{ operand node }
+
+func (n notNode) match(s Subject) bool { return !n.operand.match(s) }
+
+type textCondition struct {
+	field    string
+	op       string
+	values   []string
+	contains bool
+}
+
+// match compares case insensitively, a multi-valued field matches when any
+// of its values does, != holds when none does.
+func (c textCondition) match(s Subject) bool {
+	any := false
+	for _, value := range s.Text[c.field] {
+		value = strings.ToLower(value)
+		for _, want := range c.values {
+			if c.contains && strings.Contains(value, want) || !c.contains && value == want {
+				any = true
+			}
+		}
+	}
+	if c.op == "!=" {
+		return !any
+	}
+	return any
+}
+
+type numberCondition struct {
+	field  string
+	op     string
+	values []int
+}
+
+func (c numberCondition) match(s Subject) bool {
+	value, ok := s.Numbers[c.field]
+	if !ok {
+		return false
+	}
+
+	switch c.op {
+	case "=", "in":
+		for _, want := range c.values {
+			if value == want {
+				return true
+			}
+		}
+		return false
+	case "!=":
+		return value != c.values[0]
+	case "<":
+		return value < c.values[0]
+	case "<=":
+		return value <= c.values[0]
+	case ">":
+		return value > c.values[0]
+	case ">=":
+		return value >= c.values[0]
+	}
+	return false
+}
+
+type withinCondition struct {
+	field string
+	kind  fieldKind
+	days  int
+}
+
+func (c withinCondition) match(s Subject) bool {
+	date, ok := s.Dates[c.field]
+	if !ok {
+		return false
+	}
+
+	today := time.Date(s.Now.Year(), s.Now.Month(), s.Now.Day(), 0, 0, 0, 0, s.Now.Location())
+	if c.kind == pastDateField {
+		return !date.After(s.Now) && !date.Before(today.AddDate(0, 0, -c.days))
+	}
+
+	// Feb 29 falls on Mar 1 in common years
+	next := time.Date(today.Year(), date.Month(), date.Day(), 0, 0, 0, 0, today.Location())
+	if next.Before(today) {
+		next = time.Date(today.Year()+1, date.Month(), date.Day(), 0, 0, 0, 0, today.Location())
+	}
+	return !next.After(today.AddDate(0, 0, c.days))
+}
+
+type tokenKind int
+
+const (
+	wordToken tokenKind = iota
+	stringToken
+	symbolToken
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func tokenize(src string) ([]token, error) {
+	var tokens []token
+	runes := []rune(src)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			i++
+		case r == '(' || r == ')' || r == ',' || r == '=':
+			tokens = append(tokens, token{kind: symbolToken, text: string(r), pos: i})
+			i++
+		case r == '!' || r == '<' || r == '>':
+			if i+1 < len(runes) && runes[i+1] == '=' {
+				tokens = append(tokens, token{kind: symbolToken, text: string(runes[i : i+2]), pos: i})
+				i += 2
+				continue
+			}
+			if r == '!' {
+				return nil, fmt.Errorf("unexpected ! at %d", i+1)
+			}
+			tokens = append(tokens, token{kind: symbolToken, text: string(r), pos: i})
+			i++
+		case r == '"' || r == '\'':
+			end := i + 1
+			for end < len(runes) && runes[end] != r {
+				end++
+			}
+			if end == len(runes) {
+				return nil, fmt.Errorf("unterminated string at %d", i+1)
+			}
+			tokens = append(tokens, token{kind: stringToken, text: string(runes[i+1 : end]), pos: i})
+			i = end + 1
+		default:
+			end := i
+			for end < len(runes) && !strings.ContainsRune(" \t\n\r(),=!<>\"'", runes[end]) {
+				end++
+			}
+			tokens = append(tokens, token{kind: wordToken, text: string(runes[i:end]), pos: i})
+			i = end
+		}
+	}
+
+	return tokens, nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *parser) peek() token {
+	if p.done() {
+		return token{}
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.peek()
+	p.pos++
+	return t
+}
+
+// keyword reports whether the next token is the bare word and consumes it.
+func (p *parser) keyword(word string) bool {
+	t := p.peek()
+	if !p.done() && t.kind == wordToken && strings.EqualFold(t.text, word) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) symbol(symbol string) bool {
+	t := p.peek()
+	if !p.done() && t.kind == symbolToken && t.text == symbol {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	if p.done() {
+		return fmt.Errorf(format+" at the end", args...)
+	}
+	return fmt.Errorf(format+" at %d", append(args, p.peek().pos+1)...)
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("or") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("and") {
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if p.keyword("not") {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{operand: operand}, nil
+	}
+	if p.symbol("(") {
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.symbol(")") {
+			return nil, p.errorf("expected )")
+		}
+		return expr, nil
+	}
+	return p.parseCondition()
+}
+
+func (p *parser) parseCondition() (node, error) {
+	if p.done() || p.peek().kind != wordToken {
+		return nil, p.errorf("expected field")
+	}
+	field := strings.ToLower(p.peek().text)
+	kind, ok := fields[field]
+	if !ok {
+		return nil, p.errorf("unknown field %s", field)
+	}
+	p.next()
+
+	switch kind {
+	case anniversaryField, pastDateField:
+		if !p.keyword("within") {
+			return nil, p.errorf("expected within after %s", field)
+		}
+		days, err := p.parseNumber()
+		if err != nil {
+			return nil, err
+		}
+		if days < 0 {
+			return nil, p.errorf("days must not be negative")
+		}
+		if !p.keyword("days") && !p.keyword("day") {
+			return nil, p.errorf("expected days")
+		}
+		return withinCondition{field: field, kind: kind, days: days}, nil
+	}
+
+	op, values, err := p.parseComparison(kind)
+	if err != nil {
+		return nil, err
+	}
+
+	if kind == textField {
+		condition := textCondition{field: field, op: op, contains: op == "contains"}
+		for _, value := range values {
+			condition.values = append(condition.values, strings.ToLower(value))
+		}
+		return condition, nil
+	}
+
+	condition := numberCondition{field: field, op: op}
+	for _, value := range values {
+		number, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be compared with a number", field)
+		}
+		condition.values = append(condition.values, number)
+	}
+	return condition, nil
+}
+
+func (p *parser) parseComparison(kind fieldKind) (string, []string, error) {
+	if p.keyword("in") {
+		if !p.symbol("(") {
+			return "", nil, p.errorf("expected ( after in")
+		}
+		var values []string
+		for {
+			value, err := p.parseValue()
+			if err != nil {
+				return "", nil, err
+			}
+			values = append(values, value)
+			if p.symbol(")") {
+				return "in", values, nil
+			}
+			if !p.symbol(",") {
+				return "", nil, p.errorf("expected , or )")
+			}
+		}
+	}
+
+	if kind == textField && p.keyword("contains") {
+		value, err := p.parseValue()
+		return "contains", []string{value}, err
+	}
+
+	t := p.peek()
+	if p.done() || t.kind != symbolToken || t.text == "(" || t.text == ")" || t.text == "," {
+		return "", nil, p.errorf("expected comparison")
+	}
+	if kind == textField && t.text != "=" && t.text != "!=" {
+		return "", nil, p.errorf("%s can't be used with text", t.text)
+	}
+	p.next()
+
+	value, err := p.parseValue()
+	return t.text, []string{value}, err
+}
+
+func (p *parser) parseValue() (string, error) {
+	t := p.peek()
+	if p.done() || t.kind == symbolToken {
+		return "", p.errorf("expected value")
+	}
+	p.next()
+	return t.text, nil
+}
+
+func (p *parser) parseNumber() (int, error) {
+	value, err := p.parseValue()
+	if err != nil {
+		return 0, err
+	}
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s is not a number", value)
+	}
+	return number, nil
+}
@@ -0,0 +1,158 @@
+package rule
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		src     string
+		wantErr string
+	}{
+		{"", "expected field at the end"},
+		{"city", "expected comparison at the end"},
+		{"city < Moscow", "< can't be used with text at 6"},
+		{"height = 3", "unknown field height at 1"},
+		{"age = ten", "age must be compared with a number"},
+		{"city = 'Moscow", "unterminated string at 8"},
+		{"city ! Moscow", "unexpected ! at 6"},
+		{"(city = Moscow", "expected ) at the end"},
+		{"city = Moscow Berlin", `unexpected "Berlin" at 15`},
+		{"tag in (work, family", "expected , or ) at the end"},
+		{"tag in work", "expected ( after in at 8"},
+		{"birthday in 3 days", "expected within after birthday at 10"},
+		{"birthday within soon", "soon is not a number"},
+		{"birthday within -1 days", "days must not be negative"},
+		{"contacted within 3 weeks", "expected days at 20"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.src, func(t *testing.T) {
+			_, err := Parse(tt.src)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Parse() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	subject := Subject{
+		Text: map[string][]string{
+			"tag":        {"Work", "Old Friends"},
+			"city":       {"Moscow"},
+			"first_name": {"Иван"},
+			"country":    {""},
+		},
+		Numbers: map[string]int{"age": 34, "dob_month": 3},
+		Dates: map[string]time.Time{
+			"birthday":  time.Date(1990, time.March, 20, 0, 0, 0, 0, time.UTC),
+			"contacted": time.Date(2024, time.March, 1, 18, 0, 0, 0, time.UTC),
+		},
+		Now: time.Date(2024, time.March, 10, 12, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		src  string
+		want bool
+	}{
+		{"city = moscow", true},
+		{`CITY = 'MOSCOW' AND tag in (work, "old friends")`, true},
+		{"tag != work", false},
+		{"tag != family", true},
+		{"first_name contains ВА", true},
+		{"country = Russia", false},
+		{"country != Russia", true},
+		{"age >= 30 and age < 40", true},
+		{"age <= 33 or age > 34", false},
+		{"age != 34", false},
+		{"dob_month in (1, 2)", false},
+		{"birthday within 10 days", true},
+		{"birthday within 9 days", false},
+		{"contacted within 9 days", true},
+		{"contacted within 8 days", false},
+		{"not (city = Moscow) or age > 40", false},
+		{"city = Moscow or age > 40 and tag = family", true},
+		{"not not city = Moscow", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.src, func(t *testing.T) {
+			rule, err := Parse(tt.src)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := rule.Match(subject); got != tt.want {
+				t.Errorf("Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchUnknownValues(t *testing.T) {
+	subject := Subject{Now: time.Date(2024, time.March, 10, 12, 0, 0, 0, time.UTC)}
+
+	for _, src := range []string{"age > 0", "age != 30", "birthday within 365 days", "tag = work"} {
+		rule, err := Parse(src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rule.Match(subject) {
+			t.Errorf("%s matches a subject without values", src)
+		}
+	}
+}
+
+func TestMatchBirthdayWithin(t *testing.T) {
+	tests := []struct {
+		name     string
+		birthday time.Time
+		now      time.Time
+		days     string
+		want     bool
+	}{
+		{
+			name:     "next year",
+			birthday: time.Date(1990, time.January, 5, 0, 0, 0, 0, time.UTC),
+			now:      time.Date(2023, time.December, 30, 20, 0, 0, 0, time.UTC),
+			days:     "7",
+			want:     true,
+		},
+		{
+			name:     "today",
+			birthday: time.Date(1990, time.March, 10, 0, 0, 0, 0, time.UTC),
+			now:      time.Date(2024, time.March, 10, 20, 0, 0, 0, time.UTC),
+			days:     "0",
+			want:     true,
+		},
+		{
+			name:     "leap day falls on March 1",
+			birthday: time.Date(1992, time.February, 29, 0, 0, 0, 0, time.UTC),
+			now:      time.Date(2023, time.February, 25, 12, 0, 0, 0, time.UTC),
+			days:     "4",
+			want:     true,
+		},
+		{
+			name:     "leap day out of range",
+			birthday: time.Date(1992, time.February, 29, 0, 0, 0, 0, time.UTC),
+			now:      time.Date(2023, time.February, 25, 12, 0, 0, 0, time.UTC),
+			days:     "3",
+			want:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := Parse("birthday within " + tt.days + " days")
+			if err != nil {
+				t.Fatal(err)
+			}
+			subject := Subject{Dates: map[string]time.Time{"birthday": tt.birthday}, Now: tt.now}
+			if got := rule.Match(subject); got != tt.want {
+				t.Errorf("Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
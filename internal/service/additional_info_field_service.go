@@ -0,0 +1,296 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lunovoy/friendly/internal/models"
+	"github.com/lunovoy/friendly/internal/repository"
+)
+
+const maxAdditionalInfoFieldTextLength = 10000
+
+var additionalInfoFieldTypes = map[string]bool{
+	models.AdditionalInfoFieldTypeText:         true,
+	models.AdditionalInfoFieldTypeNumber:       true,
+	models.AdditionalInfoFieldTypeDate:         true,
+	models.AdditionalInfoFieldTypeURL:          true,
+	models.AdditionalInfoFieldTypeSingleSelect: true,
+	models.AdditionalInfoFieldTypeMultiSelect:  true,
+}
+
+type AdditionalInfoFieldService struct {
+	repo       repository.AdditionalInfoField
+	changeRepo repository.FriendChange
+}
+
+func NewAdditionalInfoFieldService(repo repository.AdditionalInfoField, changeRepo repository.FriendChange) *AdditionalInfoFieldService {
+	return &AdditionalInfoFieldService{
+		repo:       repo,
+		changeRepo: changeRepo,
+	}
+}
+
+func (s *AdditionalInfoFieldService) Create(userID uuid.UUID, field models.AdditionalInfoField) (uuid.UUID, error) {
+	if field.Type == "" {
+		field.Type = models.AdditionalInfoFieldTypeText
+	}
+	if field.Options == nil {
+		field.Options = []string{}
+	}
+	if err := validateAdditionalInfoField(field); err != nil {
+		return uuid.Nil, err
+	}
+
+	return s.repo.Create(userID, field)
+}
+
+func (s *AdditionalInfoFieldService) GetAll(userID uuid.UUID) ([]models.AdditionalInfoField, error) {
+	return s.repo.GetAll(userID)
+}
+
+func (s *AdditionalInfoFieldService) GetByID(userID, fieldID uuid.UUID) (models.AdditionalInfoField, error) {
+	return s.repo.GetByID(userID, fieldID)
+}
+
+func (s *AdditionalInfoFieldService) Update(userID, fieldID uuid.UUID, update models.AdditionalInfoFieldUpdate) error {
+	field, err := s.repo.GetByID(userID, fieldID)
+	if err != nil {
+		return err
+	}
+
+	if update.Title != nil {
+		field.Title = *update.Title
+	}
+	if update.Options != nil {
+		field.Options = update.Options
+	}
+	if err := validateAdditionalInfoField(field); err != nil {
+		return err
+	}
+
+	return s.repo.Update(userID, fieldID, field)
+}
+
+func (s *AdditionalInfoFieldService) DeleteByID(userID, fieldID uuid.UUID) error {
+	return s.repo.DeleteByID(userID, fieldID)
+}
+
+func (s *AdditionalInfoFieldService) GetAllByFriendID(friendID uuid.UUID) ([]models.FriendAdditionalInfoField, error) {
+	fields, err := friendsAdditionalInfoFields(s.repo, []uuid.UUID{friendID})
+	if err != nil {
+		return nil, err
+	}
+	if fields[friendID] == nil {
+		return []models.FriendAdditionalInfoField{}, nil
+	}
+	return fields[friendID], nil
+}
+
+// SetValue validates the value against the field type and attaches the
+// field to the friend. A null value clears the value but keeps the field.
+func (s *AdditionalInfoFieldService) SetValue(userID, friendID, fieldID uuid.UUID, value any) error {
+	field, err := s.repo.GetByID(userID, fieldID)
+	if err != nil {
+		return err
+	}
+
+	if value == nil {
+		return s.changeValue(userID, friendID, func() error {
+			if err := s.repo.AttachToFriend(friendID, fieldID); err != nil {
+				return err
+			}
+			return s.repo.ClearValue(friendID, fieldID)
+		})
+	}
+
+	content, err := encodeAdditionalInfoFieldValue(field, value)
+	if err != nil {
+		return err
+	}
+
+	return s.changeValue(userID, friendID, func() error {
+		return s.repo.SetValue(friendID, fieldID, content)
+	})
+}
+
+func (s *AdditionalInfoFieldService) DeleteFromFriend(userID, friendID, fieldID uuid.UUID) error {
+	return s.changeValue(userID, friendID, func() error {
+		return s.repo.DetachFromFriend(friendID, fieldID)
+	})
+}
+
+// changeValue runs change of the friend field values and logs the values
+// changed by it.
+func (s *AdditionalInfoFieldService) changeValue(userID, friendID uuid.UUID, change func() error) error {
+	before, err := s.GetAllByFriendID(friendID)
+	if err != nil {
+		return err
+	}
+	if err := change(); err != nil {
+		return err
+	}
+	after, err := s.GetAllByFriendID(friendID)
+	if err != nil {
+		return err
+	}
+
+	if changes := fieldChanges(before, after); len(changes) != 0 {
+		return s.changeRepo.CreateBulk(userID, friendID, changes)
+	}
+
+	return nil
+}
+
+func validateAdditionalInfoField(field models.AdditionalInfoField) error {
+	if strings.TrimSpace(field.Title) == "" {
+		return errors.New("title must not be empty")
+	}
+	if !additionalInfoFieldTypes[field.Type] {
+		return fmt.Errorf("type %s is not supported", field.Type)
+	}
+
+	isSelect := field.Type == models.AdditionalInfoFieldTypeSingleSelect || field.Type == models.AdditionalInfoFieldTypeMultiSelect
+	if isSelect && len(field.Options) == 0 {
+		return errors.New("select field must have options")
+	}
+	if !isSelect && len(field.Options) != 0 {
+		return errors.New("options are allowed only for select fields")
+	}
+	seen := make(map[string]bool, len(field.Options))
+	for _, option := range field.Options {
+		if strings.TrimSpace(option) == "" {
+			return errors.New("option must not be empty")
+		}
+		if seen[option] {
+			return fmt.Errorf("option %s is duplicated", option)
+		}
+		seen[option] = true
+	}
+
+	return nil
+}
+
+// encodeAdditionalInfoFieldValue checks value against the field and returns
+// its text form kept in additional_info_field_text.
+func encodeAdditionalInfoFieldValue(field models.AdditionalInfoField, value any) (string, error) {
+	if field.Type == models.AdditionalInfoFieldTypeMultiSelect {
+		items, ok := value.([]any)
+		if !ok {
+			return "", errors.New("value must be a list of options")
+		}
+		selected := make([]string, 0, len(items))
+		seen := make(map[string]bool, len(items))
+		for _, item := range items {
+			option, ok := item.(string)
+			if !ok || !hasOption(field, option) {
+				return "", fmt.Errorf("value %v is not one of the options", item)
+			}
+			if !seen[option] {
+				seen[option] = true
+				selected = append(selected, option)
+			}
+		}
+		content, err := json.Marshal(selected)
+		return string(content), err
+	}
+
+	if field.Type == models.AdditionalInfoFieldTypeNumber {
+		switch number := value.(type) {
+		case float64:
+			return strconv.FormatFloat(number, 'f', -1, 64), nil
+		case string:
+			if _, err := strconv.ParseFloat(number, 64); err != nil {
+				return "", errors.New("value must be a number")
+			}
+			return number, nil
+		}
+		return "", errors.New("value must be a number")
+	}
+
+	text, ok := value.(string)
+	if !ok {
+		return "", errors.New("value must be a string")
+	}
+
+	switch field.Type {
+	case models.AdditionalInfoFieldTypeText:
+		if len(text) > maxAdditionalInfoFieldTextLength {
+			return "", errors.New("value is too long")
+		}
+	case models.AdditionalInfoFieldTypeDate:
+		date, err := time.Parse("2006-01-02", text)
+		if err != nil {
+			if date, err = time.Parse(time.RFC3339, text); err != nil {
+				return "", errors.New("value must be a date in YYYY-MM-DD format")
+			}
+		}
+		text = date.Format("2006-01-02")
+	case models.AdditionalInfoFieldTypeURL:
+		parsed, err := url.ParseRequestURI(text)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return "", errors.New("value must be an http or https URL")
+		}
+	case models.AdditionalInfoFieldTypeSingleSelect:
+		if !hasOption(field, text) {
+			return "", fmt.Errorf("value %s is not one of the options", text)
+		}
+	}
+
+	return text, nil
+}
+
+// decodeAdditionalInfoFieldValue turns the stored text back into a typed value.
+func decodeAdditionalInfoFieldValue(content models.AdditionalInfoFieldContent) any {
+	if !content.Content.Valid {
+		return nil
+	}
+
+	switch content.Type {
+	case models.AdditionalInfoFieldTypeNumber:
+		if number, err := strconv.ParseFloat(content.Content.String, 64); err == nil {
+			return number
+		}
+	case models.AdditionalInfoFieldTypeMultiSelect:
+		var selected []string
+		if err := json.Unmarshal([]byte(content.Content.String), &selected); err == nil {
+			return selected
+		}
+	}
+
+	return content.Content.String
+}
+
+func hasOption(field models.AdditionalInfoField, option string) bool {
+	for _, existing := range field.Options {
+		if existing == option {
+			return true
+		}
+	}
+	return false
+}
+
+// friendsAdditionalInfoFields loads typed field values of the friends keyed
+// by friend id.
+func friendsAdditionalInfoFields(repo repository.AdditionalInfoField, friendIDs []uuid.UUID) (map[uuid.UUID][]models.FriendAdditionalInfoField, error) {
+	contents, err := repo.GetContentsByFriendIDs(friendIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make(map[uuid.UUID][]models.FriendAdditionalInfoField, len(friendIDs))
+	for _, content := range contents {
+		fields[content.FriendID] = append(fields[content.FriendID], models.FriendAdditionalInfoField{
+			Field: content.AdditionalInfoField,
+			Value: decodeAdditionalInfoFieldValue(content),
+		})
+	}
+
+	return fields, nil
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"database/sql"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/lunovoy/friendly/internal/models"
+)
+
+func TestValidateAdditionalInfoField(t *testing.T) {
+	tests := []struct {
+		name    string
+		field   models.AdditionalInfoField
+		wantErr bool
+	}{
+		{name: "text", field: models.AdditionalInfoField{Title: "Хобби", Type: models.AdditionalInfoFieldTypeText}},
+		{name: "select", field: models.AdditionalInfoField{Title: "Размер", Type: models.AdditionalInfoFieldTypeSingleSelect, Options: []string{"S", "M"}}},
+		{name: "blank title", field: models.AdditionalInfoField{Title: " ", Type: models.AdditionalInfoFieldTypeText}, wantErr: true},
+		{name: "unknown type", field: models.AdditionalInfoField{Title: "Хобби", Type: "color"}, wantErr: true},
+		{name: "select without options", field: models.AdditionalInfoField{Title: "Размер", Type: models.AdditionalInfoFieldTypeMultiSelect}, wantErr: true},
+		{name: "options of a text field", field: models.AdditionalInfoField{Title: "Хобби", Type: models.AdditionalInfoFieldTypeText, Options: []string{"S"}}, wantErr: true},
+		{name: "blank option", field: models.AdditionalInfoField{Title: "Размер", Type: models.AdditionalInfoFieldTypeSingleSelect, Options: []string{"S", ""}}, wantErr: true},
+		{name: "duplicated option", field: models.AdditionalInfoField{Title: "Размер", Type: models.AdditionalInfoFieldTypeSingleSelect, Options: []string{"S", "S"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateAdditionalInfoField(tt.field); (err != nil) != tt.wantErr {
+				t.Errorf("validateAdditionalInfoField() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEncodeAdditionalInfoFieldValue(t *testing.T) {
+	sizes := []string{"S", "M", "L"}
+
+	tests := []struct {
+		name      string
+		fieldType string
+		value     any
+		want      string
+		wantErr   bool
+	}{
+		{name: "text", fieldType: models.AdditionalInfoFieldTypeText, value: "рыбалка", want: "рыбалка"},
+		{name: "too long text", fieldType: models.AdditionalInfoFieldTypeText, value: strings.Repeat("a", maxAdditionalInfoFieldTextLength+1), wantErr: true},
+		{name: "text of a number", fieldType: models.AdditionalInfoFieldTypeText, value: 42.0, wantErr: true},
+		{name: "number", fieldType: models.AdditionalInfoFieldTypeNumber, value: 42.5, want: "42.5"},
+		{name: "number as string", fieldType: models.AdditionalInfoFieldTypeNumber, value: "17", want: "17"},
+		{name: "not a number", fieldType: models.AdditionalInfoFieldTypeNumber, value: "many", wantErr: true},
+		{name: "date", fieldType: models.AdditionalInfoFieldTypeDate, value: "2024-03-10", want: "2024-03-10"},
+		{name: "date and time", fieldType: models.AdditionalInfoFieldTypeDate, value: "2024-03-10T12:00:00Z", want: "2024-03-10"},
+		{name: "not a date", fieldType: models.AdditionalInfoFieldTypeDate, value: "10.03.2024", wantErr: true},
+		{name: "url", fieldType: models.AdditionalInfoFieldTypeURL, value: "https://example.com/a", want: "https://example.com/a"},
+		{name: "url of another scheme", fieldType: models.AdditionalInfoFieldTypeURL, value: "ftp://example.com", wantErr: true},
+		{name: "single select", fieldType: models.AdditionalInfoFieldTypeSingleSelect, value: "M", want: "M"},
+		{name: "single select of another option", fieldType: models.AdditionalInfoFieldTypeSingleSelect, value: "XL", wantErr: true},
+		{name: "multi select drops duplicates", fieldType: models.AdditionalInfoFieldTypeMultiSelect, value: []any{"S", "L", "S"}, want: `["S","L"]`},
+		{name: "multi select of another option", fieldType: models.AdditionalInfoFieldTypeMultiSelect, value: []any{"S", "XL"}, wantErr: true},
+		{name: "multi select of a string", fieldType: models.AdditionalInfoFieldTypeMultiSelect, value: "S", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field := models.AdditionalInfoField{Type: tt.fieldType, Options: sizes}
+			got, err := encodeAdditionalInfoFieldValue(field, tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("encodeAdditionalInfoFieldValue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("encodeAdditionalInfoFieldValue() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeAdditionalInfoFieldValue(t *testing.T) {
+	tests := []struct {
+		fieldType string
+		content   sql.NullString
+		want      any
+	}{
+		{models.AdditionalInfoFieldTypeText, sql.NullString{String: "рыбалка", Valid: true}, "рыбалка"},
+		{models.AdditionalInfoFieldTypeNumber, sql.NullString{String: "42.5", Valid: true}, 42.5},
+		{models.AdditionalInfoFieldTypeMultiSelect, sql.NullString{String: `["S","L"]`, Valid: true}, []string{"S", "L"}},
+		{models.AdditionalInfoFieldTypeDate, sql.NullString{String: "2024-03-10", Valid: true}, "2024-03-10"},
+		{models.AdditionalInfoFieldTypeText, sql.NullString{}, nil},
+	}
+	for _, tt := range tests {
+		content := models.AdditionalInfoFieldContent{AdditionalInfoField: models.AdditionalInfoField{Type: tt.fieldType}, Content: tt.content}
+		if got := decodeAdditionalInfoFieldValue(content); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("decodeAdditionalInfoFieldValue(%s, %q) = %#v, want %#v", tt.fieldType, tt.content.String, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/lunovoy/friendly/internal/models"
+	"github.com/lunovoy/friendly/internal/repository"
+)
+
+const maxAttachmentFileNameLength = 255
+
+var ErrStorageQuotaExceeded = errors.New("storage quota exceeded")
+
+type AttachmentService struct {
+	repo repository.Attachment
+}
+
+func NewAttachmentService(repo repository.Attachment) *AttachmentService {
+	return &AttachmentService{
+		repo: repo,
+	}
+}
+
+// Create stores attachment metadata, the file itself is saved by the caller
+// under the returned id.
+func (s *AttachmentService) Create(userID uuid.UUID, attachment models.Attachment) (uuid.UUID, error) {
+	if attachment.FriendID.Valid == attachment.EventID.Valid {
+		return uuid.Nil, errors.New("attachment must be linked to exactly one friend or event")
+	}
+
+	// Browsers may send the full client path
+	attachment.FileName = strings.TrimSpace(filepath.Base(strings.ReplaceAll(attachment.FileName, `\`, "/")))
+	if attachment.FileName == "" || attachment.FileName == "." || attachment.FileName == "/" {
+		return uuid.Nil, errors.New("file name must not be empty")
+	}
+	if utf8.RuneCountInString(attachment.FileName) > maxAttachmentFileNameLength {
+		return uuid.Nil, errors.New("file name is too long")
+	}
+	if attachment.MimeType == "" {
+		return uuid.Nil, errors.New("mime type must not be empty")
+	}
+	if attachment.Size <= 0 {
+		return uuid.Nil, errors.New("file must not be empty")
+	}
+
+	attachmentID, ok, err := s.repo.Create(userID, attachment)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if !ok {
+		return uuid.Nil, ErrStorageQuotaExceeded
+	}
+
+	return attachmentID, nil
+}
+
+func (s *AttachmentService) GetAll(userID uuid.UUID, filter models.AttachmentFilter) ([]models.Attachment, error) {
+	return s.repo.GetAll(userID, filter)
+}
+
+func (s *AttachmentService) GetByID(userID, attachmentID uuid.UUID) (models.Attachment, error) {
+	return s.repo.GetByID(userID, attachmentID)
+}
+
+func (s *AttachmentService) DeleteByID(userID, attachmentID uuid.UUID) error {
+	return s.repo.DeleteByID(userID, attachmentID)
+}
+
+func (s *AttachmentService) GetUsage(userID uuid.UUID) (models.StorageUsage, error) {
+	return s.repo.GetUsage(userID)
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/lunovoy/friendly/internal/models"
+	"github.com/lunovoy/friendly/internal/repository"
+)
+
+type fakeAttachmentRepo struct {
+	repository.Attachment
+	withinQuota bool
+	created     models.Attachment
+}
+
+func (r *fakeAttachmentRepo) Create(userID uuid.UUID, attachment models.Attachment) (uuid.UUID, bool, error) {
+	if !r.withinQuota {
+		return uuid.Nil, false, nil
+	}
+	r.created = attachment
+	return uuid.New(), true, nil
+}
+
+func TestAttachmentServiceCreate(t *testing.T) {
+	friend := uuid.NullUUID{UUID: uuid.New(), Valid: true}
+	event := uuid.NullUUID{UUID: uuid.New(), Valid: true}
+
+	tests := []struct {
+		name         string
+		attachment   models.Attachment
+		wantFileName string
+		wantErr      bool
+	}{
+		{
+			name:         "client path is dropped",
+			attachment:   models.Attachment{FriendID: friend, FileName: `C:\Users\ivan\Договор.pdf`, MimeType: "application/pdf", Size: 100},
+			wantFileName: "Договор.pdf",
+		},
+		{
+			name:         "event attachment",
+			attachment:   models.Attachment{EventID: event, FileName: " /tmp/photo.jpg ", MimeType: "image/jpeg", Size: 100},
+			wantFileName: "photo.jpg",
+		},
+		{
+			name:       "friend and event",
+			attachment: models.Attachment{FriendID: friend, EventID: event, FileName: "a.pdf", MimeType: "application/pdf", Size: 100},
+			wantErr:    true,
+		},
+		{
+			name:       "neither friend nor event",
+			attachment: models.Attachment{FileName: "a.pdf", MimeType: "application/pdf", Size: 100},
+			wantErr:    true,
+		},
+		{
+			name:       "directory as file name",
+			attachment: models.Attachment{FriendID: friend, FileName: "/", MimeType: "application/pdf", Size: 100},
+			wantErr:    true,
+		},
+		{
+			name:       "too long file name",
+			attachment: models.Attachment{FriendID: friend, FileName: strings.Repeat("я", maxAttachmentFileNameLength+1), MimeType: "application/pdf", Size: 100},
+			wantErr:    true,
+		},
+		{
+			name:       "no mime type",
+			attachment: models.Attachment{FriendID: friend, FileName: "a.pdf", Size: 100},
+			wantErr:    true,
+		},
+		{
+			name:       "empty file",
+			attachment: models.Attachment{FriendID: friend, FileName: "a.pdf", MimeType: "application/pdf"},
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeAttachmentRepo{withinQuota: true}
+			_, err := NewAttachmentService(repo).Create(uuid.New(), tt.attachment)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Create() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if repo.created.FileName != tt.wantFileName {
+				t.Errorf("file name = %q, want %q", repo.created.FileName, tt.wantFileName)
+			}
+		})
+	}
+}
+
+func TestAttachmentServiceCreateOverQuota(t *testing.T) {
+	attachment := models.Attachment{
+		FriendID: uuid.NullUUID{UUID: uuid.New(), Valid: true},
+		FileName: "a.pdf",
+		MimeType: "application/pdf",
+		Size:     100,
+	}
+
+	_, err := NewAttachmentService(&fakeAttachmentRepo{}).Create(uuid.New(), attachment)
+	if !errors.Is(err, ErrStorageQuotaExceeded) {
+		t.Errorf("Create() error = %v, want %v", err, ErrStorageQuotaExceeded)
+	}
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lunovoy/friendly/internal/models"
+	"github.com/lunovoy/friendly/internal/repository"
+)
+
+// maxCalendarRange bounds occurrence queries, daily events would otherwise
+// expand without limit.
+const maxCalendarRange = 366 * 24 * time.Hour
+
+type CalendarService struct {
+	friendRepo     repository.Friend
+	eventRepo      repository.Event
+	friendDateRepo repository.FriendDate
+	preferenceRepo repository.NotificationPreference
+}
+
+func NewCalendarService(friendRepo repository.Friend, eventRepo repository.Event, friendDateRepo repository.FriendDate, preferenceRepo repository.NotificationPreference) *CalendarService {
+	return &CalendarService{
+		friendRepo:     friendRepo,
+		eventRepo:      eventRepo,
+		friendDateRepo: friendDateRepo,
+		preferenceRepo: preferenceRepo,
+	}
+}
+
+func (s *CalendarService) GetOccurrences(userID uuid.UUID, from, to time.Time) ([]models.Occurrence, error) {
+	if to.Before(from) {
+		return nil, errors.New("to must not be before from")
+	}
+	if to.Sub(from) > maxCalendarRange {
+		return nil, errors.New("range must not exceed one year")
+	}
+
+	preference, err := NewNotificationPreferenceService(s.preferenceRepo).Get(userID)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := time.LoadLocation(preference.Timezone)
+	if err != nil {
+		return nil, err
+	}
+	from, to = from.In(loc), to.In(loc)
+
+	occurrences := make([]models.Occurrence, 0)
+
+	events, err := s.eventRepo.GetAll(userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, event := range events {
+		if !event.IsActive {
+			continue
+		}
+		eventID := event.ID
+		for _, date := range eventOccurrences(event, from, to) {
+			occurrences = append(occurrences, models.Occurrence{
+				Kind:    models.OccurrenceKindEvent,
+				Title:   event.Title,
+				Date:    date.In(loc),
+				EventID: &eventID,
+			})
+		}
+	}
+
+	friends, err := s.friendRepo.GetAll(userID)
+	if err != nil {
+		return nil, err
+	}
+	friendsByID := make(map[uuid.UUID]models.Friend, len(friends))
+	for _, friend := range friends {
+		friendsByID[friend.Friend.ID] = friend.Friend
+	}
+	for _, friend := range friends {
+		if !friend.Friend.DOB.Valid || !friend.Friend.TrackBirthday {
+			continue
+		}
+		friendID := friend.Friend.ID
+		for _, date := range anniversaryOccurrences(friend.Friend.DOB.Time, from, to) {
+			occurrences = append(occurrences, models.Occurrence{
+				Kind:     models.OccurrenceKindBirthday,
+				Title:    fmt.Sprintf("День рождения: %s %s", friend.Friend.FirstName, friend.Friend.LastName),
+				Date:     date,
+				FriendID: &friendID,
+				Age:      date.Year() - friend.Friend.DOB.Time.Year(),
+			})
+		}
+	}
+
+	dates, err := s.friendDateRepo.GetAll(userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, date := range dates {
+		friend := friendsByID[date.FriendID]
+		friendID, dateID := date.FriendID, date.ID
+		for _, occurrence := range friendDateOccurrences(date, from, to) {
+			occurrences = append(occurrences, models.Occurrence{
+				Kind:         models.OccurrenceKindFriendDate,
+				Title:        fmt.Sprintf("%s: %s %s", date.Label, friend.FirstName, friend.LastName),
+				Date:         occurrence,
+				FriendID:     &friendID,
+				FriendDateID: &dateID,
+				Age:          friendDateYears(date, occurrence),
+			})
+		}
+	}
+
+	sort.SliceStable(occurrences, func(i, j int) bool {
+		return occurrences[i].Date.Before(occurrences[j].Date)
+	})
+
+	return occurrences, nil
+}
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/lunovoy/friendly/internal/models"
+	"github.com/lunovoy/friendly/internal/repository"
+)
+
+var (
+	phoneRe          = regexp.MustCompile(`^\+?[0-9]{5,15}$`)
+	telegramRe       = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]{4,31}$`)
+	signalUsernameRe = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]{2,31}\.[0-9]{2,10}$`)
+	phoneSeparators  = strings.NewReplacer(" ", "", "-", "", "(", "", ")", "", ".", "")
+)
+
+type ContactPointService struct {
+	repo repository.ContactPoint
+}
+
+func NewContactPointService(repo repository.ContactPoint) *ContactPointService {
+	return &ContactPointService{
+		repo: repo,
+	}
+}
+
+func (s *ContactPointService) Create(userID, friendID uuid.UUID, contact models.ContactPoint) (uuid.UUID, error) {
+	value, err := normalizeContactPoint(contact.Kind, contact.Value)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if err := validateContactPointLabel(contact.Label); err != nil {
+		return uuid.Nil, err
+	}
+	contact.Value = value
+	contact.FriendID = friendID
+
+	return s.repo.Create(userID, contact)
+}
+
+func (s *ContactPointService) GetAllByFriendID(userID, friendID uuid.UUID) ([]models.ContactPoint, error) {
+	return s.repo.GetAllByFriendID(userID, friendID)
+}
+
+func (s *ContactPointService) GetByID(userID, friendID, contactID uuid.UUID) (models.ContactPoint, error) {
+	return s.repo.GetByID(userID, friendID, contactID)
+}
+
+func (s *ContactPointService) Update(userID, friendID, contactID uuid.UUID, update models.ContactPointUpdate) error {
+	contact, err := s.repo.GetByID(userID, friendID, contactID)
+	if err != nil {
+		return err
+	}
+
+	if update.Kind != nil {
+		contact.Kind = *update.Kind
+	}
+	if update.Label != nil {
+		contact.Label = *update.Label
+	}
+	if update.Value != nil {
+		contact.Value = *update.Value
+	}
+	if update.IsPrimary != nil {
+		contact.IsPrimary = *update.IsPrimary
+	}
+
+	value, err := normalizeContactPoint(contact.Kind, contact.Value)
+	if err != nil {
+		return err
+	}
+	if err := validateContactPointLabel(contact.Label); err != nil {
+		return err
+	}
+	contact.Value = value
+
+	return s.repo.Update(userID, contactID, contact)
+}
+
+func (s *ContactPointService) DeleteByID(userID, contactID uuid.UUID) error {
+	return s.repo.DeleteByID(userID, contactID)
+}
+
+// friendsContactPoints groups contact points of the friends by friend id.
+func friendsContactPoints(repo repository.ContactPoint, friendIDs []uuid.UUID) (map[uuid.UUID][]models.ContactPoint, error) {
+	contacts, err := repo.GetAllByFriendIDs(friendIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[uuid.UUID][]models.ContactPoint, len(friendIDs))
+	for _, contact := range contacts {
+		result[contact.FriendID] = append(result[contact.FriendID], contact)
+	}
+
+	return result, nil
+}
+
+func validateContactPointLabel(label string) error {
+	if len([]rune(label)) > 100 {
+		return errors.New("label must not be longer than 100 characters")
+	}
+	return nil
+}
+
+// normalizeContactPoint validates the value against its kind and returns it
+// in the stored form: phones as digits with leading plus, emails in lower
+// case, messenger handles without @.
+func normalizeContactPoint(kind, value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", errors.New("value must not be empty")
+	}
+
+	switch kind {
+	case models.ContactPointKindPhone, models.ContactPointKindWhatsApp:
+		return normalizePhone(value)
+	case models.ContactPointKindEmail:
+		address, err := mail.ParseAddress(value)
+		if err != nil || address.Address != value {
+			return "", errors.New("email is not valid")
+		}
+		return strings.ToLower(address.Address), nil
+	case models.ContactPointKindTelegram:
+		handle := strings.TrimPrefix(value, "@")
+		if !telegramRe.MatchString(handle) {
+			return "", errors.New("telegram username is not valid")
+		}
+		return handle, nil
+	case models.ContactPointKindSignal:
+		if phone, err := normalizePhone(value); err == nil {
+			return phone, nil
+		}
+		handle := strings.TrimPrefix(value, "@")
+		if !signalUsernameRe.MatchString(handle) {
+			return "", errors.New("signal phone or username is not valid")
+		}
+		return handle, nil
+	case models.ContactPointKindSocial:
+		u, err := url.Parse(value)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return "", errors.New("social link must be an http(s) url")
+		}
+		return u.String(), nil
+	case models.ContactPointKindAddress:
+		if len([]rune(value)) > 500 {
+			return "", errors.New("address must not be longer than 500 characters")
+		}
+		return value, nil
+	default:
+		return "", fmt.Errorf("kind %s is not supported", kind)
+	}
+}
+
+func normalizePhone(value string) (string, error) {
+	phone := phoneSeparators.Replace(value)
+	if !phoneRe.MatchString(phone) {
+		return "", errors.New("phone number is not valid")
+	}
+	return phone, nil
+}
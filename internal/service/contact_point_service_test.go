@@ -0,0 +1,47 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lunovoy/friendly/internal/models"
+)
+
+func TestNormalizeContactPoint(t *testing.T) {
+	tests := []struct {
+		name    string
+		kind    string
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{name: "phone with separators", kind: models.ContactPointKindPhone, value: " +7 (999) 123-45-67 ", want: "+79991234567"},
+		{name: "short phone", kind: models.ContactPointKindPhone, value: "123", wantErr: true},
+		{name: "phone with letters", kind: models.ContactPointKindWhatsApp, value: "+7999CALLME", wantErr: true},
+		{name: "email", kind: models.ContactPointKindEmail, value: "Ivan@Example.com", want: "ivan@example.com"},
+		{name: "email with name", kind: models.ContactPointKindEmail, value: "Ivan <ivan@example.com>", wantErr: true},
+		{name: "telegram handle", kind: models.ContactPointKindTelegram, value: "@ivan_petrov", want: "ivan_petrov"},
+		{name: "short telegram handle", kind: models.ContactPointKindTelegram, value: "@ivan", wantErr: true},
+		{name: "signal phone", kind: models.ContactPointKindSignal, value: "+7 999 123 45 67", want: "+79991234567"},
+		{name: "signal username", kind: models.ContactPointKindSignal, value: "@ivan.01", want: "ivan.01"},
+		{name: "signal username without number", kind: models.ContactPointKindSignal, value: "ivan", wantErr: true},
+		{name: "social link", kind: models.ContactPointKindSocial, value: "https://vk.com/ivan", want: "https://vk.com/ivan"},
+		{name: "social link without scheme", kind: models.ContactPointKindSocial, value: "vk.com/ivan", wantErr: true},
+		{name: "address", kind: models.ContactPointKindAddress, value: "Москва, ул. Ленина, 1", want: "Москва, ул. Ленина, 1"},
+		{name: "long address", kind: models.ContactPointKindAddress, value: strings.Repeat("д", 501), wantErr: true},
+		{name: "empty value", kind: models.ContactPointKindAddress, value: "  ", wantErr: true},
+		{name: "unknown kind", kind: "pager", value: "123", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := normalizeContactPoint(tt.kind, tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("normalizeContactPoint() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("normalizeContactPoint() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
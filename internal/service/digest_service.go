@@ -0,0 +1,279 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lunovoy/friendly/internal/models"
+	"github.com/lunovoy/friendly/internal/repository"
+)
+
+var digestFrequencies = map[string]bool{
+	"daily":  true,
+	"weekly": true,
+}
+
+type DigestService struct {
+	repo           repository.Digest
+	friendRepo     repository.Friend
+	eventRepo      repository.Event
+	friendDateRepo repository.FriendDate
+	giftIdeaRepo   repository.GiftIdea
+	keepInTouch    *KeepInTouchService
+}
+
+func NewDigestService(repo repository.Digest, friendRepo repository.Friend, eventRepo repository.Event, friendDateRepo repository.FriendDate, giftIdeaRepo repository.GiftIdea, interactionRepo repository.Interaction) *DigestService {
+	return &DigestService{
+		repo:           repo,
+		friendRepo:     friendRepo,
+		eventRepo:      eventRepo,
+		friendDateRepo: friendDateRepo,
+		giftIdeaRepo:   giftIdeaRepo,
+		keepInTouch:    NewKeepInTouchService(friendRepo, eventRepo, interactionRepo),
+	}
+}
+
+func (s *DigestService) GetSetting(userID uuid.UUID) (models.DigestSetting, error) {
+	setting, err := s.repo.GetByUserID(userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.DigestSetting{
+			Frequency: "daily",
+			TimeOfDay: "09:00",
+			Weekday:   int(time.Monday),
+			Timezone:  "UTC",
+			DaysAhead: 7,
+			UserID:    userID,
+		}, nil
+	}
+	return setting, err
+}
+
+func (s *DigestService) UpdateSetting(userID uuid.UUID, update models.DigestSettingUpdate) error {
+	setting, err := s.GetSetting(userID)
+	if err != nil {
+		return err
+	}
+
+	if update.IsActive != nil {
+		setting.IsActive = *update.IsActive
+	}
+	if update.Frequency != nil {
+		if !digestFrequencies[*update.Frequency] {
+			return errors.New("frequency is not valid")
+		}
+		setting.Frequency = *update.Frequency
+	}
+	if update.TimeOfDay != nil {
+		if _, err := time.Parse("15:04", *update.TimeOfDay); err != nil {
+			return errors.New("time of day must be in HH:MM format")
+		}
+		setting.TimeOfDay = *update.TimeOfDay
+	}
+	if update.Weekday != nil {
+		if *update.Weekday < int(time.Sunday) || *update.Weekday > int(time.Saturday) {
+			return errors.New("weekday must be between 0 (sunday) and 6 (saturday)")
+		}
+		setting.Weekday = *update.Weekday
+	}
+	if update.Timezone != nil {
+		if _, err := time.LoadLocation(*update.Timezone); err != nil {
+			return fmt.Errorf("timezone is not valid: %w", err)
+		}
+		setting.Timezone = *update.Timezone
+	}
+	if update.DaysAhead != nil {
+		if *update.DaysAhead < 1 || *update.DaysAhead > 60 {
+			return errors.New("days ahead must be between 1 and 60")
+		}
+		setting.DaysAhead = *update.DaysAhead
+	}
+
+	return s.repo.Save(userID, setting)
+}
+
+func (s *DigestService) Compose(userID uuid.UUID, now time.Time) (models.Digest, error) {
+	setting, err := s.GetSetting(userID)
+	if err != nil {
+		return models.Digest{}, err
+	}
+
+	loc, err := time.LoadLocation(setting.Timezone)
+	if err != nil {
+		return models.Digest{}, err
+	}
+
+	from := now.In(loc)
+	to := from.AddDate(0, 0, setting.DaysAhead)
+	digest := models.Digest{
+		From: from,
+		To:   to,
+	}
+
+	friends, err := s.friendRepo.GetAll(userID)
+	if err != nil {
+		return models.Digest{}, err
+	}
+	friendIDs := make([]uuid.UUID, 0, len(friends))
+	for _, friend := range friends {
+		friendIDs = append(friendIDs, friend.Friend.ID)
+	}
+	giftIdeas, err := s.giftIdeaRepo.GetOpenByFriendIDs(friendIDs)
+	if err != nil {
+		return models.Digest{}, err
+	}
+
+	for _, friend := range friends {
+		if !friend.Friend.DOB.Valid || !friend.Friend.TrackBirthday {
+			continue
+		}
+		birthday := nextAnniversary(friend.Friend.DOB.Time, from)
+		if birthday.After(to) {
+			continue
+		}
+		digest.Birthdays = append(digest.Birthdays, models.DigestBirthday{
+			Friend:     friend.Friend,
+			Date:       birthday,
+			AgeTurning: birthday.Year() - friend.Friend.DOB.Time.Year(),
+			GiftIdeas:  birthdayGiftIdeas(giftIdeas, friend.Friend.ID, birthday),
+		})
+	}
+	sort.Slice(digest.Birthdays, func(i, j int) bool {
+		return digest.Birthdays[i].Date.Before(digest.Birthdays[j].Date)
+	})
+
+	friendsByID := make(map[uuid.UUID]models.Friend, len(friends))
+	for _, friend := range friends {
+		friendsByID[friend.Friend.ID] = friend.Friend
+	}
+	dates, err := s.friendDateRepo.GetAll(userID)
+	if err != nil {
+		return models.Digest{}, err
+	}
+	for _, date := range dates {
+		for _, occurrence := range friendDateOccurrences(date, from, to) {
+			digest.Dates = append(digest.Dates, models.DigestFriendDate{
+				FriendDate: date,
+				Friend:     friendsByID[date.FriendID],
+				Date:       occurrence,
+				Years:      friendDateYears(date, occurrence),
+			})
+		}
+	}
+	sort.Slice(digest.Dates, func(i, j int) bool {
+		return digest.Dates[i].Date.Before(digest.Dates[j].Date)
+	})
+
+	events, err := s.eventRepo.GetAll(userID)
+	if err != nil {
+		return models.Digest{}, err
+	}
+	for _, event := range events {
+		if !event.IsActive {
+			continue
+		}
+		for _, occurrence := range eventOccurrences(event, from, to) {
+			digest.Events = append(digest.Events, models.DigestEvent{
+				Event:     event,
+				Date:      occurrence.In(loc),
+				GiftIdeas: eventGiftIdeas(giftIdeas, event.ID, occurrence.In(loc)),
+			})
+		}
+	}
+	sort.Slice(digest.Events, func(i, j int) bool {
+		return digest.Events[i].Date.Before(digest.Events[j].Date)
+	})
+
+	digest.Overdue, err = s.keepInTouch.GetOverdue(userID, now)
+	if err != nil {
+		return models.Digest{}, err
+	}
+
+	return digest, nil
+}
+
+// isDigestDue reports whether the digest scheduled last before now has not
+// been sent yet. A digest held back by quiet hours stays due until it is sent.
+func isDigestDue(setting models.DigestSetting, now time.Time) (bool, error) {
+	loc, err := time.LoadLocation(setting.Timezone)
+	if err != nil {
+		return false, err
+	}
+	clock, err := time.Parse("15:04", setting.TimeOfDay)
+	if err != nil {
+		return false, err
+	}
+
+	local := now.In(loc)
+	scheduled := time.Date(local.Year(), local.Month(), local.Day(), clock.Hour(), clock.Minute(), 0, 0, loc)
+	period := 1
+	if setting.Frequency == "weekly" {
+		period = 7
+		scheduled = scheduled.AddDate(0, 0, -((int(local.Weekday()) - setting.Weekday + 7) % 7))
+	}
+	if scheduled.After(now) {
+		scheduled = scheduled.AddDate(0, 0, -period)
+	}
+
+	return !setting.LastSentAt.Valid || setting.LastSentAt.Time.Before(scheduled), nil
+}
+
+func isDigestEmpty(digest models.Digest) bool {
+	return len(digest.Birthdays) == 0 && len(digest.Dates) == 0 && len(digest.Events) == 0 && len(digest.Overdue) == 0
+}
+
+func renderDigest(digest models.Digest) string {
+	var builder strings.Builder
+
+	if len(digest.Birthdays) != 0 {
+		builder.WriteString("Дни рождения:\n")
+		for _, birthday := range digest.Birthdays {
+			fmt.Fprintf(&builder, "• %s — %s %s", birthday.Date.Format("02.01"), birthday.Friend.FirstName, birthday.Friend.LastName)
+			if birthday.AgeTurning > 0 {
+				fmt.Fprintf(&builder, " (%d)", birthday.AgeTurning)
+			}
+			if len(birthday.GiftIdeas) != 0 {
+				fmt.Fprintf(&builder, "\n  Идеи подарков: %s", formatGiftIdeas(birthday.GiftIdeas))
+			}
+			builder.WriteString("\n")
+		}
+	}
+
+	if len(digest.Dates) != 0 {
+		builder.WriteString("Важные даты:\n")
+		for _, date := range digest.Dates {
+			fmt.Fprintf(&builder, "• %s — %s: %s %s", date.Date.Format("02.01"), date.FriendDate.Label, date.Friend.FirstName, date.Friend.LastName)
+			if date.Years > 0 {
+				fmt.Fprintf(&builder, " (%d)", date.Years)
+			}
+			builder.WriteString("\n")
+		}
+	}
+
+	if len(digest.Events) != 0 {
+		builder.WriteString("События:\n")
+		for _, event := range digest.Events {
+			fmt.Fprintf(&builder, "• %s — %s\n", event.Date.Format("02.01 15:04"), event.Event.Title)
+			if len(event.GiftIdeas) != 0 {
+				fmt.Fprintf(&builder, "  Идеи подарков: %s\n", formatGiftIdeas(event.GiftIdeas))
+			}
+		}
+	}
+
+	if len(digest.Overdue) != 0 {
+		builder.WriteString("Давно не общались:\n")
+		for _, overdue := range digest.Overdue {
+			fmt.Fprintf(&builder, "• %s %s", overdue.Friend.FirstName, overdue.Friend.LastName)
+			if overdue.LastContactedAt != nil {
+				fmt.Fprintf(&builder, " — с %s", overdue.LastContactedAt.Format("02.01.2006"))
+			}
+			builder.WriteString("\n")
+		}
+	}
+
+	return strings.TrimRight(builder.String(), "\n")
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/lunovoy/friendly/internal/models"
+)
+
+func TestIsDigestDue(t *testing.T) {
+	sentAt := func(t time.Time) sql.NullTime { return sql.NullTime{Time: t, Valid: true} }
+
+	tests := []struct {
+		name       string
+		frequency  string
+		now        time.Time
+		lastSentAt sql.NullTime
+		want       bool
+	}{
+		{
+			name:      "never sent",
+			frequency: "daily",
+			now:       time.Date(2024, time.March, 10, 5, 0, 0, 0, time.UTC),
+			want:      true,
+		},
+		{
+			name:       "daily sent after yesterday's time",
+			frequency:  "daily",
+			now:        time.Date(2024, time.March, 10, 5, 0, 0, 0, time.UTC),
+			lastSentAt: sentAt(time.Date(2024, time.March, 9, 6, 30, 0, 0, time.UTC)),
+			want:       false,
+		},
+		{
+			name:       "daily time of day passed",
+			frequency:  "daily",
+			now:        time.Date(2024, time.March, 10, 6, 30, 0, 0, time.UTC),
+			lastSentAt: sentAt(time.Date(2024, time.March, 9, 6, 30, 0, 0, time.UTC)),
+			want:       true,
+		},
+		{
+			name:       "weekly sent this week",
+			frequency:  "weekly",
+			now:        time.Date(2024, time.March, 10, 6, 30, 0, 0, time.UTC),
+			lastSentAt: sentAt(time.Date(2024, time.March, 4, 6, 5, 0, 0, time.UTC)),
+			want:       false,
+		},
+		{
+			name:       "weekly before time on the weekday",
+			frequency:  "weekly",
+			now:        time.Date(2024, time.March, 11, 5, 0, 0, 0, time.UTC),
+			lastSentAt: sentAt(time.Date(2024, time.March, 4, 6, 5, 0, 0, time.UTC)),
+			want:       false,
+		},
+		{
+			name:       "weekly time on the weekday passed",
+			frequency:  "weekly",
+			now:        time.Date(2024, time.March, 11, 6, 30, 0, 0, time.UTC),
+			lastSentAt: sentAt(time.Date(2024, time.March, 4, 6, 5, 0, 0, time.UTC)),
+			want:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setting := models.DigestSetting{
+				Frequency:  tt.frequency,
+				TimeOfDay:  "09:00",
+				Weekday:    int(time.Monday),
+				Timezone:   "Europe/Moscow",
+				LastSentAt: tt.lastSentAt,
+			}
+			got, err := isDigestDue(setting, tt.now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("isDigestDue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	for _, setting := range []models.DigestSetting{
+		{Frequency: "daily", TimeOfDay: "09:00", Timezone: "Mars/Olympus"},
+		{Frequency: "daily", TimeOfDay: "9 am", Timezone: "UTC"},
+	} {
+		if _, err := isDigestDue(setting, time.Now()); err == nil {
+			t.Errorf("isDigestDue(%+v) succeeded, want error", setting)
+		}
+	}
+}
+
+func TestRenderDigest(t *testing.T) {
+	lastContactedAt := date(2024, time.January, 5, 12)
+	digest := models.Digest{
+		Birthdays: []models.DigestBirthday{
+			{Friend: models.Friend{FirstName: "Иван", LastName: "Петров"}, Date: date(2024, time.March, 12, 9), AgeTurning: 30},
+			{Friend: models.Friend{FirstName: "Анна"}, Date: date(2024, time.March, 14, 9)},
+		},
+		Events: []models.DigestEvent{
+			{Event: models.Event{Title: "Встреча"}, Date: date(2024, time.March, 13, 18)},
+		},
+		Overdue: []models.OverdueFriend{
+			{Friend: models.Friend{FirstName: "Олег", LastName: "Сидоров"}},
+			{Friend: models.Friend{FirstName: "Мария", LastName: "Иванова"}, LastContactedAt: &lastContactedAt},
+		},
+	}
+
+	want := "Дни рождения:\n" +
+		"• 12.03 — Иван Петров (30)\n" +
+		"• 14.03 — Анна \n" +
+		"События:\n" +
+		"• 13.03 18:00 — Встреча\n" +
+		"Давно не общались:\n" +
+		"• Олег Сидоров\n" +
+		"• Мария Иванова — с 05.01.2024"
+	if got := renderDigest(digest); got != want {
+		t.Errorf("renderDigest() = %q, want %q", got, want)
+	}
+
+	if !isDigestEmpty(models.Digest{}) {
+		t.Error("isDigestEmpty() of an empty digest = false")
+	}
+	if isDigestEmpty(digest) {
+		t.Error("isDigestEmpty() = true")
+	}
+}
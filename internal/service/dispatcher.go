@@ -0,0 +1,494 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lunovoy/friendly/internal/models"
+	"github.com/lunovoy/friendly/internal/repository"
+	"github.com/lunovoy/friendly/internal/storage"
+	"github.com/sirupsen/logrus"
+)
+
+// reminderLookback limits how far back missed reminders are still delivered,
+// e.g. after the server was down.
+const reminderLookback = time.Hour
+
+// deliveryClaimTimeout is how long a dispatcher owns the deliveries it picked
+// up, a claim left by a crashed dispatcher expires after it.
+const deliveryClaimTimeout = 5 * time.Minute
+
+// maxDeliveryAttempts is how many times a delivery is sent before it is
+// marked failed, retries back off up to maxDeliveryRetryDelay.
+const (
+	maxDeliveryAttempts   = 8
+	maxDeliveryRetryDelay = time.Hour
+)
+
+// errNotDelivered is returned by notify when no channel accepted the
+// notification.
+var errNotDelivered = errors.New("notification not delivered")
+
+// keepInTouchInterval is how often overdue contacts are checked, the check
+// loads every friend and event of a user.
+const keepInTouchInterval = time.Hour
+
+// trashPurgeInterval is how often trashed rows past the retention are purged.
+const trashPurgeInterval = time.Hour
+
+type Dispatcher struct {
+	repo        *repository.Repository
+	digests     *DigestService
+	preferences *NotificationPreferenceService
+	keepInTouch *KeepInTouchService
+	trash       *TrashService
+	images      *ImageService
+	notifiers   []Notifier
+	interval    time.Duration
+
+	trashRetention time.Duration
+
+	keepInTouchCheckedAt time.Time
+	trashPurgedAt        time.Time
+}
+
+func NewDispatcher(repo *repository.Repository, storage storage.Storage, interval, trashRetention time.Duration, notifiers ...Notifier) *Dispatcher {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	return &Dispatcher{
+		repo:        repo,
+		digests:     NewDigestService(repo.Digest, repo.Friend, repo.Event, repo.FriendDate, repo.GiftIdea, repo.Interaction),
+		preferences: NewNotificationPreferenceService(repo.NotificationPreference),
+		keepInTouch: NewKeepInTouchService(repo.Friend, repo.Event, repo.Interaction),
+		trash:       NewTrashService(repo.Trash),
+		images:      NewImageService(repo.Image, storage),
+		notifiers:   notifiers,
+		interval:    interval,
+
+		trashRetention: trashRetention,
+	}
+}
+
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		d.tick(ctx, time.Now())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (d *Dispatcher) tick(ctx context.Context, now time.Time) {
+	if err := d.scheduleReminders(now); err != nil {
+		logrus.Errorf("error scheduling reminders: %s", err.Error())
+	}
+	if err := d.scheduleBirthdays(now); err != nil {
+		logrus.Errorf("error scheduling birthdays: %s", err.Error())
+	}
+	if err := d.scheduleFriendDates(now); err != nil {
+		logrus.Errorf("error scheduling important dates: %s", err.Error())
+	}
+	if now.Sub(d.keepInTouchCheckedAt) >= keepInTouchInterval {
+		if err := d.scheduleKeepInTouch(now); err != nil {
+			logrus.Errorf("error scheduling keep-in-touch reminders: %s", err.Error())
+		} else {
+			d.keepInTouchCheckedAt = now
+		}
+	}
+	if err := d.dispatchReminders(now); err != nil {
+		logrus.Errorf("error dispatching reminders: %s", err.Error())
+	}
+	if err := d.dispatchDigests(now); err != nil {
+		logrus.Errorf("error dispatching digests: %s", err.Error())
+	}
+	if now.Sub(d.trashPurgedAt) >= trashPurgeInterval {
+		if purged, imageIDs, err := d.trash.Purge(now, d.trashRetention); err != nil {
+			logrus.Errorf("error purging trash: %s", err.Error())
+		} else {
+			if purged != 0 {
+				logrus.Infof("purged %d rows from trash", purged)
+			}
+			// images are kept while their friend or friendlist can be restored
+			if err := d.images.DeleteUnreferenced(ctx, imageIDs); err != nil {
+				logrus.Errorf("error deleting images of purged rows: %s", err.Error())
+			}
+			d.trashPurgedAt = now
+		}
+	}
+}
+
+// scheduleReminders creates a pending delivery for every reminder whose
+// notification time for some event occurrence has come.
+func (d *Dispatcher) scheduleReminders(now time.Time) error {
+	reminders, err := d.repo.Reminder.GetAllActive()
+	if err != nil {
+		return err
+	}
+
+	for _, reminder := range reminders {
+		offset := time.Duration(reminder.Reminder.MinutesUntilEvent) * time.Minute
+		occurrences := eventOccurrences(reminder.Event, now.Add(offset-reminderLookback), now.Add(offset))
+		for _, occurrence := range occurrences {
+			_, err := d.repo.ReminderDelivery.CreatePending(models.ReminderDelivery{
+				Kind:           models.DeliveryKindEvent,
+				ReminderID:     uuid.NullUUID{UUID: reminder.Reminder.ID, Valid: true},
+				EventID:        uuid.NullUUID{UUID: reminder.Reminder.EventID, Valid: true},
+				OffsetMinutes:  reminder.Reminder.MinutesUntilEvent,
+				OccurrenceDate: occurrence,
+				NotifyAt:       occurrence.Add(-offset),
+				UserID:         reminder.Reminder.UserID,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// scheduleBirthdays creates pending deliveries for friends' birthdays using
+// the reminder offsets from the user's notification preferences.
+func (d *Dispatcher) scheduleBirthdays(now time.Time) error {
+	friends, err := d.repo.Friend.GetAllTrackingBirthdays()
+	if err != nil {
+		return err
+	}
+
+	preferences := make(map[uuid.UUID]models.NotificationPreference)
+	for _, friend := range friends {
+		preference, ok := preferences[friend.UserID]
+		if !ok {
+			preference, err = d.preferences.Get(friend.UserID)
+			if err != nil {
+				return err
+			}
+			preferences[friend.UserID] = preference
+		}
+		if !preference.Birthdays {
+			continue
+		}
+
+		loc, err := time.LoadLocation(preference.Timezone)
+		if err != nil {
+			logrus.Errorf("error loading timezone of user %s: %s", friend.UserID, err.Error())
+			continue
+		}
+
+		for _, minutes := range preference.BirthdayMinutes {
+			offset := time.Duration(minutes) * time.Minute
+			from := now.Add(offset - reminderLookback).In(loc)
+			for _, occurrence := range anniversaryOccurrences(friend.DOB.Time, from, now.Add(offset)) {
+				_, err := d.repo.ReminderDelivery.CreatePending(models.ReminderDelivery{
+					Kind:           models.DeliveryKindBirthday,
+					FriendID:       uuid.NullUUID{UUID: friend.ID, Valid: true},
+					OffsetMinutes:  int(minutes),
+					OccurrenceDate: occurrence,
+					NotifyAt:       occurrence.Add(-offset),
+					UserID:         friend.UserID,
+				})
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// scheduleFriendDates creates pending deliveries for friends' important dates
+// using the reminder offsets of each date.
+func (d *Dispatcher) scheduleFriendDates(now time.Time) error {
+	dates, err := d.repo.FriendDate.GetAllWithReminders()
+	if err != nil {
+		return err
+	}
+
+	locations := make(map[uuid.UUID]*time.Location)
+	for _, date := range dates {
+		loc, ok := locations[date.UserID]
+		if !ok {
+			preference, err := d.preferences.Get(date.UserID)
+			if err != nil {
+				return err
+			}
+			loc, err = time.LoadLocation(preference.Timezone)
+			if err != nil {
+				logrus.Errorf("error loading timezone of user %s: %s", date.UserID, err.Error())
+				loc = time.UTC
+			}
+			locations[date.UserID] = loc
+		}
+
+		for _, minutes := range date.ReminderMinutes {
+			offset := time.Duration(minutes) * time.Minute
+			from := now.Add(offset - reminderLookback).In(loc)
+			for _, occurrence := range friendDateOccurrences(date, from, now.Add(offset)) {
+				_, err := d.repo.ReminderDelivery.CreatePending(models.ReminderDelivery{
+					Kind:           models.DeliveryKindFriendDate,
+					FriendDateID:   uuid.NullUUID{UUID: date.ID, Valid: true},
+					OffsetMinutes:  int(minutes),
+					OccurrenceDate: occurrence,
+					NotifyAt:       occurrence.Add(-offset),
+					UserID:         date.UserID,
+				})
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// scheduleKeepInTouch creates a pending delivery for every overdue contact.
+// A delivery is keyed by the due date, so a friend is reminded once per
+// missed cadence.
+func (d *Dispatcher) scheduleKeepInTouch(now time.Time) error {
+	userIDs, err := d.repo.Friend.GetContactCadenceUserIDs()
+	if err != nil {
+		return err
+	}
+
+	for _, userID := range userIDs {
+		overdue, err := d.keepInTouch.GetOverdue(userID, now)
+		if err != nil {
+			return err
+		}
+		for _, item := range overdue {
+			// friends never contacted are reminded once
+			dueAt := time.Unix(0, 0)
+			if item.DueAt != nil {
+				dueAt = *item.DueAt
+			}
+			_, err := d.repo.ReminderDelivery.CreatePending(models.ReminderDelivery{
+				Kind:           models.DeliveryKindKeepInTouch,
+				FriendID:       uuid.NullUUID{UUID: item.Friend.ID, Valid: true},
+				OccurrenceDate: dueAt,
+				NotifyAt:       now,
+				UserID:         userID,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (d *Dispatcher) dispatchReminders(now time.Time) error {
+	deliveries, err := d.repo.ReminderDelivery.GetDue(now, now.Add(deliveryClaimTimeout))
+	if err != nil {
+		return err
+	}
+
+	var friendIDs []uuid.UUID
+	for _, delivery := range deliveries {
+		if delivery.Delivery.Kind == models.DeliveryKindBirthday {
+			friendIDs = append(friendIDs, delivery.Friend.ID)
+		}
+	}
+	var giftIdeas []models.GiftIdea
+	if len(friendIDs) != 0 {
+		if giftIdeas, err = d.repo.GiftIdea.GetOpenByFriendIDs(friendIDs); err != nil {
+			return err
+		}
+	}
+
+	for _, delivery := range deliveries {
+		if delivery.Delivery.Kind == models.DeliveryKindBirthday {
+			delivery.GiftIdeas = birthdayGiftIdeas(giftIdeas, delivery.Friend.ID, delivery.Delivery.OccurrenceDate)
+		}
+		quietUntil, quiet, err := d.notify(delivery.Delivery.UserID, deliveryNotification(delivery), now)
+		if errors.Is(err, errNotDelivered) {
+			logrus.Errorf("error sending reminder %s: %s", delivery.Delivery.ID, err.Error())
+			if err := d.retryDelivery(delivery.Delivery, now); err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if quiet {
+			if err := d.repo.ReminderDelivery.Defer(delivery.Delivery.ID, quietUntil); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := d.repo.ReminderDelivery.MarkSent(delivery.Delivery.ID, now); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// retryDelivery leaves the delivery pending for another attempt, or marks it
+// failed when all attempts are used up.
+func (d *Dispatcher) retryDelivery(delivery models.ReminderDelivery, now time.Time) error {
+	if delivery.Attempts+1 >= maxDeliveryAttempts {
+		return d.repo.ReminderDelivery.MarkFailed(delivery.ID)
+	}
+	return d.repo.ReminderDelivery.Retry(delivery.ID, now.Add(deliveryRetryDelay(delivery.Attempts)))
+}
+
+// deliveryRetryDelay doubles the delay after every failed attempt starting
+// with a minute.
+func deliveryRetryDelay(attempts int) time.Duration {
+	delay := time.Minute
+	for i := 0; i < attempts && delay < maxDeliveryRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxDeliveryRetryDelay)
+}
+
+func deliveryNotification(delivery models.ReminderDeliveryWithSource) models.Notification {
+	notification := models.Notification{
+		DeliveryID: delivery.Delivery.ID,
+		Actions: []string{
+			models.NotificationActionAcknowledge,
+			models.NotificationActionSnooze,
+			models.NotificationActionDismiss,
+		},
+	}
+
+	switch delivery.Delivery.Kind {
+	case models.DeliveryKindFriendDate:
+		date, friend := delivery.FriendDate, delivery.Friend
+		notification.Category = models.NotificationCategoryEvents
+		notification.Title = fmt.Sprintf("%s: %s %s", date.Label, friend.FirstName, friend.LastName)
+		notification.Text = delivery.Delivery.OccurrenceDate.Format("02.01.2006")
+		if years := friendDateYears(*date, delivery.Delivery.OccurrenceDate); years > 0 {
+			notification.Text += fmt.Sprintf(", годовщина: %d", years)
+		}
+	case models.DeliveryKindBirthday:
+		friend := delivery.Friend
+		notification.Category = models.NotificationCategoryBirthdays
+		notification.Title = fmt.Sprintf("День рождения: %s %s", friend.FirstName, friend.LastName)
+		notification.Text = fmt.Sprintf("%s, исполняется %d", delivery.Delivery.OccurrenceDate.Format("02.01.2006"), delivery.Delivery.OccurrenceDate.Year()-friend.DOB.Time.Year())
+		if len(delivery.GiftIdeas) != 0 {
+			notification.Text += fmt.Sprintf("\nИдеи подарков: %s", formatGiftIdeas(delivery.GiftIdeas))
+		}
+	case models.DeliveryKindKeepInTouch:
+		friend := delivery.Friend
+		notification.Category = models.NotificationCategoryKeepInTouch
+		notification.Title = fmt.Sprintf("Пора связаться: %s %s", friend.FirstName, friend.LastName)
+		notification.Text = "Вы давно не общались"
+	default:
+		notification.Category = models.NotificationCategoryEvents
+		notification.Title = delivery.Event.Title
+		notification.Text = fmt.Sprintf("%s\n%s", delivery.Delivery.OccurrenceDate.Format("02.01.2006 15:04"), delivery.Event.Description)
+	}
+
+	return notification
+}
+
+func (d *Dispatcher) dispatchDigests(now time.Time) error {
+	settings, err := d.repo.Digest.GetAllActive()
+	if err != nil {
+		return err
+	}
+
+	for _, setting := range settings {
+		due, err := isDigestDue(setting, now)
+		if err != nil {
+			logrus.Errorf("error checking digest schedule of user %s: %s", setting.UserID, err.Error())
+			continue
+		}
+		if !due {
+			continue
+		}
+
+		digest, err := d.digests.Compose(setting.UserID, now)
+		if err != nil {
+			return err
+		}
+		if !isDigestEmpty(digest) {
+			_, quiet, err := d.notify(setting.UserID, models.Notification{
+				Category: models.NotificationCategoryDigests,
+				Title:    "Ближайшие события",
+				Text:     renderDigest(digest),
+			}, now)
+			if errors.Is(err, errNotDelivered) {
+				// the digest stays due and is sent again on the next tick
+				logrus.Errorf("error sending digest of user %s: %s", setting.UserID, err.Error())
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			if quiet {
+				continue
+			}
+		}
+
+		if err := d.repo.Digest.MarkSent(setting.ID, now); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// notify sends the notification through the channels enabled by the user.
+// When now falls into the user's quiet hours nothing is sent and the end of
+// the quiet window is returned so the caller can retry then. errNotDelivered
+// is returned when the user has channels enabled but none of them accepted
+// the notification.
+func (d *Dispatcher) notify(userID uuid.UUID, notification models.Notification, now time.Time) (time.Time, bool, error) {
+	preference, err := d.preferences.Get(userID)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	if !isCategoryEnabled(preference, notification.Category) || len(preference.Channels) == 0 {
+		return time.Time{}, false, nil
+	}
+
+	quietUntil, quiet, err := quietHoursEnd(preference, now)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	if quiet {
+		return quietUntil, true, nil
+	}
+
+	// a notification that reached at least one channel is not retried, that
+	// would repeat it on the others
+	delivered := false
+	var failures []string
+	for _, notifier := range d.notifiers {
+		if !isChannelEnabled(preference, notifier.Channel()) {
+			continue
+		}
+		if err := notifier.Notify(userID, notification); err != nil {
+			logrus.Errorf("error sending notification via %s: %s", notifier.Channel(), err.Error())
+			failures = append(failures, fmt.Sprintf("%s: %s", notifier.Channel(), err.Error()))
+			continue
+		}
+		delivered = true
+	}
+
+	if delivered {
+		return time.Time{}, false, nil
+	}
+	if len(failures) == 0 {
+		return time.Time{}, false, fmt.Errorf("%w: no notifier configured for channels %s", errNotDelivered, strings.Join(preference.Channels, ", "))
+	}
+	return time.Time{}, false, fmt.Errorf("%w: %s", errNotDelivered, strings.Join(failures, "; "))
+}
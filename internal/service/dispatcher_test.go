@@ -0,0 +1,56 @@
+package service
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/lunovoy/friendly/internal/models"
+)
+
+func TestDeliveryRetryDelay(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{0, time.Minute},
+		{1, 2 * time.Minute},
+		{2, 4 * time.Minute},
+		{5, 32 * time.Minute},
+		{6, time.Hour},
+		{100, time.Hour},
+	}
+	for _, tt := range tests {
+		if got := deliveryRetryDelay(tt.attempts); got != tt.want {
+			t.Errorf("deliveryRetryDelay(%d) = %s, want %s", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestDeliveryNotification(t *testing.T) {
+	delivery := models.ReminderDeliveryWithSource{
+		Delivery: models.ReminderDelivery{
+			Kind:           models.DeliveryKindEvent,
+			OccurrenceDate: date(2024, time.March, 10, 10),
+		},
+		Event: &models.Event{
+			Title:       "Встреча",
+			Description: "в кафе",
+			StartDate:   sql.NullTime{Time: date(2024, time.March, 3, 10), Valid: true},
+		},
+	}
+
+	notification := deliveryNotification(delivery)
+	if notification.Category != models.NotificationCategoryEvents {
+		t.Errorf("category = %s, want %s", notification.Category, models.NotificationCategoryEvents)
+	}
+	if notification.Title != "Встреча" {
+		t.Errorf("title = %s, want Встреча", notification.Title)
+	}
+	if want := "10.03.2024 10:00\nв кафе"; notification.Text != want {
+		t.Errorf("text = %q, want %q", notification.Text, want)
+	}
+	if len(notification.Actions) != 3 {
+		t.Errorf("actions = %v, want acknowledge, snooze and dismiss", notification.Actions)
+	}
+}
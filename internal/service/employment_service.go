@@ -0,0 +1,207 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lunovoy/friendly/internal/models"
+	"github.com/lunovoy/friendly/internal/repository"
+)
+
+type EmploymentService struct {
+	repo repository.Employment
+}
+
+func NewEmploymentService(repo repository.Employment) *EmploymentService {
+	return &EmploymentService{
+		repo: repo,
+	}
+}
+
+func (s *EmploymentService) Create(userID, friendID uuid.UUID, employment models.Employment) (uuid.UUID, error) {
+	if err := validateEmployment(employment); err != nil {
+		return uuid.Nil, err
+	}
+	employment.FriendID = friendID
+
+	employmentID, err := s.repo.Create(userID, employment)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	return employmentID, s.syncWorkInfo(userID, friendID)
+}
+
+func (s *EmploymentService) GetAllByFriendID(userID, friendID uuid.UUID) ([]models.Employment, error) {
+	employments, err := s.repo.GetAllByFriendID(userID, friendID)
+	if err != nil {
+		return nil, err
+	}
+	if current := currentEmployment(employments, time.Now()); current != -1 {
+		employments[current].IsCurrent = true
+	}
+
+	return employments, nil
+}
+
+func (s *EmploymentService) GetByID(userID, friendID, employmentID uuid.UUID) (models.Employment, error) {
+	employment, err := s.repo.GetByID(userID, friendID, employmentID)
+	if err != nil {
+		return models.Employment{}, err
+	}
+
+	employments, err := s.repo.GetAllByFriendID(userID, friendID)
+	if err != nil {
+		return models.Employment{}, err
+	}
+	if current := currentEmployment(employments, time.Now()); current != -1 {
+		employment.IsCurrent = employments[current].ID == employment.ID
+	}
+
+	return employment, nil
+}
+
+func (s *EmploymentService) Update(userID, friendID, employmentID uuid.UUID, update models.EmploymentUpdate) error {
+	employment, err := s.repo.GetByID(userID, friendID, employmentID)
+	if err != nil {
+		return err
+	}
+
+	if update.Company != nil {
+		employment.Company = *update.Company
+	}
+	if update.Position != nil {
+		employment.Position = *update.Position
+	}
+	if update.Profession != nil {
+		employment.Profession = *update.Profession
+	}
+	if update.City != nil {
+		employment.City = *update.City
+	}
+	if update.StartedAt != nil {
+		employment.StartedAt = update.StartedAt
+	}
+	if update.EndedAt != nil {
+		employment.EndedAt = update.EndedAt
+	}
+	if update.Current != nil && *update.Current {
+		employment.EndedAt = nil
+	}
+	if err := validateEmployment(employment); err != nil {
+		return err
+	}
+
+	if err := s.repo.Update(userID, employmentID, employment); err != nil {
+		return err
+	}
+
+	return s.syncWorkInfo(userID, friendID)
+}
+
+func (s *EmploymentService) DeleteByID(userID, friendID, employmentID uuid.UUID) error {
+	if err := s.repo.DeleteByID(userID, employmentID); err != nil {
+		return err
+	}
+
+	return s.syncWorkInfo(userID, friendID)
+}
+
+// syncWorkInfo keeps work_info showing the current employment for clients
+// that don't know about the history.
+func (s *EmploymentService) syncWorkInfo(userID, friendID uuid.UUID) error {
+	employments, err := s.repo.GetAllByFriendID(userID, friendID)
+	if err != nil {
+		return err
+	}
+
+	var current models.Employment
+	if i := currentEmployment(employments, time.Now()); i != -1 {
+		current = employments[i]
+	}
+
+	return s.repo.SyncWorkInfo(friendID, current)
+}
+
+// currentEmployment returns index of the employment going on at now, the
+// latest started one if there are several, or -1. An employment is over on
+// its end date, one without start date is older than any dated one.
+func currentEmployment(employments []models.Employment, now time.Time) int {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	current := -1
+	for i, employment := range employments {
+		if employment.StartedAt != nil && employment.StartedAt.After(today) {
+			continue
+		}
+		if employment.EndedAt != nil && !employment.EndedAt.After(today) {
+			continue
+		}
+		if current == -1 || (employment.StartedAt != nil && (employments[current].StartedAt == nil || employment.StartedAt.After(*employments[current].StartedAt))) {
+			current = i
+		}
+	}
+
+	return current
+}
+
+// writeEmploymentThrough applies employment part of work_info edited by the
+// old friend api to the current employment, a friend without one gets it.
+// Clearing company and position ends the current employment today.
+func writeEmploymentThrough(repo repository.Employment, userID, friendID uuid.UUID, workInfo models.WorkInfo) error {
+	employments, err := repo.GetAllByFriendID(userID, friendID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	cleared := workInfo.Company == "" && workInfo.Position == ""
+	current := currentEmployment(employments, now)
+	if current == -1 {
+		if cleared {
+			return nil
+		}
+		_, err := repo.Create(userID, models.Employment{
+			Company:    workInfo.Company,
+			Position:   workInfo.Position,
+			Profession: workInfo.Profession,
+			City:       workInfo.City,
+			FriendID:   friendID,
+		})
+		return err
+	}
+
+	employment := employments[current]
+	if cleared {
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+		employment.EndedAt = &today
+		return repo.Update(userID, employment.ID, employment)
+	}
+	employment.Company = workInfo.Company
+	employment.Position = workInfo.Position
+	employment.Profession = workInfo.Profession
+	employment.City = workInfo.City
+
+	return repo.Update(userID, employment.ID, employment)
+}
+
+func employmentChanged(before, after models.WorkInfo) bool {
+	return before.Company != after.Company || before.Position != after.Position ||
+		before.Profession != after.Profession || before.City != after.City
+}
+
+func validateEmployment(employment models.Employment) error {
+	if employment.Company == "" && employment.Position == "" {
+		return errors.New("company or position must not be empty")
+	}
+	for _, value := range []string{employment.Company, employment.Position, employment.Profession, employment.City} {
+		if len([]rune(value)) > 100 {
+			return errors.New("values must not be longer than 100 characters")
+		}
+	}
+	if employment.StartedAt != nil && employment.EndedAt != nil && employment.EndedAt.Before(*employment.StartedAt) {
+		return errors.New("ended at must not be before started at")
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lunovoy/friendly/internal/models"
+)
+
+func TestCurrentEmployment(t *testing.T) {
+	day := func(year int, month time.Month, day int) *time.Time {
+		at := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+		return &at
+	}
+	now := time.Date(2024, time.March, 10, 15, 0, 0, 0, time.UTC)
+
+	over := models.Employment{Company: "over", StartedAt: day(2020, time.January, 1), EndedAt: day(2022, time.January, 1)}
+	endsToday := models.Employment{Company: "ends today", StartedAt: day(2022, time.January, 1), EndedAt: day(2024, time.March, 10)}
+	undated := models.Employment{Company: "undated"}
+	ongoing := models.Employment{Company: "ongoing", StartedAt: day(2023, time.May, 1)}
+	older := models.Employment{Company: "older", StartedAt: day(2021, time.May, 1)}
+	future := models.Employment{Company: "future", StartedAt: day(2024, time.April, 1)}
+
+	tests := []struct {
+		name        string
+		employments []models.Employment
+		want        int
+	}{
+		{"none", nil, -1},
+		{"all over or in the future", []models.Employment{over, endsToday, future}, -1},
+		{"undated", []models.Employment{over, undated}, 1},
+		{"dated wins over undated", []models.Employment{ongoing, undated}, 0},
+		{"latest started", []models.Employment{older, over, ongoing, future}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := currentEmployment(tt.employments, now); got != tt.want {
+				t.Errorf("currentEmployment() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateEmployment(t *testing.T) {
+	startedAt := time.Date(2023, time.May, 1, 0, 0, 0, 0, time.UTC)
+	endedAt := startedAt.AddDate(0, 0, -1)
+
+	tests := []struct {
+		name       string
+		employment models.Employment
+		wantErr    bool
+	}{
+		{name: "company only", employment: models.Employment{Company: "Ромашка"}},
+		{name: "position only", employment: models.Employment{Position: "CTO"}},
+		{name: "neither company nor position", employment: models.Employment{City: "Москва"}, wantErr: true},
+		{name: "too long value", employment: models.Employment{Company: "Ромашка", City: strings.Repeat("М", 101)}, wantErr: true},
+		{name: "ended before started", employment: models.Employment{Company: "Ромашка", StartedAt: &startedAt, EndedAt: &endedAt}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateEmployment(tt.employment); (err != nil) != tt.wantErr {
+				t.Errorf("validateEmployment() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEmploymentChanged(t *testing.T) {
+	before := models.WorkInfo{Company: "Ромашка", Position: "CTO", Nationality: "RU"}
+
+	if employmentChanged(before, models.WorkInfo{Company: "Ромашка", Position: "CTO", Nationality: "KZ"}) {
+		t.Error("employmentChanged() = true for a field out of employment")
+	}
+	if !employmentChanged(before, models.WorkInfo{Company: "Ромашка", Position: "CEO", Nationality: "RU"}) {
+		t.Error("employmentChanged() = false for a changed position")
+	}
+}
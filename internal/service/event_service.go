@@ -98,3 +98,10 @@ func (s *EventService) UpdateFull(userID, eventID uuid.UUID, event models.EventF
 func (s *EventService) DeleteByID(userID, eventID uuid.UUID) error {
 	return s.repo.DeleteByID(userID, eventID)
 }
+
+func (s *EventService) List(userID uuid.UUID, filter models.EventFilter, params models.ListParams) ([]models.Event, string, error) {
+	if err := validateListParams(params); err != nil {
+		return nil, "", err
+	}
+	return s.repo.List(userID, filter, params)
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lunovoy/friendly/internal/models"
+	"github.com/lunovoy/friendly/internal/repository"
+)
+
+var friendDateKinds = map[string]bool{
+	"anniversary":      true,
+	"name_day":         true,
+	"work_anniversary": true,
+	"met_on":           true,
+	"other":            true,
+}
+
+var friendDateRecurrences = map[string]bool{
+	models.FriendDateRecurrenceAnnually: true,
+	models.FriendDateRecurrenceOnce:     true,
+}
+
+type FriendDateService struct {
+	repo         repository.FriendDate
+	deliveryRepo repository.ReminderDelivery
+}
+
+func NewFriendDateService(repo repository.FriendDate, deliveryRepo repository.ReminderDelivery) *FriendDateService {
+	return &FriendDateService{
+		repo:         repo,
+		deliveryRepo: deliveryRepo,
+	}
+}
+
+func (s *FriendDateService) Create(userID, friendID uuid.UUID, date models.FriendDate) (uuid.UUID, error) {
+	if date.Kind == "" {
+		date.Kind = "other"
+	}
+	if date.Recurrence == "" {
+		date.Recurrence = models.FriendDateRecurrenceAnnually
+	}
+	if date.ReminderMinutes == nil {
+		date.ReminderMinutes = []int64{0}
+	}
+	if date.Year != nil && *date.Year == 0 {
+		date.Year = nil
+	}
+	if err := validateFriendDate(date); err != nil {
+		return uuid.Nil, err
+	}
+	date.FriendID = friendID
+
+	return s.repo.Create(userID, date)
+}
+
+func (s *FriendDateService) GetAllByFriendID(userID, friendID uuid.UUID) ([]models.FriendDate, error) {
+	return s.repo.GetAllByFriendID(userID, friendID)
+}
+
+func (s *FriendDateService) GetByID(userID, friendID, dateID uuid.UUID) (models.FriendDate, error) {
+	return s.repo.GetByID(userID, friendID, dateID)
+}
+
+func (s *FriendDateService) Update(userID, friendID, dateID uuid.UUID, update models.FriendDateUpdate) error {
+	date, err := s.repo.GetByID(userID, friendID, dateID)
+	if err != nil {
+		return err
+	}
+
+	if update.Label != nil {
+		date.Label = *update.Label
+	}
+	if update.Kind != nil {
+		date.Kind = *update.Kind
+	}
+	if update.Month != nil {
+		date.Month = *update.Month
+	}
+	if update.Day != nil {
+		date.Day = *update.Day
+	}
+	if update.Year != nil {
+		date.Year = update.Year
+		if *update.Year == 0 {
+			date.Year = nil
+		}
+	}
+	if update.Recurrence != nil {
+		date.Recurrence = *update.Recurrence
+	}
+	if update.ReminderMinutes != nil {
+		date.ReminderMinutes = update.ReminderMinutes
+	}
+	if err := validateFriendDate(date); err != nil {
+		return err
+	}
+
+	if err := s.repo.Update(userID, dateID, date); err != nil {
+		return err
+	}
+
+	// Pending reminders are rescheduled by the dispatcher from the new date
+	return s.deliveryRepo.DeletePendingByFriendDateID(dateID)
+}
+
+func (s *FriendDateService) DeleteByID(userID, dateID uuid.UUID) error {
+	return s.repo.DeleteByID(userID, dateID)
+}
+
+func validateFriendDate(date models.FriendDate) error {
+	if date.Label == "" {
+		return errors.New("label must not be empty")
+	}
+	if !friendDateKinds[date.Kind] {
+		return fmt.Errorf("kind %s is not supported", date.Kind)
+	}
+	if !friendDateRecurrences[date.Recurrence] {
+		return fmt.Errorf("recurrence %s is not supported", date.Recurrence)
+	}
+	if date.Recurrence == models.FriendDateRecurrenceOnce && date.Year == nil {
+		return errors.New("year is required for a one-time date")
+	}
+
+	// year 0 is a leap year, so Feb 29 is accepted without a year
+	year := 0
+	if date.Year != nil {
+		year = *date.Year
+	}
+	if date.Month < 1 || date.Month > 12 || date.Day < 1 || time.Date(year, time.Month(date.Month), date.Day, 0, 0, 0, 0, time.UTC).Day() != date.Day {
+		return errors.New("date is not valid")
+	}
+
+	for _, minutes := range date.ReminderMinutes {
+		if minutes < 0 || minutes > maxReminderOffsetMinutes {
+			return errors.New("reminder minutes must be between 0 and 43200")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/lunovoy/friendly/internal/models"
+)
+
+func TestValidateFriendDate(t *testing.T) {
+	year := func(year int) *int { return &year }
+
+	tests := []struct {
+		name    string
+		date    models.FriendDate
+		wantErr bool
+	}{
+		{
+			name: "annually without year",
+			date: models.FriendDate{Label: "Годовщина", Kind: "anniversary", Month: 6, Day: 15, Recurrence: models.FriendDateRecurrenceAnnually},
+		},
+		{
+			name: "leap day without year",
+			date: models.FriendDate{Label: "Именины", Kind: "name_day", Month: 2, Day: 29, Recurrence: models.FriendDateRecurrenceAnnually},
+		},
+		{
+			name:    "leap day in a common year",
+			date:    models.FriendDate{Label: "Именины", Kind: "name_day", Month: 2, Day: 29, Year: year(2023), Recurrence: models.FriendDateRecurrenceAnnually},
+			wantErr: true,
+		},
+		{
+			name:    "empty label",
+			date:    models.FriendDate{Kind: "other", Month: 6, Day: 15, Recurrence: models.FriendDateRecurrenceAnnually},
+			wantErr: true,
+		},
+		{
+			name:    "unknown kind",
+			date:    models.FriendDate{Label: "Дата", Kind: "birthday", Month: 6, Day: 15, Recurrence: models.FriendDateRecurrenceAnnually},
+			wantErr: true,
+		},
+		{
+			name:    "unknown recurrence",
+			date:    models.FriendDate{Label: "Дата", Kind: "other", Month: 6, Day: 15, Recurrence: "monthly"},
+			wantErr: true,
+		},
+		{
+			name:    "once without year",
+			date:    models.FriendDate{Label: "Дата", Kind: "other", Month: 6, Day: 15, Recurrence: models.FriendDateRecurrenceOnce},
+			wantErr: true,
+		},
+		{
+			name:    "day out of month",
+			date:    models.FriendDate{Label: "Дата", Kind: "other", Month: 4, Day: 31, Recurrence: models.FriendDateRecurrenceAnnually},
+			wantErr: true,
+		},
+		{
+			name:    "month out of range",
+			date:    models.FriendDate{Label: "Дата", Kind: "other", Month: 13, Day: 1, Recurrence: models.FriendDateRecurrenceAnnually},
+			wantErr: true,
+		},
+		{
+			name:    "reminder too far ahead",
+			date:    models.FriendDate{Label: "Дата", Kind: "other", Month: 6, Day: 15, Recurrence: models.FriendDateRecurrenceAnnually, ReminderMinutes: []int64{maxReminderOffsetMinutes + 1}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateFriendDate(tt.date); (err != nil) != tt.wantErr {
+				t.Errorf("validateFriendDate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
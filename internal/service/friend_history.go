@@ -0,0 +1,352 @@
+package service
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lunovoy/friendly/internal/models"
+)
+
+func (s *FriendService) GetHistory(userID, friendID uuid.UUID) ([]models.FriendChange, error) {
+	changes, err := s.changeRepo.GetAllByFriendID(userID, friendID)
+	if err != nil {
+		return nil, err
+	}
+	if changes == nil {
+		return []models.FriendChange{}, nil
+	}
+
+	return changes, nil
+}
+
+// GetVersion rebuilds the friend as it was at the given time by undoing the
+// logged changes made after it, the latest first.
+func (s *FriendService) GetVersion(userID, friendID uuid.UUID, at time.Time) (models.FriendWorkInfoTags, error) {
+	friend, err := s.GetByID(userID, friendID)
+	if err != nil {
+		return models.FriendWorkInfoTags{}, err
+	}
+	changes, err := s.changeRepo.GetAllByFriendID(userID, friendID)
+	if err != nil {
+		return models.FriendWorkInfoTags{}, err
+	}
+
+	for _, change := range changes {
+		if !change.ChangedAt.After(at) {
+			break
+		}
+		switch change.Entity {
+		case models.FriendChangeEntityFriend:
+			setFriendValue(&friend.Friend, change.Field, change.OldValue)
+		case models.FriendChangeEntityWorkInfo:
+			setWorkInfoValue(&friend.WorkInfo, change.Field, change.OldValue)
+		case models.FriendChangeEntityTag:
+			friend.Tags = setTagValue(friend.Tags, userID, change.Field, change.OldValue)
+		case models.FriendChangeEntityField:
+			if friend.AdditionalFields, err = s.setFieldValue(friend.AdditionalFields, userID, change.Field, change.OldValue); err != nil {
+				return models.FriendWorkInfoTags{}, err
+			}
+		}
+	}
+
+	return friend, nil
+}
+
+// Revert brings the profile, tags and custom fields of the friend back to the
+// version at the given time. The revert itself is logged as a regular change.
+func (s *FriendService) Revert(userID, friendID uuid.UUID, at time.Time) error {
+	if at.After(time.Now()) {
+		return errors.New("at must not be in the future")
+	}
+
+	before, err := s.GetByID(userID, friendID)
+	if err != nil {
+		return err
+	}
+	version, err := s.GetVersion(userID, friendID, at)
+	if err != nil {
+		return err
+	}
+
+	dob := version.Friend.DOB.Time
+	var interval int
+	if version.Friend.ContactIntervalDays != nil {
+		interval = *version.Friend.ContactIntervalDays
+	}
+	update := models.UpdateFriendWorkInfoInput{
+		Friend: &models.UpdateFriendInput{
+			FirstName:           &version.Friend.FirstName,
+			LastName:            &version.Friend.LastName,
+			DOB:                 &dob,
+			ImageID:             &version.Friend.ImageID,
+			TrackBirthday:       &version.Friend.TrackBirthday,
+			ContactIntervalDays: &interval,
+		},
+		WorkInfo: &models.UpdateWorkInfoInput{
+			Country:             &version.WorkInfo.Country,
+			City:                &version.WorkInfo.City,
+			Company:             &version.WorkInfo.Company,
+			Profession:          &version.WorkInfo.Profession,
+			Position:            &version.WorkInfo.Position,
+			Messenger:           &version.WorkInfo.Messenger,
+			CommunicationMethod: &version.WorkInfo.CommunicationMethod,
+			Nationality:         &version.WorkInfo.Nationality,
+			Resident:            &version.WorkInfo.Resident,
+			Language:            &version.WorkInfo.Language,
+		},
+	}
+	if err := s.repo.Update(userID, friendID, update); err != nil {
+		return err
+	}
+
+	currentTags := make(map[uuid.UUID]bool, len(before.Tags))
+	for _, tag := range before.Tags {
+		currentTags[tag.ID] = true
+	}
+	for _, tag := range version.Tags {
+		if !currentTags[tag.ID] {
+			if err := s.repo.AddTagToFriend(friendID, tag.ID); err != nil {
+				return err
+			}
+		}
+		delete(currentTags, tag.ID)
+	}
+	for tagID := range currentTags {
+		if err := s.repo.DeleteTagFromFriend(friendID, tagID); err != nil {
+			return err
+		}
+	}
+
+	currentFields := make(map[uuid.UUID]string, len(before.AdditionalFields))
+	for _, field := range before.AdditionalFields {
+		currentFields[field.Field.ID] = additionalInfoFieldText(field.Value)
+	}
+	for _, field := range version.AdditionalFields {
+		text := additionalInfoFieldText(field.Value)
+		if current, ok := currentFields[field.Field.ID]; !ok || current != text {
+			if err := s.restoreFieldValue(friendID, field.Field.ID, text); err != nil {
+				return err
+			}
+		}
+		delete(currentFields, field.Field.ID)
+	}
+	for fieldID := range currentFields {
+		if err := s.fieldRepo.DetachFromFriend(friendID, fieldID); err != nil {
+			return err
+		}
+	}
+
+	after, err := s.GetByID(userID, friendID)
+	if err != nil {
+		return err
+	}
+
+	return s.recordChanges(userID, friendID, before, after)
+}
+
+func (s *FriendService) restoreFieldValue(friendID, fieldID uuid.UUID, text string) error {
+	if text == "" {
+		if err := s.fieldRepo.AttachToFriend(friendID, fieldID); err != nil {
+			return err
+		}
+		return s.fieldRepo.ClearValue(friendID, fieldID)
+	}
+
+	return s.fieldRepo.SetValue(friendID, fieldID, text)
+}
+
+// setFieldValue applies the stored text of a custom field value, fields which
+// were deleted since are skipped.
+func (s *FriendService) setFieldValue(fields []models.FriendAdditionalInfoField, userID uuid.UUID, id, value string) ([]models.FriendAdditionalInfoField, error) {
+	fieldID, err := uuid.Parse(id)
+	if err != nil {
+		return fields, nil
+	}
+
+	for i := range fields {
+		if fields[i].Field.ID == fieldID {
+			if value == "" {
+				return append(fields[:i:i], fields[i+1:]...), nil
+			}
+			fields[i].Value = decodeAdditionalInfoFieldValue(models.AdditionalInfoFieldContent{
+				AdditionalInfoField: fields[i].Field,
+				Content:             sql.NullString{String: value, Valid: true},
+			})
+			return fields, nil
+		}
+	}
+	if value == "" {
+		return fields, nil
+	}
+
+	field, err := s.fieldRepo.GetByID(userID, fieldID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fields, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return append(fields, models.FriendAdditionalInfoField{
+		Field: field,
+		Value: decodeAdditionalInfoFieldValue(models.AdditionalInfoFieldContent{
+			AdditionalInfoField: field,
+			Content:             sql.NullString{String: value, Valid: true},
+		}),
+	}), nil
+}
+
+func setFriendValue(friend *models.Friend, field, value string) {
+	switch field {
+	case "first_name":
+		friend.FirstName = value
+	case "last_name":
+		friend.LastName = value
+	case "dob":
+		friend.DOB = sql.NullTime{}
+		if dob, err := time.Parse("2006-01-02", value); err == nil {
+			friend.DOB = sql.NullTime{Time: dob, Valid: true}
+		}
+	case "image_id":
+		if imageID, err := uuid.Parse(value); err == nil {
+			friend.ImageID = imageID
+		}
+	case "track_birthday":
+		if track, err := strconv.ParseBool(value); err == nil {
+			friend.TrackBirthday = track
+		}
+	case "contact_interval_days":
+		friend.ContactIntervalDays = nil
+		if interval, err := strconv.Atoi(value); err == nil {
+			friend.ContactIntervalDays = &interval
+		}
+	}
+}
+
+func setWorkInfoValue(workInfo *models.WorkInfo, field, value string) {
+	fields := map[string]*string{
+		"country":              &workInfo.Country,
+		"city":                 &workInfo.City,
+		"company":              &workInfo.Company,
+		"profession":           &workInfo.Profession,
+		"position":             &workInfo.Position,
+		"messenger":            &workInfo.Messenger,
+		"communication_method": &workInfo.CommunicationMethod,
+		"nationality":          &workInfo.Nationality,
+		"language":             &workInfo.Language,
+	}
+	if text, ok := fields[field]; ok {
+		*text = value
+		return
+	}
+	if field == "resident" {
+		if resident, err := strconv.ParseBool(value); err == nil {
+			workInfo.Resident = resident
+		}
+	}
+}
+
+func setTagValue(tags []models.Tag, userID uuid.UUID, id, title string) []models.Tag {
+	tagID, err := uuid.Parse(id)
+	if err != nil {
+		return tags
+	}
+
+	for i := range tags {
+		if tags[i].ID == tagID {
+			if title == "" {
+				return append(tags[:i:i], tags[i+1:]...)
+			}
+			tags[i].Title = title
+			return tags
+		}
+	}
+	if title == "" {
+		return tags
+	}
+
+	return append(tags, models.Tag{ID: tagID, Title: title, UserID: userID})
+}
+
+// tagChanges logs tags attached and detached between before and after, the
+// title is the value of an attached tag.
+func tagChanges(before, after []models.Tag) []models.FriendChange {
+	var changes []models.FriendChange
+
+	titles := make(map[uuid.UUID]string, len(before))
+	for _, tag := range before {
+		titles[tag.ID] = tag.Title
+	}
+	for _, tag := range after {
+		if _, ok := titles[tag.ID]; !ok {
+			changes = append(changes, models.FriendChange{
+				Entity:   models.FriendChangeEntityTag,
+				Field:    tag.ID.String(),
+				NewValue: tag.Title,
+			})
+		}
+		delete(titles, tag.ID)
+	}
+	for _, tag := range before {
+		if _, ok := titles[tag.ID]; ok {
+			changes = append(changes, models.FriendChange{
+				Entity:   models.FriendChangeEntityTag,
+				Field:    tag.ID.String(),
+				OldValue: tag.Title,
+			})
+		}
+	}
+
+	return changes
+}
+
+// fieldChanges logs custom field values changed between before and after.
+func fieldChanges(before, after []models.FriendAdditionalInfoField) []models.FriendChange {
+	var changes []models.FriendChange
+
+	values := make(map[uuid.UUID]string, len(before))
+	for _, field := range before {
+		values[field.Field.ID] = additionalInfoFieldText(field.Value)
+	}
+	for _, field := range after {
+		value := additionalInfoFieldText(field.Value)
+		if value != values[field.Field.ID] {
+			changes = append(changes, models.FriendChange{
+				Entity:   models.FriendChangeEntityField,
+				Field:    field.Field.ID.String(),
+				OldValue: values[field.Field.ID],
+				NewValue: value,
+			})
+		}
+		delete(values, field.Field.ID)
+	}
+	for _, field := range before {
+		if value, ok := values[field.Field.ID]; ok && value != "" {
+			changes = append(changes, models.FriendChange{
+				Entity:   models.FriendChangeEntityField,
+				Field:    field.Field.ID.String(),
+				OldValue: value,
+			})
+		}
+	}
+
+	return changes
+}
+
+// additionalInfoFieldText is the stored text of a decoded custom field value.
+func additionalInfoFieldText(value any) string {
+	switch value := value.(type) {
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case []string:
+		content, _ := json.Marshal(value)
+		return string(content)
+	}
+	return ""
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lunovoy/friendly/internal/models"
+)
+
+func historyFriends() (before, after models.FriendWorkInfoTags) {
+	userID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	work := models.Tag{ID: uuid.MustParse("00000000-0000-0000-0000-000000000002"), Title: "work", UserID: userID}
+	family := models.Tag{ID: uuid.MustParse("00000000-0000-0000-0000-000000000003"), Title: "family", UserID: userID}
+	hobby := models.AdditionalInfoField{ID: uuid.MustParse("00000000-0000-0000-0000-000000000004")}
+	size := models.AdditionalInfoField{ID: uuid.MustParse("00000000-0000-0000-0000-000000000005")}
+	interval := 30
+
+	before = models.FriendWorkInfoTags{
+		Friend:   models.Friend{FirstName: "Иван", LastName: "Петров", TrackBirthday: true},
+		WorkInfo: models.WorkInfo{City: "Москва", Company: "Ромашка"},
+		Tags:     []models.Tag{work},
+		AdditionalFields: []models.FriendAdditionalInfoField{
+			{Field: hobby, Value: "рыбалка"},
+			{Field: size, Value: []string{"M"}},
+		},
+	}
+	after = models.FriendWorkInfoTags{
+		Friend: models.Friend{
+			FirstName:           "Иван",
+			LastName:            "Сидоров",
+			DOB:                 sql.NullTime{Time: date(1990, time.March, 10, 0), Valid: true},
+			TrackBirthday:       true,
+			ContactIntervalDays: &interval,
+		},
+		WorkInfo: models.WorkInfo{City: "Казань", Company: "Ромашка", Resident: true},
+		Tags:     []models.Tag{family},
+		AdditionalFields: []models.FriendAdditionalInfoField{
+			{Field: size, Value: []string{"M", "L"}},
+		},
+	}
+	return before, after
+}
+
+func TestFriendChanges(t *testing.T) {
+	before, after := historyFriends()
+
+	want := []models.FriendChange{
+		{Entity: models.FriendChangeEntityFriend, Field: "last_name", OldValue: "Петров", NewValue: "Сидоров"},
+		{Entity: models.FriendChangeEntityFriend, Field: "dob", NewValue: "1990-03-10"},
+		{Entity: models.FriendChangeEntityFriend, Field: "contact_interval_days", NewValue: "30"},
+		{Entity: models.FriendChangeEntityWorkInfo, Field: "city", OldValue: "Москва", NewValue: "Казань"},
+		{Entity: models.FriendChangeEntityWorkInfo, Field: "resident", OldValue: "false", NewValue: "true"},
+		{Entity: models.FriendChangeEntityTag, Field: after.Tags[0].ID.String(), NewValue: "family"},
+		{Entity: models.FriendChangeEntityTag, Field: before.Tags[0].ID.String(), OldValue: "work"},
+		{Entity: models.FriendChangeEntityField, Field: after.AdditionalFields[0].Field.ID.String(), OldValue: `["M"]`, NewValue: `["M","L"]`},
+		{Entity: models.FriendChangeEntityField, Field: before.AdditionalFields[0].Field.ID.String(), OldValue: "рыбалка"},
+	}
+
+	if got := friendChanges(before, after); !reflect.DeepEqual(got, want) {
+		t.Errorf("friendChanges() = %+v, want %+v", got, want)
+	}
+	if got := friendChanges(before, before); got != nil {
+		t.Errorf("friendChanges() of the same friend = %+v, want none", got)
+	}
+}
+
+// TestRevertChanges replays old values of the changes onto the updated
+// friend the way Revert does and expects the friend before the update.
+func TestRevertChanges(t *testing.T) {
+	before, after := historyFriends()
+	changes := friendChanges(before, after)
+
+	reverted := after
+	reverted.Tags = append([]models.Tag(nil), after.Tags...)
+	for _, change := range changes {
+		switch change.Entity {
+		case models.FriendChangeEntityFriend:
+			setFriendValue(&reverted.Friend, change.Field, change.OldValue)
+		case models.FriendChangeEntityWorkInfo:
+			setWorkInfoValue(&reverted.WorkInfo, change.Field, change.OldValue)
+		case models.FriendChangeEntityTag:
+			reverted.Tags = setTagValue(reverted.Tags, before.Tags[0].UserID, change.Field, change.OldValue)
+		}
+	}
+
+	if !reflect.DeepEqual(reverted.Friend, before.Friend) {
+		t.Errorf("reverted friend = %+v, want %+v", reverted.Friend, before.Friend)
+	}
+	if reverted.WorkInfo != before.WorkInfo {
+		t.Errorf("reverted work info = %+v, want %+v", reverted.WorkInfo, before.WorkInfo)
+	}
+	if !reflect.DeepEqual(reverted.Tags, before.Tags) {
+		t.Errorf("reverted tags = %+v, want %+v", reverted.Tags, before.Tags)
+	}
+}
+
+func TestAdditionalInfoFieldText(t *testing.T) {
+	tests := []struct {
+		value any
+		want  string
+	}{
+		{"рыбалка", "рыбалка"},
+		{42.5, "42.5"},
+		{[]string{"S", "M"}, `["S","M"]`},
+		{nil, ""},
+	}
+	for _, tt := range tests {
+		if got := additionalInfoFieldText(tt.value); got != tt.want {
+			t.Errorf("additionalInfoFieldText(%v) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
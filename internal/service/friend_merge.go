@@ -0,0 +1,238 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/lunovoy/friendly/internal/models"
+)
+
+// duplicateMinScore is the score from which a pair of friends is reported as
+// a duplicate candidate.
+const duplicateMinScore = 0.5
+
+// mergeFields are friend and work info fields with a merge choice, custom
+// fields are chosen by id.
+var mergeFields = map[string]bool{
+	"first_name":            true,
+	"last_name":             true,
+	"dob":                   true,
+	"image_id":              true,
+	"track_birthday":        true,
+	"contact_interval_days": true,
+	"country":               true,
+	"city":                  true,
+	"company":               true,
+	"profession":            true,
+	"position":              true,
+	"messenger":             true,
+	"communication_method":  true,
+	"nationality":           true,
+	"resident":              true,
+	"language":              true,
+}
+
+// FindDuplicates scores every pair of friends by name similarity, dob, shared
+// contact points and company, the most likely duplicates first.
+func (s *FriendService) FindDuplicates(userID uuid.UUID) ([]models.DuplicateCandidate, error) {
+	friends, err := s.GetAll(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]models.DuplicateCandidate, 0)
+	for i := range friends {
+		for j := i + 1; j < len(friends); j++ {
+			if candidate, ok := duplicateScore(friends[i], friends[j]); ok {
+				candidates = append(candidates, candidate)
+			}
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Score > candidates[j].Score
+	})
+
+	return candidates, nil
+}
+
+// Merge moves tags, friendlists, events, dates, gift ideas, notes and
+// mentions, interactions, contact points, employment, relationships and custom
+// fields of source friend to target and deletes source. Empty values of target are filled from source,
+// conflicts are resolved by merge choices.
+func (s *FriendService) Merge(userID uuid.UUID, merge models.FriendMerge) error {
+	if merge.TargetID == merge.SourceID {
+		return errors.New("friend can't be merged into itself")
+	}
+	for field, choice := range merge.Choices {
+		if choice != models.MergeChoiceTarget && choice != models.MergeChoiceSource {
+			return fmt.Errorf("choice for %s must be target or source", field)
+		}
+		if _, err := uuid.Parse(field); !mergeFields[field] && err != nil {
+			return fmt.Errorf("field %s can't be merged", field)
+		}
+	}
+
+	target, err := s.GetByID(userID, merge.TargetID)
+	if err != nil {
+		return err
+	}
+	source, err := s.GetByID(userID, merge.SourceID)
+	if err != nil {
+		return err
+	}
+
+	fromSource := func(field string) bool {
+		return merge.Choices[field] == models.MergeChoiceSource
+	}
+	pick := func(field, targetValue, sourceValue string) *string {
+		value := targetValue
+		if sourceValue != "" && (targetValue == "" || fromSource(field)) {
+			value = sourceValue
+		}
+		return &value
+	}
+
+	friend := &models.UpdateFriendInput{
+		FirstName: pick("first_name", target.Friend.FirstName, source.Friend.FirstName),
+		LastName:  pick("last_name", target.Friend.LastName, source.Friend.LastName),
+	}
+	if source.Friend.DOB.Valid && (!target.Friend.DOB.Valid || fromSource("dob")) {
+		friend.DOB = &source.Friend.DOB.Time
+	}
+	if source.Friend.ImageID != uuid.Nil && (target.Friend.ImageID == uuid.Nil || fromSource("image_id")) {
+		friend.ImageID = &source.Friend.ImageID
+	}
+	if fromSource("track_birthday") {
+		friend.TrackBirthday = &source.Friend.TrackBirthday
+	}
+	if source.Friend.ContactIntervalDays != nil && (target.Friend.ContactIntervalDays == nil || fromSource("contact_interval_days")) {
+		friend.ContactIntervalDays = source.Friend.ContactIntervalDays
+	}
+
+	workInfo := &models.UpdateWorkInfoInput{
+		Country:             pick("country", target.WorkInfo.Country, source.WorkInfo.Country),
+		City:                pick("city", target.WorkInfo.City, source.WorkInfo.City),
+		Company:             pick("company", target.WorkInfo.Company, source.WorkInfo.Company),
+		Profession:          pick("profession", target.WorkInfo.Profession, source.WorkInfo.Profession),
+		Position:            pick("position", target.WorkInfo.Position, source.WorkInfo.Position),
+		Messenger:           pick("messenger", target.WorkInfo.Messenger, source.WorkInfo.Messenger),
+		CommunicationMethod: pick("communication_method", target.WorkInfo.CommunicationMethod, source.WorkInfo.CommunicationMethod),
+		Nationality:         pick("nationality", target.WorkInfo.Nationality, source.WorkInfo.Nationality),
+		Language:            pick("language", target.WorkInfo.Language, source.WorkInfo.Language),
+	}
+	if fromSource("resident") {
+		workInfo.Resident = &source.WorkInfo.Resident
+	}
+
+	var sourceFieldIDs []uuid.UUID
+	for _, field := range source.AdditionalFields {
+		if field.Value != nil && fromSource(field.Field.ID.String()) {
+			sourceFieldIDs = append(sourceFieldIDs, field.Field.ID)
+		}
+	}
+
+	merged := models.UpdateFriendWorkInfoInput{Friend: friend, WorkInfo: workInfo}
+	if err := s.repo.Merge(userID, merge.TargetID, merge.SourceID, merged, sourceFieldIDs); err != nil {
+		return err
+	}
+
+	after, err := s.GetByID(userID, merge.TargetID)
+	if err != nil {
+		return err
+	}
+
+	return s.recordChanges(userID, merge.TargetID, target, after, models.FriendChange{
+		Entity:   models.FriendChangeEntityFriend,
+		Field:    "merged_friend",
+		NewValue: strings.TrimSpace(source.Friend.FirstName + " " + source.Friend.LastName),
+	})
+}
+
+func duplicateScore(a, b models.FriendWorkInfoTags) (models.DuplicateCandidate, bool) {
+	candidate := models.DuplicateCandidate{
+		Friend:    a.Friend,
+		Duplicate: b.Friend,
+		Reasons:   []string{},
+	}
+
+	var score float64
+	similarity := math.Max(
+		nameSimilarity(a.Friend.FirstName+" "+a.Friend.LastName, b.Friend.FirstName+" "+b.Friend.LastName),
+		nameSimilarity(a.Friend.FirstName+" "+a.Friend.LastName, b.Friend.LastName+" "+b.Friend.FirstName),
+	)
+	if similarity >= 0.8 {
+		score += 0.5 * similarity
+		candidate.Reasons = append(candidate.Reasons, "name")
+	}
+
+	if a.Friend.DOB.Valid && b.Friend.DOB.Valid {
+		if formatNullDate(a.Friend.DOB) == formatNullDate(b.Friend.DOB) {
+			score += 0.25
+			candidate.Reasons = append(candidate.Reasons, "dob")
+		} else {
+			score -= 0.25
+		}
+	}
+
+	if sharesContactPoint(a.ContactPoints, b.ContactPoints) {
+		score += 0.4
+		candidate.Reasons = append(candidate.Reasons, "contact_point")
+	}
+
+	if a.WorkInfo.Company != "" && strings.EqualFold(a.WorkInfo.Company, b.WorkInfo.Company) {
+		score += 0.1
+		candidate.Reasons = append(candidate.Reasons, "company")
+	}
+
+	candidate.Score = math.Round(math.Min(score, 1)*100) / 100
+
+	return candidate, candidate.Score >= duplicateMinScore
+}
+
+// nameSimilarity is 1 minus edit distance relative to the longer name.
+func nameSimilarity(a, b string) float64 {
+	x, y := []rune(normalizeName(a)), []rune(normalizeName(b))
+	longest := max(len(x), len(y))
+	if longest == 0 {
+		return 0
+	}
+
+	previous := make([]int, len(y)+1)
+	current := make([]int, len(y)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(x); i++ {
+		current[0] = i
+		for j := 1; j <= len(y); j++ {
+			cost := 1
+			if x[i-1] == y[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return 1 - float64(previous[len(y)])/float64(longest)
+}
+
+func normalizeName(name string) string {
+	name = strings.ReplaceAll(strings.ToLower(name), "ё", "е")
+	return strings.Join(strings.Fields(name), " ")
+}
+
+func sharesContactPoint(a, b []models.ContactPoint) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if x.Kind == y.Kind && strings.EqualFold(x.Value, y.Value) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"database/sql"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lunovoy/friendly/internal/models"
+)
+
+func TestNameSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want float64
+	}{
+		{"Иван Петров", "иван  петров", 1},
+		{"Семён", "Семен", 1},
+		{"abcd", "abce", 0.75},
+		{"Иван Петрович", "Иван Петров", 1 - 2.0/13},
+		{"Ivan", "", 0},
+		{"", "", 0},
+	}
+	for _, tt := range tests {
+		if got := nameSimilarity(tt.a, tt.b); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("nameSimilarity(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestDuplicateScore(t *testing.T) {
+	dob := func(year int, month time.Month, day int) sql.NullTime {
+		return sql.NullTime{Time: date(year, month, day, 0), Valid: true}
+	}
+	phone := []models.ContactPoint{{Kind: models.ContactPointKindPhone, Value: "+79991234567"}}
+
+	friend := models.FriendWorkInfoTags{
+		Friend:        models.Friend{FirstName: "Иван", LastName: "Петров", DOB: dob(1990, time.March, 10)},
+		WorkInfo:      models.WorkInfo{Company: "Ромашка"},
+		ContactPoints: phone,
+	}
+
+	tests := []struct {
+		name        string
+		duplicate   models.FriendWorkInfoTags
+		wantScore   float64
+		wantReasons []string
+		wantOK      bool
+	}{
+		{
+			name:        "same name and dob",
+			duplicate:   models.FriendWorkInfoTags{Friend: models.Friend{FirstName: "иван", LastName: "Петров", DOB: dob(1990, time.March, 10)}},
+			wantScore:   0.75,
+			wantReasons: []string{"name", "dob"},
+			wantOK:      true,
+		},
+		{
+			name:        "swapped names",
+			duplicate:   models.FriendWorkInfoTags{Friend: models.Friend{FirstName: "Петров", LastName: "Иван"}},
+			wantScore:   0.5,
+			wantReasons: []string{"name"},
+			wantOK:      true,
+		},
+		{
+			name:        "same name and different dob",
+			duplicate:   models.FriendWorkInfoTags{Friend: models.Friend{FirstName: "Иван", LastName: "Петров", DOB: dob(1991, time.March, 10)}},
+			wantScore:   0.25,
+			wantReasons: []string{"name"},
+		},
+		{
+			name:        "similar name",
+			duplicate:   models.FriendWorkInfoTags{Friend: models.Friend{FirstName: "Иван", LastName: "Петрович"}},
+			wantScore:   0.42,
+			wantReasons: []string{"name"},
+		},
+		{
+			name: "shared phone and company",
+			duplicate: models.FriendWorkInfoTags{
+				Friend:        models.Friend{FirstName: "Ваня"},
+				WorkInfo:      models.WorkInfo{Company: "ромашка"},
+				ContactPoints: phone,
+			},
+			wantScore:   0.5,
+			wantReasons: []string{"contact_point", "company"},
+			wantOK:      true,
+		},
+		{
+			name: "everything matches",
+			duplicate: models.FriendWorkInfoTags{
+				Friend:        models.Friend{FirstName: "Иван", LastName: "Петров", DOB: dob(1990, time.March, 10)},
+				WorkInfo:      models.WorkInfo{Company: "Ромашка"},
+				ContactPoints: phone,
+			},
+			wantScore:   1,
+			wantReasons: []string{"name", "dob", "contact_point", "company"},
+			wantOK:      true,
+		},
+		{
+			name:        "different friends",
+			duplicate:   models.FriendWorkInfoTags{Friend: models.Friend{FirstName: "Анна", LastName: "Сидорова"}},
+			wantReasons: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			candidate, ok := duplicateScore(friend, tt.duplicate)
+			if ok != tt.wantOK {
+				t.Errorf("duplicateScore() reported = %v, want %v", ok, tt.wantOK)
+			}
+			if candidate.Score != tt.wantScore {
+				t.Errorf("score = %v, want %v", candidate.Score, tt.wantScore)
+			}
+			if !reflect.DeepEqual(candidate.Reasons, tt.wantReasons) {
+				t.Errorf("reasons = %v, want %v", candidate.Reasons, tt.wantReasons)
+			}
+		})
+	}
+}
@@ -1,49 +1,282 @@
 package service
 
 import (
+	"database/sql"
+	"errors"
+	"strconv"
+
 	"github.com/google/uuid"
 	"github.com/lunovoy/friendly/internal/models"
 	"github.com/lunovoy/friendly/internal/repository"
 )
 
 type FriendService struct {
-	repo repository.Friend
+	repo           repository.Friend
+	deliveryRepo   repository.ReminderDelivery
+	changeRepo     repository.FriendChange
+	fieldRepo      repository.AdditionalInfoField
+	contactRepo    repository.ContactPoint
+	employmentRepo repository.Employment
 }
 
-func NewFriendService(repo repository.Friend) *FriendService {
+func NewFriendService(repo repository.Friend, deliveryRepo repository.ReminderDelivery, changeRepo repository.FriendChange, fieldRepo repository.AdditionalInfoField, contactRepo repository.ContactPoint, employmentRepo repository.Employment) *FriendService {
 	return &FriendService{
-		repo: repo,
+		repo:           repo,
+		deliveryRepo:   deliveryRepo,
+		changeRepo:     changeRepo,
+		fieldRepo:      fieldRepo,
+		contactRepo:    contactRepo,
+		employmentRepo: employmentRepo,
 	}
 }
 
 func (s *FriendService) Create(userID uuid.UUID, friend models.UpdateFriendWorkInfoInput) (models.FriendIDWorkInfoID, error) {
-	return s.repo.Create(userID, friend)
+	if friend.Friend != nil {
+		if err := validateContactInterval(friend.Friend.ContactIntervalDays); err != nil {
+			return models.FriendIDWorkInfoID{}, err
+		}
+	}
+	ids, err := s.repo.Create(userID, friend)
+	if err != nil {
+		return models.FriendIDWorkInfoID{}, err
+	}
+
+	if friend.WorkInfo != nil {
+		created, err := s.repo.GetByID(userID, ids.FriendID)
+		if err != nil {
+			return models.FriendIDWorkInfoID{}, err
+		}
+		if err := writeEmploymentThrough(s.employmentRepo, userID, ids.FriendID, created.WorkInfo); err != nil {
+			return models.FriendIDWorkInfoID{}, err
+		}
+	}
+
+	return ids, nil
 }
 
 func (s *FriendService) GetAll(userID uuid.UUID) ([]models.FriendWorkInfoTags, error) {
-	return s.repo.GetAll(userID)
+	friends, err := s.repo.GetAll(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.attachDetails(friends)
+}
+
+func (s *FriendService) List(userID uuid.UUID, filter models.FriendFilter, params models.ListParams) ([]models.FriendWorkInfoTags, string, error) {
+	if err := validateListParams(params); err != nil {
+		return nil, "", err
+	}
+	if filter.DOBMonth < 0 || filter.DOBMonth > 12 {
+		return nil, "", errors.New("dob month must be between 1 and 12")
+	}
+
+	friends, next, err := s.repo.List(userID, filter, params)
+	if err != nil {
+		return nil, "", err
+	}
+
+	friends, err = s.attachDetails(friends)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return friends, next, nil
+}
+
+// attachDetails adds custom fields and contact points to the friends.
+func (s *FriendService) attachDetails(friends []models.FriendWorkInfoTags) ([]models.FriendWorkInfoTags, error) {
+	friendIDs := make([]uuid.UUID, 0, len(friends))
+	for _, friend := range friends {
+		friendIDs = append(friendIDs, friend.Friend.ID)
+	}
+	fields, err := friendsAdditionalInfoFields(s.fieldRepo, friendIDs)
+	if err != nil {
+		return nil, err
+	}
+	contacts, err := friendsContactPoints(s.contactRepo, friendIDs)
+	if err != nil {
+		return nil, err
+	}
+	for i := range friends {
+		friends[i].AdditionalFields = fields[friends[i].Friend.ID]
+		friends[i].ContactPoints = contacts[friends[i].Friend.ID]
+	}
+
+	return friends, nil
 }
 
 func (s *FriendService) GetByID(userID, friendID uuid.UUID) (models.FriendWorkInfoTags, error) {
-	return s.repo.GetByID(userID, friendID)
+	friend, err := s.repo.GetByID(userID, friendID)
+	if err != nil {
+		return models.FriendWorkInfoTags{}, err
+	}
+
+	fields, err := friendsAdditionalInfoFields(s.fieldRepo, []uuid.UUID{friendID})
+	if err != nil {
+		return models.FriendWorkInfoTags{}, err
+	}
+	friend.AdditionalFields = fields[friendID]
+
+	contacts, err := friendsContactPoints(s.contactRepo, []uuid.UUID{friendID})
+	if err != nil {
+		return models.FriendWorkInfoTags{}, err
+	}
+	friend.ContactPoints = contacts[friendID]
+
+	return friend, nil
+}
+
+func (s *FriendService) GetVCard(userID, friendID uuid.UUID) (string, error) {
+	friend, err := s.GetByID(userID, friendID)
+	if err != nil {
+		return "", err
+	}
+	return renderVCard(friend), nil
 }
 
 func (s *FriendService) Update(userID, friendID uuid.UUID, friend models.UpdateFriendWorkInfoInput) error {
-	return s.repo.Update(userID, friendID, friend)
+	if friend.Friend != nil {
+		if err := validateContactInterval(friend.Friend.ContactIntervalDays); err != nil {
+			return err
+		}
+	}
+	before, err := s.repo.GetByID(userID, friendID)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.Update(userID, friendID, friend); err != nil {
+		return err
+	}
+	after, err := s.repo.GetByID(userID, friendID)
+	if err != nil {
+		return err
+	}
+
+	return s.recordChanges(userID, friendID, before, after)
+}
+
+// recordChanges logs the difference of the friend before and after an update
+// and keeps employment and birthday reminders in line with it.
+func (s *FriendService) recordChanges(userID, friendID uuid.UUID, before, after models.FriendWorkInfoTags, extra ...models.FriendChange) error {
+	if changes := append(friendChanges(before, after), extra...); len(changes) != 0 {
+		if err := s.changeRepo.CreateBulk(userID, friendID, changes); err != nil {
+			return err
+		}
+	}
+	if employmentChanged(before.WorkInfo, after.WorkInfo) {
+		if err := writeEmploymentThrough(s.employmentRepo, userID, friendID, after.WorkInfo); err != nil {
+			return err
+		}
+	}
+
+	// Pending birthday reminders are rescheduled by the dispatcher from the new dob
+	if formatNullDate(before.Friend.DOB) != formatNullDate(after.Friend.DOB) || before.Friend.TrackBirthday != after.Friend.TrackBirthday {
+		return s.deliveryRepo.DeletePendingByFriendID(friendID, models.DeliveryKindBirthday)
+	}
+
+	return nil
 }
 
 func (s *FriendService) DeleteByID(userID, friendID uuid.UUID) error {
 	return s.repo.DeleteByID(userID, friendID)
 }
 
-func (s *FriendService) AddTagToFriend(friendID, tagID uuid.UUID) error {
-	return s.repo.AddTagToFriend(friendID, tagID)
+func (s *FriendService) AddTagToFriend(userID, friendID, tagID uuid.UUID) error {
+	return s.changeTags(userID, friendID, func() error {
+		return s.repo.AddTagToFriend(friendID, tagID)
+	})
 }
 
 func (s *FriendService) AddTagsToFriend(userID, friendID uuid.UUID, tagIDs []models.AdditionTag) ([]uuid.UUID, error) {
-	return s.repo.AddTagsToFriend(userID, friendID, tagIDs)
+	var ids []uuid.UUID
+	err := s.changeTags(userID, friendID, func() error {
+		var err error
+		ids, err = s.repo.AddTagsToFriend(userID, friendID, tagIDs)
+		return err
+	})
+
+	return ids, err
+}
+
+func (s *FriendService) DeleteTagFromFriend(userID, friendID, tagID uuid.UUID) error {
+	return s.changeTags(userID, friendID, func() error {
+		return s.repo.DeleteTagFromFriend(friendID, tagID)
+	})
 }
 
-func (s *FriendService) DeleteTagFromFriend(friendID, tagID uuid.UUID) error {
-	return s.repo.DeleteTagFromFriend(friendID, tagID)
+// changeTags runs change of the friend tags and logs the tags attached and
+// detached by it.
+func (s *FriendService) changeTags(userID, friendID uuid.UUID, change func() error) error {
+	before, err := s.repo.GetByID(userID, friendID)
+	if err != nil {
+		return err
+	}
+	if err := change(); err != nil {
+		return err
+	}
+	after, err := s.repo.GetByID(userID, friendID)
+	if err != nil {
+		return err
+	}
+
+	if changes := tagChanges(before.Tags, after.Tags); len(changes) != 0 {
+		return s.changeRepo.CreateBulk(userID, friendID, changes)
+	}
+
+	return nil
+}
+
+// friendChanges compares the profile before and after an update field by
+// field, values are kept as text. Custom fields are compared only when both
+// sides have them loaded.
+func friendChanges(before, after models.FriendWorkInfoTags) []models.FriendChange {
+	var changes []models.FriendChange
+	add := func(entity, field, oldValue, newValue string) {
+		if oldValue != newValue {
+			changes = append(changes, models.FriendChange{
+				Entity:   entity,
+				Field:    field,
+				OldValue: oldValue,
+				NewValue: newValue,
+			})
+		}
+	}
+
+	add(models.FriendChangeEntityFriend, "first_name", before.Friend.FirstName, after.Friend.FirstName)
+	add(models.FriendChangeEntityFriend, "last_name", before.Friend.LastName, after.Friend.LastName)
+	add(models.FriendChangeEntityFriend, "dob", formatNullDate(before.Friend.DOB), formatNullDate(after.Friend.DOB))
+	add(models.FriendChangeEntityFriend, "image_id", before.Friend.ImageID.String(), after.Friend.ImageID.String())
+	add(models.FriendChangeEntityFriend, "track_birthday", strconv.FormatBool(before.Friend.TrackBirthday), strconv.FormatBool(after.Friend.TrackBirthday))
+	add(models.FriendChangeEntityFriend, "contact_interval_days", formatOptionalInt(before.Friend.ContactIntervalDays), formatOptionalInt(after.Friend.ContactIntervalDays))
+
+	add(models.FriendChangeEntityWorkInfo, "country", before.WorkInfo.Country, after.WorkInfo.Country)
+	add(models.FriendChangeEntityWorkInfo, "city", before.WorkInfo.City, after.WorkInfo.City)
+	add(models.FriendChangeEntityWorkInfo, "company", before.WorkInfo.Company, after.WorkInfo.Company)
+	add(models.FriendChangeEntityWorkInfo, "profession", before.WorkInfo.Profession, after.WorkInfo.Profession)
+	add(models.FriendChangeEntityWorkInfo, "position", before.WorkInfo.Position, after.WorkInfo.Position)
+	add(models.FriendChangeEntityWorkInfo, "messenger", before.WorkInfo.Messenger, after.WorkInfo.Messenger)
+	add(models.FriendChangeEntityWorkInfo, "communication_method", before.WorkInfo.CommunicationMethod, after.WorkInfo.CommunicationMethod)
+	add(models.FriendChangeEntityWorkInfo, "nationality", before.WorkInfo.Nationality, after.WorkInfo.Nationality)
+	add(models.FriendChangeEntityWorkInfo, "resident", strconv.FormatBool(before.WorkInfo.Resident), strconv.FormatBool(after.WorkInfo.Resident))
+	add(models.FriendChangeEntityWorkInfo, "language", before.WorkInfo.Language, after.WorkInfo.Language)
+
+	changes = append(changes, tagChanges(before.Tags, after.Tags)...)
+	changes = append(changes, fieldChanges(before.AdditionalFields, after.AdditionalFields)...)
+
+	return changes
+}
+
+func formatNullDate(date sql.NullTime) string {
+	if !date.Valid {
+		return ""
+	}
+	return date.Time.Format("2006-01-02")
+}
+
+func formatOptionalInt(value *int) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.Itoa(*value)
 }
@@ -1,22 +1,32 @@
 package service
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/lunovoy/friendly/internal/models"
 	"github.com/lunovoy/friendly/internal/repository"
 )
 
 type FriendlistService struct {
-	repo repository.Friendlist
+	repo       repository.Friendlist
+	friendRepo repository.Friend
 }
 
-func NewFriendlistService(repo repository.Friendlist) *FriendlistService {
+func NewFriendlistService(repo repository.Friendlist, friendRepo repository.Friend) *FriendlistService {
 	return &FriendlistService{
-		repo: repo,
+		repo:       repo,
+		friendRepo: friendRepo,
 	}
 }
 
 func (s *FriendlistService) Create(userID uuid.UUID, friendlist models.UpdateFriendlist) (uuid.UUID, error) {
+	if err := validateContactInterval(friendlist.ContactIntervalDays); err != nil {
+		return uuid.Nil, err
+	}
+	if err := validateFriendlistRule(friendlist.Rule); err != nil {
+		return uuid.Nil, err
+	}
 	return s.repo.Create(userID, friendlist)
 }
 
@@ -36,15 +46,54 @@ func (s *FriendlistService) GetByIDWithTags(userID, friendlistID uuid.UUID) (mod
 	return s.repo.GetByIDWithTags(userID, friendlistID)
 }
 
+// GetAllWithFriends returns friends of smart friendlists matching their rules
+// at the moment, friends of other friendlists as added.
 func (s *FriendlistService) GetAllWithFriends(userID uuid.UUID) ([]models.FriendlistWithFriends, error) {
-	return s.repo.GetAllWithFriends(userID)
+	friendlists, err := s.repo.GetAllWithFriends(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var friends []models.FriendWorkInfoTags
+	for i, friendlist := range friendlists {
+		if friendlist.Friendlist.Rule == nil {
+			continue
+		}
+		if friends == nil {
+			if friends, err = s.friendRepo.GetAll(userID); err != nil {
+				return nil, err
+			}
+		}
+		if friendlists[i].Friends, err = matchFriendlistRule(*friendlist.Friendlist.Rule, friends, time.Now()); err != nil {
+			return nil, err
+		}
+	}
+
+	return friendlists, nil
 }
 
 func (s *FriendlistService) GetByIDWithFriends(userID, friendlistID uuid.UUID) (models.FriendlistWithFriends, error) {
-	return s.repo.GetByIDWithFriends(userID, friendlistID)
+	friendlist, err := s.repo.GetByIDWithFriends(userID, friendlistID)
+	if err != nil || friendlist.Friendlist.Rule == nil {
+		return friendlist, err
+	}
+
+	friends, err := s.friendRepo.GetAll(userID)
+	if err != nil {
+		return models.FriendlistWithFriends{}, err
+	}
+	friendlist.Friends, err = matchFriendlistRule(*friendlist.Friendlist.Rule, friends, time.Now())
+
+	return friendlist, err
 }
 
 func (s *FriendlistService) Update(userID, friendlistID uuid.UUID, friendlist models.UpdateFriendlist) error {
+	if err := validateContactInterval(friendlist.ContactIntervalDays); err != nil {
+		return err
+	}
+	if err := validateFriendlistRule(friendlist.Rule); err != nil {
+		return err
+	}
 	return s.repo.Update(userID, friendlistID, friendlist)
 }
 
@@ -70,3 +119,69 @@ func (s *FriendlistService) DeleteFriendFromFriendlist(friendlistID, friendID uu
 func (s *FriendlistService) DeleteByID(userID, friendlistID uuid.UUID) error {
 	return s.repo.DeleteByID(userID, friendlistID)
 }
+
+func (s *FriendlistService) GetTemplateFields(friendlistID uuid.UUID) ([]models.AdditionalInfoField, error) {
+	fields, err := s.repo.GetTemplateFields(friendlistID)
+	if err != nil {
+		return nil, err
+	}
+	if fields == nil {
+		fields = []models.AdditionalInfoField{}
+	}
+	return fields, nil
+}
+
+// GetAllTemplateFields returns template fields of all friendlists of the
+// user by friendlist id.
+func (s *FriendlistService) GetAllTemplateFields(userID uuid.UUID) (map[uuid.UUID][]models.AdditionalInfoField, error) {
+	fields, err := s.repo.GetAllTemplateFields(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	byFriendlist := make(map[uuid.UUID][]models.AdditionalInfoField)
+	for _, field := range fields {
+		byFriendlist[field.FriendlistID] = append(byFriendlist[field.FriendlistID], field.AdditionalInfoField)
+	}
+	return byFriendlist, nil
+}
+
+func (s *FriendlistService) AddFieldToTemplate(friendlistID, fieldID uuid.UUID) error {
+	return s.repo.AddFieldToTemplate(friendlistID, fieldID)
+}
+
+func (s *FriendlistService) DeleteFieldFromTemplate(friendlistID, fieldID uuid.UUID) error {
+	return s.repo.DeleteFieldFromTemplate(friendlistID, fieldID)
+}
+
+func (s *FriendlistService) List(userID uuid.UUID, filter models.FriendlistFilter, params models.ListParams) ([]models.Friendlist, string, error) {
+	if err := validateListParams(params); err != nil {
+		return nil, "", err
+	}
+
+	if filter.FriendID != nil {
+		friend, err := s.friendRepo.GetByID(userID, *filter.FriendID)
+		if err != nil {
+			return nil, "", err
+		}
+		friendlists, err := s.repo.GetAll(userID)
+		if err != nil {
+			return nil, "", err
+		}
+		filter.SmartIDs = []uuid.UUID{}
+		for _, friendlist := range friendlists {
+			if friendlist.Rule == nil {
+				continue
+			}
+			matched, err := matchFriendlistRule(*friendlist.Rule, []models.FriendWorkInfoTags{friend}, time.Now())
+			if err != nil {
+				return nil, "", err
+			}
+			if len(matched) != 0 {
+				filter.SmartIDs = append(filter.SmartIDs, friendlist.ID)
+			}
+		}
+	}
+
+	return s.repo.List(userID, filter, params)
+}
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/lunovoy/friendly/internal/models"
+	"github.com/lunovoy/friendly/internal/repository"
+)
+
+type FriendlistShareService struct {
+	repo     repository.FriendlistShare
+	authRepo repository.Authorization
+}
+
+func NewFriendlistShareService(repo repository.FriendlistShare, authRepo repository.Authorization) *FriendlistShareService {
+	return &FriendlistShareService{
+		repo:     repo,
+		authRepo: authRepo,
+	}
+}
+
+// Share gives the user with the mail access to the friendlist of the owner.
+func (s *FriendlistShareService) Share(ownerID, friendlistID uuid.UUID, mail string) (uuid.UUID, error) {
+	user, err := s.authRepo.GetUserByMail(strings.TrimSpace(mail))
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("user with mail %s not found", mail)
+	}
+	if user.ID == ownerID {
+		return uuid.Nil, errors.New("friendlist can not be shared with its owner")
+	}
+
+	return s.repo.Create(friendlistID, user.ID)
+}
+
+func (s *FriendlistShareService) GetAllByFriendlistID(friendlistID uuid.UUID) ([]models.FriendlistShare, error) {
+	return s.repo.GetAllByFriendlistID(friendlistID)
+}
+
+func (s *FriendlistShareService) Unshare(friendlistID, userID uuid.UUID) error {
+	return s.repo.Delete(friendlistID, userID)
+}
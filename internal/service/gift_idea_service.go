@@ -0,0 +1,189 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lunovoy/friendly/internal/models"
+	"github.com/lunovoy/friendly/internal/repository"
+)
+
+var giftIdeaStatuses = map[string]bool{
+	models.GiftIdeaStatusIdea:   true,
+	models.GiftIdeaStatusBought: true,
+	models.GiftIdeaStatusGiven:  true,
+}
+
+type GiftIdeaService struct {
+	repo      repository.GiftIdea
+	eventRepo repository.Event
+}
+
+func NewGiftIdeaService(repo repository.GiftIdea, eventRepo repository.Event) *GiftIdeaService {
+	return &GiftIdeaService{
+		repo:      repo,
+		eventRepo: eventRepo,
+	}
+}
+
+func (s *GiftIdeaService) Create(userID, friendID uuid.UUID, idea models.GiftIdea) (uuid.UUID, error) {
+	if idea.Status == "" {
+		idea.Status = models.GiftIdeaStatusIdea
+	}
+	if err := s.validate(userID, idea); err != nil {
+		return uuid.Nil, err
+	}
+	idea.FriendID = friendID
+
+	return s.repo.Create(userID, idea)
+}
+
+func (s *GiftIdeaService) GetAllByFriendID(userID, friendID uuid.UUID) ([]models.GiftIdea, error) {
+	return s.repo.GetAllByFriendID(userID, friendID)
+}
+
+func (s *GiftIdeaService) GetByID(userID, friendID, ideaID uuid.UUID) (models.GiftIdea, error) {
+	return s.repo.GetByID(userID, friendID, ideaID)
+}
+
+func (s *GiftIdeaService) Update(userID, friendID, ideaID uuid.UUID, update models.GiftIdeaUpdate) error {
+	idea, err := s.repo.GetByID(userID, friendID, ideaID)
+	if err != nil {
+		return err
+	}
+
+	if update.Title != nil {
+		idea.Title = *update.Title
+	}
+	if update.Link != nil {
+		idea.Link = *update.Link
+	}
+	if update.Price != nil {
+		idea.Price = update.Price
+		if *update.Price < 0 {
+			idea.Price = nil
+		}
+	}
+	if update.Status != nil {
+		idea.Status = *update.Status
+	}
+	if update.Occasion != nil {
+		idea.Occasion = *update.Occasion
+		if idea.Occasion == "" {
+			idea.OccasionDate = nil
+			idea.EventID = uuid.NullUUID{}
+		}
+	}
+	if update.OccasionDate != nil {
+		idea.OccasionDate = update.OccasionDate
+	}
+	if update.EventID != nil {
+		idea.EventID = uuid.NullUUID{UUID: *update.EventID, Valid: true}
+	}
+	if idea.Occasion == models.GiftOccasionBirthday {
+		idea.EventID = uuid.NullUUID{}
+	}
+	if err := s.validate(userID, idea); err != nil {
+		return err
+	}
+
+	return s.repo.Update(userID, ideaID, idea)
+}
+
+func (s *GiftIdeaService) DeleteByID(userID, ideaID uuid.UUID) error {
+	return s.repo.DeleteByID(userID, ideaID)
+}
+
+func (s *GiftIdeaService) validate(userID uuid.UUID, idea models.GiftIdea) error {
+	if strings.TrimSpace(idea.Title) == "" {
+		return errors.New("title must not be empty")
+	}
+	if idea.Link != "" {
+		parsed, err := url.ParseRequestURI(idea.Link)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return errors.New("link must be an http or https URL")
+		}
+	}
+	if idea.Price != nil && *idea.Price < 0 {
+		return errors.New("price must not be negative")
+	}
+	if !giftIdeaStatuses[idea.Status] {
+		return fmt.Errorf("status %s is not supported", idea.Status)
+	}
+
+	switch idea.Occasion {
+	case "":
+		if idea.OccasionDate != nil || idea.EventID.Valid {
+			return errors.New("occasion date and event require an occasion")
+		}
+	case models.GiftOccasionBirthday:
+		if idea.EventID.Valid {
+			return errors.New("birthday occasion must not have an event")
+		}
+	case models.GiftOccasionEvent:
+		if !idea.EventID.Valid {
+			return errors.New("event is required for an event occasion")
+		}
+		if _, err := s.eventRepo.GetByID(userID, idea.EventID.UUID); err != nil {
+			return fmt.Errorf("event not found: %w", err)
+		}
+	default:
+		return fmt.Errorf("occasion %s is not supported", idea.Occasion)
+	}
+
+	return nil
+}
+
+// birthdayGiftIdeas picks ideas without an occasion or for the birthday on
+// the given date.
+func birthdayGiftIdeas(ideas []models.GiftIdea, friendID uuid.UUID, date time.Time) []models.GiftIdea {
+	var picked []models.GiftIdea
+	for _, idea := range ideas {
+		if idea.FriendID != friendID || (idea.Occasion != "" && idea.Occasion != models.GiftOccasionBirthday) {
+			continue
+		}
+		if isGiftOccasionDate(idea, date) {
+			picked = append(picked, idea)
+		}
+	}
+	return picked
+}
+
+// eventGiftIdeas picks ideas for the event occurrence on the given date.
+func eventGiftIdeas(ideas []models.GiftIdea, eventID uuid.UUID, date time.Time) []models.GiftIdea {
+	var picked []models.GiftIdea
+	for _, idea := range ideas {
+		if idea.Occasion != models.GiftOccasionEvent || idea.EventID.UUID != eventID {
+			continue
+		}
+		if isGiftOccasionDate(idea, date) {
+			picked = append(picked, idea)
+		}
+	}
+	return picked
+}
+
+// isGiftOccasionDate reports whether the idea is for the occurrence on date,
+// ideas without a date are for any occurrence.
+func isGiftOccasionDate(idea models.GiftIdea, date time.Time) bool {
+	return idea.OccasionDate == nil || idea.OccasionDate.Format("2006-01-02") == date.Format("2006-01-02")
+}
+
+func formatGiftIdeas(ideas []models.GiftIdea) string {
+	titles := make([]string, 0, len(ideas))
+	for _, idea := range ideas {
+		title := idea.Title
+		if idea.Price != nil {
+			title += fmt.Sprintf(" (%.2f)", *idea.Price)
+		}
+		if idea.Status == models.GiftIdeaStatusBought {
+			title += " — куплено"
+		}
+		titles = append(titles, title)
+	}
+	return strings.Join(titles, ", ")
+}
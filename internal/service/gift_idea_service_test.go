@@ -0,0 +1,87 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lunovoy/friendly/internal/models"
+)
+
+func giftIdeaTitles(ideas []models.GiftIdea) []string {
+	var titles []string
+	for _, idea := range ideas {
+		titles = append(titles, idea.Title)
+	}
+	return titles
+}
+
+func TestOccasionGiftIdeas(t *testing.T) {
+	friendID, otherFriendID, eventID := uuid.New(), uuid.New(), uuid.New()
+	birthday := date(2024, time.March, 10, 9)
+	lastBirthday := date(2023, time.March, 10, 0)
+	event := uuid.NullUUID{UUID: eventID, Valid: true}
+
+	ideas := []models.GiftIdea{
+		{Title: "книга", FriendID: friendID},
+		{Title: "часы", FriendID: friendID, Occasion: models.GiftOccasionBirthday},
+		{Title: "шарф", FriendID: friendID, Occasion: models.GiftOccasionBirthday, OccasionDate: &lastBirthday},
+		{Title: "цветы", FriendID: friendID, Occasion: models.GiftOccasionEvent, EventID: event},
+		{Title: "торт", FriendID: otherFriendID, Occasion: models.GiftOccasionEvent, EventID: event, OccasionDate: &birthday},
+		{Title: "мяч", FriendID: otherFriendID},
+	}
+
+	if got, want := giftIdeaTitles(birthdayGiftIdeas(ideas, friendID, birthday)), []string{"книга", "часы"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("birthdayGiftIdeas() = %v, want %v", got, want)
+	}
+	if got, want := giftIdeaTitles(eventGiftIdeas(ideas, eventID, birthday)), []string{"цветы", "торт"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("eventGiftIdeas() = %v, want %v", got, want)
+	}
+	if got, want := giftIdeaTitles(eventGiftIdeas(ideas, eventID, birthday.AddDate(0, 0, 7))), []string{"цветы"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("eventGiftIdeas() of another occurrence = %v, want %v", got, want)
+	}
+}
+
+func TestFormatGiftIdeas(t *testing.T) {
+	price := 1500.0
+	ideas := []models.GiftIdea{
+		{Title: "книга", Status: models.GiftIdeaStatusIdea},
+		{Title: "часы", Price: &price, Status: models.GiftIdeaStatusBought},
+	}
+
+	if got, want := formatGiftIdeas(ideas), "книга, часы (1500.00) — куплено"; got != want {
+		t.Errorf("formatGiftIdeas() = %q, want %q", got, want)
+	}
+}
+
+func TestGiftIdeaServiceValidate(t *testing.T) {
+	price, negative := 10.0, -1.0
+	occasionDate := date(2024, time.March, 10, 0)
+
+	tests := []struct {
+		name    string
+		idea    models.GiftIdea
+		wantErr bool
+	}{
+		{name: "idea", idea: models.GiftIdea{Title: "книга", Link: "https://example.com/book", Price: &price, Status: models.GiftIdeaStatusIdea}},
+		{name: "birthday", idea: models.GiftIdea{Title: "книга", Status: models.GiftIdeaStatusIdea, Occasion: models.GiftOccasionBirthday, OccasionDate: &occasionDate}},
+		{name: "blank title", idea: models.GiftIdea{Title: " ", Status: models.GiftIdeaStatusIdea}, wantErr: true},
+		{name: "link without scheme", idea: models.GiftIdea{Title: "книга", Link: "example.com", Status: models.GiftIdeaStatusIdea}, wantErr: true},
+		{name: "negative price", idea: models.GiftIdea{Title: "книга", Price: &negative, Status: models.GiftIdeaStatusIdea}, wantErr: true},
+		{name: "unknown status", idea: models.GiftIdea{Title: "книга", Status: "lost"}, wantErr: true},
+		{name: "date without occasion", idea: models.GiftIdea{Title: "книга", Status: models.GiftIdeaStatusIdea, OccasionDate: &occasionDate}, wantErr: true},
+		{name: "birthday with event", idea: models.GiftIdea{Title: "книга", Status: models.GiftIdeaStatusIdea, Occasion: models.GiftOccasionBirthday, EventID: uuid.NullUUID{UUID: uuid.New(), Valid: true}}, wantErr: true},
+		{name: "event occasion without event", idea: models.GiftIdea{Title: "книга", Status: models.GiftIdeaStatusIdea, Occasion: models.GiftOccasionEvent}, wantErr: true},
+		{name: "unknown occasion", idea: models.GiftIdea{Title: "книга", Status: models.GiftIdeaStatusIdea, Occasion: "wedding"}, wantErr: true},
+	}
+
+	service := NewGiftIdeaService(nil, nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := service.validate(uuid.New(), tt.idea); (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lunovoy/friendly/internal/models"
+	"github.com/lunovoy/friendly/internal/repository"
+	"github.com/lunovoy/friendly/internal/storage"
+)
+
+// ImagePrefix is the storage key prefix of uploaded images and their
+// variants.
+const ImagePrefix = "images/"
+
+// ImageExtensions are extensions the uploaded images may be stored with.
+var ImageExtensions = []string{".jpg", ".jpeg", ".png", ".heic", ".HEIC", ".heif"}
+
+// ImageVariantSizes is the ladder of sizes resized images are cached for,
+// requested resolutions are snapped to it.
+var ImageVariantSizes = []int{64, 128, 256, 512, 1024}
+
+func ImageKey(imageID uuid.UUID, ext string) string {
+	return ImagePrefix + imageID.String() + ext
+}
+
+func ImageVariantKey(imageID uuid.UUID, size int) string {
+	return fmt.Sprintf("%svariants/%s/%d.jpg", ImagePrefix, imageID, size)
+}
+
+type ImageService struct {
+	repo    repository.Image
+	storage storage.Storage
+}
+
+func NewImageService(repo repository.Image, storage storage.Storage) *ImageService {
+	return &ImageService{
+		repo:    repo,
+		storage: storage,
+	}
+}
+
+func (s *ImageService) Create(userID uuid.UUID, image models.Image) error {
+	if image.ID == uuid.Nil {
+		return errors.New("image id must not be empty")
+	}
+	if image.MimeType == "" {
+		return errors.New("mime type must not be empty")
+	}
+	if image.Width <= 0 || image.Height <= 0 {
+		return errors.New("image dimensions must be positive")
+	}
+
+	return s.repo.Create(userID, image)
+}
+
+func (s *ImageService) GetByID(userID, imageID uuid.UUID) (models.Image, error) {
+	return s.repo.GetByID(userID, imageID)
+}
+
+func (s *ImageService) GetShared(userID, imageID uuid.UUID) (models.Image, error) {
+	return s.repo.GetShared(userID, imageID)
+}
+
+// DeleteByID deletes the image of the user together with its files.
+func (s *ImageService) DeleteByID(ctx context.Context, userID, imageID uuid.UUID) error {
+	if _, err := s.repo.GetByID(userID, imageID); err != nil {
+		return err
+	}
+	if err := s.deleteFiles(ctx, imageID); err != nil {
+		return err
+	}
+	return s.repo.DeleteByID(userID, imageID)
+}
+
+// Release is called when the image of the user was replaced, the image is
+// deleted with its files unless something still refers to it. Images of
+// other users are left to their owners.
+func (s *ImageService) Release(ctx context.Context, userID, imageID uuid.UUID) error {
+	if imageID == uuid.Nil {
+		return nil
+	}
+	if _, err := s.repo.GetByID(userID, imageID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+	return s.DeleteUnreferenced(ctx, []uuid.UUID{imageID})
+}
+
+// DeleteUnreferenced deletes those of the images nothing refers to anymore
+// together with their files.
+func (s *ImageService) DeleteUnreferenced(ctx context.Context, imageIDs []uuid.UUID) error {
+	if len(imageIDs) == 0 {
+		return nil
+	}
+
+	deleted, err := s.repo.DeleteUnreferenced(imageIDs)
+	if err != nil {
+		return err
+	}
+	for _, imageID := range deleted {
+		if err := s.deleteFiles(ctx, imageID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteFiles removes the image stored with any of the extensions and its
+// cached variants.
+func (s *ImageService) deleteFiles(ctx context.Context, imageID uuid.UUID) error {
+	for _, ext := range ImageExtensions {
+		if err := s.storage.Delete(ctx, ImageKey(imageID, ext)); err != nil {
+			return fmt.Errorf("unable to delete file: %w", err)
+		}
+	}
+	for _, size := range ImageVariantSizes {
+		if err := s.storage.Delete(ctx, ImageVariantKey(imageID, size)); err != nil {
+			return fmt.Errorf("unable to delete file: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/lunovoy/friendly/internal/models"
+	"github.com/lunovoy/friendly/internal/repository"
+	"github.com/lunovoy/friendly/internal/storage"
+)
+
+// fakeImageRepo keeps image owners, referenced images are never deleted as
+// unreferenced.
+type fakeImageRepo struct {
+	repository.Image
+	owners     map[uuid.UUID]uuid.UUID
+	referenced map[uuid.UUID]bool
+}
+
+func (r *fakeImageRepo) Create(userID uuid.UUID, image models.Image) error {
+	r.owners[image.ID] = userID
+	return nil
+}
+
+func (r *fakeImageRepo) GetByID(userID, imageID uuid.UUID) (models.Image, error) {
+	if owner, ok := r.owners[imageID]; !ok || owner != userID {
+		return models.Image{}, sql.ErrNoRows
+	}
+	return models.Image{ID: imageID, UserID: userID}, nil
+}
+
+func (r *fakeImageRepo) DeleteByID(userID, imageID uuid.UUID) error {
+	delete(r.owners, imageID)
+	return nil
+}
+
+func (r *fakeImageRepo) DeleteUnreferenced(imageIDs []uuid.UUID) ([]uuid.UUID, error) {
+	var deleted []uuid.UUID
+	for _, imageID := range imageIDs {
+		if _, ok := r.owners[imageID]; ok && !r.referenced[imageID] {
+			delete(r.owners, imageID)
+			deleted = append(deleted, imageID)
+		}
+	}
+	return deleted, nil
+}
+
+func TestImageServiceCreate(t *testing.T) {
+	tests := []struct {
+		name    string
+		image   models.Image
+		wantErr bool
+	}{
+		{name: "valid", image: models.Image{ID: uuid.New(), MimeType: "image/jpeg", Width: 10, Height: 20}},
+		{name: "no id", image: models.Image{MimeType: "image/jpeg", Width: 10, Height: 20}, wantErr: true},
+		{name: "no mime type", image: models.Image{ID: uuid.New(), Width: 10, Height: 20}, wantErr: true},
+		{name: "no dimensions", image: models.Image{ID: uuid.New(), MimeType: "image/jpeg"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeImageRepo{owners: map[uuid.UUID]uuid.UUID{}}
+			err := NewImageService(repo, nil).Create(uuid.New(), tt.image)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Create() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if _, created := repo.owners[tt.image.ID]; created == tt.wantErr {
+				t.Errorf("image created = %v, want %v", created, !tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestImageServiceDelete(t *testing.T) {
+	userID := uuid.New()
+	deleteUnreferenced := func(s *ImageService, ctx context.Context, userID, imageID uuid.UUID) error {
+		return s.DeleteUnreferenced(ctx, []uuid.UUID{imageID, uuid.New()})
+	}
+
+	tests := []struct {
+		name       string
+		owner      uuid.UUID
+		referenced bool
+		delete     func(s *ImageService, ctx context.Context, userID, imageID uuid.UUID) error
+		wantErr    error
+		wantKept   bool
+	}{
+		{name: "delete own image", owner: userID, delete: (*ImageService).DeleteByID},
+		{name: "delete referenced image", owner: userID, referenced: true, delete: (*ImageService).DeleteByID},
+		{name: "delete image of another user", owner: uuid.New(), delete: (*ImageService).DeleteByID, wantErr: sql.ErrNoRows, wantKept: true},
+		{name: "release unreferenced image", owner: userID, delete: (*ImageService).Release},
+		{name: "release referenced image", owner: userID, referenced: true, delete: (*ImageService).Release, wantKept: true},
+		{name: "release image of another user", owner: uuid.New(), delete: (*ImageService).Release, wantKept: true},
+		{name: "unreferenced image of any user", owner: uuid.New(), delete: deleteUnreferenced},
+		{name: "referenced image as unreferenced", owner: userID, referenced: true, delete: deleteUnreferenced, wantKept: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			imageID := uuid.New()
+			repo := &fakeImageRepo{
+				owners:     map[uuid.UUID]uuid.UUID{imageID: tt.owner},
+				referenced: map[uuid.UUID]bool{imageID: tt.referenced},
+			}
+			store := storage.NewLocal(t.TempDir())
+			keys := []string{ImageKey(imageID, ".jpg"), ImageVariantKey(imageID, 64)}
+			for _, key := range keys {
+				if err := store.Put(ctx, key, strings.NewReader("image"), "image/jpeg"); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if err := tt.delete(NewImageService(repo, store), ctx, userID, imageID); !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+			if _, kept := repo.owners[imageID]; kept != tt.wantKept {
+				t.Errorf("image kept = %v, want %v", kept, tt.wantKept)
+			}
+			for _, key := range keys {
+				if _, err := store.Stat(ctx, key); (err == nil) != tt.wantKept {
+					t.Errorf("file %s kept = %v, want %v", key, err == nil, tt.wantKept)
+				}
+			}
+		})
+	}
+
+	if err := NewImageService(nil, nil).Release(context.Background(), userID, uuid.Nil); err != nil {
+		t.Errorf("Release() of no image error = %v", err)
+	}
+}
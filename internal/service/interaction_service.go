@@ -0,0 +1,93 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lunovoy/friendly/internal/models"
+	"github.com/lunovoy/friendly/internal/repository"
+)
+
+var interactionKinds = map[string]bool{
+	models.InteractionKindCall:    true,
+	models.InteractionKindMessage: true,
+	models.InteractionKindMeeting: true,
+	models.InteractionKindNote:    true,
+}
+
+type InteractionService struct {
+	repo      repository.Interaction
+	eventRepo repository.Event
+}
+
+func NewInteractionService(repo repository.Interaction, eventRepo repository.Event) *InteractionService {
+	return &InteractionService{
+		repo:      repo,
+		eventRepo: eventRepo,
+	}
+}
+
+func (s *InteractionService) Create(userID, friendID uuid.UUID, interaction models.Interaction) (uuid.UUID, error) {
+	if interaction.OccurredAt.IsZero() {
+		interaction.OccurredAt = time.Now()
+	}
+	if err := s.validate(userID, interaction); err != nil {
+		return uuid.Nil, err
+	}
+	interaction.FriendID = friendID
+
+	return s.repo.Create(userID, interaction)
+}
+
+func (s *InteractionService) GetAllByFriendID(userID, friendID uuid.UUID) ([]models.Interaction, error) {
+	return s.repo.GetAllByFriendID(userID, friendID)
+}
+
+func (s *InteractionService) GetByID(userID, friendID, interactionID uuid.UUID) (models.Interaction, error) {
+	return s.repo.GetByID(userID, friendID, interactionID)
+}
+
+func (s *InteractionService) Update(userID, friendID, interactionID uuid.UUID, update models.InteractionUpdate) error {
+	interaction, err := s.repo.GetByID(userID, friendID, interactionID)
+	if err != nil {
+		return err
+	}
+
+	if update.Kind != nil {
+		interaction.Kind = *update.Kind
+	}
+	if update.OccurredAt != nil {
+		interaction.OccurredAt = *update.OccurredAt
+	}
+	if update.Channel != nil {
+		interaction.Channel = *update.Channel
+	}
+	if update.Text != nil {
+		interaction.Text = *update.Text
+	}
+	if update.EventID != nil {
+		interaction.EventID = *update.EventID
+	}
+	if err := s.validate(userID, interaction); err != nil {
+		return err
+	}
+
+	return s.repo.Update(userID, interactionID, interaction)
+}
+
+func (s *InteractionService) DeleteByID(userID, interactionID uuid.UUID) error {
+	return s.repo.DeleteByID(userID, interactionID)
+}
+
+func (s *InteractionService) validate(userID uuid.UUID, interaction models.Interaction) error {
+	if !interactionKinds[interaction.Kind] {
+		return fmt.Errorf("kind %s is not supported", interaction.Kind)
+	}
+	if interaction.EventID.Valid {
+		if _, err := s.eventRepo.GetByID(userID, interaction.EventID.UUID); err != nil {
+			return fmt.Errorf("event not found: %w", err)
+		}
+	}
+	return nil
+}
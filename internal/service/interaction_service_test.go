@@ -0,0 +1,33 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/lunovoy/friendly/internal/models"
+)
+
+func TestInteractionServiceValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		kind    string
+		wantErr bool
+	}{
+		{name: "call", kind: models.InteractionKindCall},
+		{name: "message", kind: models.InteractionKindMessage},
+		{name: "meeting", kind: models.InteractionKindMeeting},
+		{name: "note", kind: models.InteractionKindNote},
+		{name: "unknown kind", kind: "letter", wantErr: true},
+		{name: "empty kind", kind: "", wantErr: true},
+	}
+
+	s := NewInteractionService(nil, nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := s.validate(uuid.New(), models.Interaction{Kind: tt.kind})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lunovoy/friendly/internal/models"
+	"github.com/lunovoy/friendly/internal/repository"
+)
+
+const maxContactIntervalDays = 365
+
+type KeepInTouchService struct {
+	friendRepo      repository.Friend
+	eventRepo       repository.Event
+	interactionRepo repository.Interaction
+}
+
+func NewKeepInTouchService(friendRepo repository.Friend, eventRepo repository.Event, interactionRepo repository.Interaction) *KeepInTouchService {
+	return &KeepInTouchService{
+		friendRepo:      friendRepo,
+		eventRepo:       eventRepo,
+		interactionRepo: interactionRepo,
+	}
+}
+
+// GetOverdue returns friends whose keep-in-touch cadence has elapsed since
+// the last contact, the latest first. Friends never contacted go first.
+func (s *KeepInTouchService) GetOverdue(userID uuid.UUID, now time.Time) ([]models.OverdueFriend, error) {
+	cadences, err := s.friendRepo.GetContactCadences(userID)
+	if err != nil {
+		return nil, err
+	}
+	overdue := make([]models.OverdueFriend, 0)
+	if len(cadences) == 0 {
+		return overdue, nil
+	}
+
+	lastContacts, err := s.lastContacts(userID, cadences, now)
+	if err != nil {
+		return nil, err
+	}
+
+	friends, err := s.friendRepo.GetAll(userID)
+	if err != nil {
+		return nil, err
+	}
+	friendsByID := make(map[uuid.UUID]models.Friend, len(friends))
+	for _, friend := range friends {
+		friendsByID[friend.Friend.ID] = friend.Friend
+	}
+
+	for _, cadence := range cadences {
+		item := models.OverdueFriend{
+			Friend:              friendsByID[cadence.FriendID],
+			ContactIntervalDays: cadence.ContactIntervalDays,
+		}
+		if lastContact, ok := lastContacts[cadence.FriendID]; ok {
+			dueAt := lastContact.AddDate(0, 0, cadence.ContactIntervalDays)
+			if dueAt.After(now) {
+				continue
+			}
+			item.LastContactedAt = &lastContact
+			item.DueAt = &dueAt
+			item.OverdueDays = int(now.Sub(dueAt).Hours() / 24)
+		}
+		overdue = append(overdue, item)
+	}
+
+	sort.SliceStable(overdue, func(i, j int) bool {
+		if overdue[i].DueAt == nil || overdue[j].DueAt == nil {
+			return overdue[i].DueAt == nil && overdue[j].DueAt != nil
+		}
+		return overdue[i].DueAt.Before(*overdue[j].DueAt)
+	})
+
+	return overdue, nil
+}
+
+func (s *KeepInTouchService) MarkContacted(userID, friendID uuid.UUID, at time.Time) error {
+	if at.After(time.Now()) {
+		return errors.New("contact time must not be in the future")
+	}
+	return s.friendRepo.MarkContacted(userID, friendID, at)
+}
+
+// lastContacts returns the latest contact per friend, taken from the manual
+// mark, logged interactions and past occurrences of events the friend attends.
+func (s *KeepInTouchService) lastContacts(userID uuid.UUID, cadences []models.ContactCadence, now time.Time) (map[uuid.UUID]time.Time, error) {
+	lastContacts := make(map[uuid.UUID]time.Time, len(cadences))
+	for _, cadence := range cadences {
+		if cadence.LastContactedAt.Valid {
+			lastContacts[cadence.FriendID] = cadence.LastContactedAt.Time
+		}
+	}
+
+	interactions, err := s.interactionRepo.GetLastContacts(userID, now)
+	if err != nil {
+		return nil, err
+	}
+	for _, interaction := range interactions {
+		if lastContact, ok := lastContacts[interaction.FriendID]; !ok || interaction.OccurredAt.After(lastContact) {
+			lastContacts[interaction.FriendID] = interaction.OccurredAt
+		}
+	}
+
+	events, err := s.eventRepo.GetAllWithFriends(userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, event := range events {
+		occurrence, ok := lastEventOccurrence(event.Event, now)
+		if !ok {
+			continue
+		}
+		for _, friend := range event.Friends {
+			if lastContact, ok := lastContacts[friend.ID]; !ok || occurrence.After(lastContact) {
+				lastContacts[friend.ID] = occurrence
+			}
+		}
+	}
+
+	return lastContacts, nil
+}
+
+func validateContactInterval(days *int) error {
+	if days != nil && (*days < 0 || *days > maxContactIntervalDays) {
+		return errors.New("contact interval must be between 1 and 365 days, 0 removes it")
+	}
+	return nil
+}
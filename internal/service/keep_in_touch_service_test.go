@@ -0,0 +1,24 @@
+package service
+
+import "testing"
+
+func TestValidateContactInterval(t *testing.T) {
+	days := func(days int) *int { return &days }
+
+	tests := []struct {
+		days    *int
+		wantErr bool
+	}{
+		{nil, false},
+		{days(0), false},
+		{days(30), false},
+		{days(maxContactIntervalDays), false},
+		{days(maxContactIntervalDays + 1), true},
+		{days(-1), true},
+	}
+	for _, tt := range tests {
+		if err := validateContactInterval(tt.days); (err != nil) != tt.wantErr {
+			t.Errorf("validateContactInterval(%v) error = %v, wantErr %v", tt.days, err, tt.wantErr)
+		}
+	}
+}
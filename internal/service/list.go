@@ -0,0 +1,16 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/lunovoy/friendly/internal/models"
+)
+
+const maxListLimit = 200
+
+func validateListParams(params models.ListParams) error {
+	if params.Limit < 1 || params.Limit > maxListLimit {
+		return errors.New("limit must be between 1 and 200")
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/lunovoy/friendly/internal/models"
+)
+
+func TestValidateListParams(t *testing.T) {
+	tests := []struct {
+		limit   int
+		wantErr bool
+	}{
+		{1, false},
+		{maxListLimit, false},
+		{0, true},
+		{maxListLimit + 1, true},
+	}
+	for _, tt := range tests {
+		if err := validateListParams(models.ListParams{Limit: tt.limit}); (err != nil) != tt.wantErr {
+			t.Errorf("validateListParams(limit %d) error = %v, wantErr %v", tt.limit, err, tt.wantErr)
+		}
+	}
+}
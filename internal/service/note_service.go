@@ -0,0 +1,177 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/lunovoy/friendly/internal/models"
+	"github.com/lunovoy/friendly/internal/repository"
+)
+
+const maxNoteBodyLength = 100000
+
+// noteMentionPattern matches @[label](friend id) mentions in note bodies.
+var noteMentionPattern = regexp.MustCompile(`@\[([^\]]*)\]\(([0-9a-fA-F-]{36})\)`)
+
+// noteLabelReplacer keeps friend names from closing the mention label.
+var noteLabelReplacer = strings.NewReplacer("[", "", "]", "")
+
+type NoteService struct {
+	repo       repository.Note
+	friendRepo repository.Friend
+}
+
+func NewNoteService(repo repository.Note, friendRepo repository.Friend) *NoteService {
+	return &NoteService{
+		repo:       repo,
+		friendRepo: friendRepo,
+	}
+}
+
+func (s *NoteService) Create(userID uuid.UUID, note models.Note) (uuid.UUID, error) {
+	parents := 0
+	for _, id := range []uuid.NullUUID{note.FriendID, note.EventID, note.FriendlistID} {
+		if id.Valid {
+			parents++
+		}
+	}
+	if parents != 1 {
+		return uuid.Nil, errors.New("note must be attached to exactly one friend, event or friendlist")
+	}
+
+	body, mentionIDs, err := s.parseMentions(userID, note.Body)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	note.Body = body
+
+	return s.repo.Create(userID, note, mentionIDs)
+}
+
+func (s *NoteService) GetAll(userID uuid.UUID, filter models.NoteFilter) ([]models.Note, error) {
+	notes, err := s.repo.GetAll(userID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return resolveNoteMentions(s.repo, notes)
+}
+
+func (s *NoteService) GetByID(userID, noteID uuid.UUID) (models.Note, error) {
+	note, err := s.repo.GetByID(userID, noteID)
+	if err != nil {
+		return models.Note{}, err
+	}
+
+	notes, err := resolveNoteMentions(s.repo, []models.Note{note})
+	if err != nil {
+		return models.Note{}, err
+	}
+
+	return notes[0], nil
+}
+
+func (s *NoteService) Update(userID, noteID uuid.UUID, update models.NoteUpdate) error {
+	note, err := s.repo.GetByID(userID, noteID)
+	if err != nil {
+		return err
+	}
+
+	if update.Body != nil {
+		note.Body = *update.Body
+	}
+	body, mentionIDs, err := s.parseMentions(userID, note.Body)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.Update(userID, noteID, body, mentionIDs)
+}
+
+func (s *NoteService) DeleteByID(userID, noteID uuid.UUID) error {
+	return s.repo.DeleteByID(userID, noteID)
+}
+
+// parseMentions validates the body and returns it with mentions in canonical
+// form along with the friends mentioned in it.
+func (s *NoteService) parseMentions(userID uuid.UUID, body string) (string, []uuid.UUID, error) {
+	if strings.TrimSpace(body) == "" {
+		return "", nil, errors.New("body must not be empty")
+	}
+	if len(body) > maxNoteBodyLength {
+		return "", nil, errors.New("body is too long")
+	}
+
+	mentionIDs := make([]uuid.UUID, 0)
+	seen := make(map[uuid.UUID]bool)
+	var err error
+	body = noteMentionPattern.ReplaceAllStringFunc(body, func(match string) string {
+		parts := noteMentionPattern.FindStringSubmatch(match)
+		friendID, parseErr := uuid.Parse(parts[2])
+		if parseErr != nil {
+			err = fmt.Errorf("mention %s is not valid", match)
+			return match
+		}
+		if !seen[friendID] && err == nil {
+			if _, getErr := s.friendRepo.GetByID(userID, friendID); getErr != nil {
+				err = fmt.Errorf("mentioned friend not found: %s", friendID)
+				return match
+			}
+			seen[friendID] = true
+			mentionIDs = append(mentionIDs, friendID)
+		}
+		return fmt.Sprintf("@[%s](%s)", parts[1], friendID)
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	return body, mentionIDs, nil
+}
+
+// resolveNoteMentions attaches mentioned friends to the notes and refreshes
+// mention labels in the bodies with the current friend names.
+func resolveNoteMentions(repo repository.Note, notes []models.Note) ([]models.Note, error) {
+	if len(notes) == 0 {
+		return []models.Note{}, nil
+	}
+
+	noteIDs := make([]uuid.UUID, 0, len(notes))
+	for _, note := range notes {
+		noteIDs = append(noteIDs, note.ID)
+	}
+	mentions, err := repo.GetMentions(noteIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	byNote := make(map[uuid.UUID][]models.NoteMention, len(notes))
+	for _, mention := range mentions {
+		byNote[mention.NoteID] = append(byNote[mention.NoteID], mention)
+	}
+
+	for i := range notes {
+		notes[i].Mentions = byNote[notes[i].ID]
+		if notes[i].Mentions == nil {
+			notes[i].Mentions = []models.NoteMention{}
+		}
+
+		names := make(map[string]string, len(notes[i].Mentions))
+		for _, mention := range notes[i].Mentions {
+			names[mention.FriendID.String()] = noteLabelReplacer.Replace(strings.TrimSpace(mention.FirstName + " " + mention.LastName))
+		}
+		notes[i].Body = noteMentionPattern.ReplaceAllStringFunc(notes[i].Body, func(match string) string {
+			parts := noteMentionPattern.FindStringSubmatch(match)
+			name, ok := names[parts[2]]
+			if !ok {
+				return match
+			}
+			return fmt.Sprintf("@[%s](%s)", name, parts[2])
+		})
+	}
+
+	return notes, nil
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"database/sql"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/lunovoy/friendly/internal/models"
+	"github.com/lunovoy/friendly/internal/repository"
+)
+
+// fakeNoteFriendRepo knows only the friends it was given, the rest of the
+// repository is not used by notes.
+type fakeNoteFriendRepo struct {
+	repository.Friend
+	friends map[uuid.UUID]bool
+}
+
+func (r fakeNoteFriendRepo) GetByID(userID, friendID uuid.UUID) (models.FriendWorkInfoTags, error) {
+	if !r.friends[friendID] {
+		return models.FriendWorkInfoTags{}, sql.ErrNoRows
+	}
+	return models.FriendWorkInfoTags{Friend: models.Friend{ID: friendID}}, nil
+}
+
+type fakeNoteRepo struct {
+	repository.Note
+	mentions []models.NoteMention
+}
+
+func (r fakeNoteRepo) GetMentions(noteIDs []uuid.UUID) ([]models.NoteMention, error) {
+	return r.mentions, nil
+}
+
+func TestNoteServiceParseMentions(t *testing.T) {
+	ivan := uuid.MustParse("6f1c2b1e-3d4a-4b5c-8d9e-0a1b2c3d4e5f")
+	anna := uuid.MustParse("7a2d3c4b-5e6f-4a7b-9c8d-1e2f3a4b5c6d")
+	stranger := uuid.MustParse("8b3e4d5c-6f7a-4b8c-ad9e-2f3a4b5c6d7e")
+	service := NewNoteService(nil, fakeNoteFriendRepo{friends: map[uuid.UUID]bool{ivan: true, anna: true}})
+
+	tests := []struct {
+		name         string
+		body         string
+		wantBody     string
+		wantMentions []uuid.UUID
+		wantErr      bool
+	}{
+		{
+			name:         "no mentions",
+			body:         "Позвонить в субботу",
+			wantBody:     "Позвонить в субботу",
+			wantMentions: []uuid.UUID{},
+		},
+		{
+			name:         "mentions are canonical and unique",
+			body:         "Ужин с @[Ваней](" + strings.ToUpper(ivan.String()) + ") и @[Аней](" + anna.String() + "), @[Ваня](" + ivan.String() + ") платит",
+			wantBody:     "Ужин с @[Ваней](" + ivan.String() + ") и @[Аней](" + anna.String() + "), @[Ваня](" + ivan.String() + ") платит",
+			wantMentions: []uuid.UUID{ivan, anna},
+		},
+		{
+			name:         "plain brackets are kept",
+			body:         "@[не упоминание] (скоро)",
+			wantBody:     "@[не упоминание] (скоро)",
+			wantMentions: []uuid.UUID{},
+		},
+		{
+			name:    "unknown friend",
+			body:    "@[Кто-то](" + stranger.String() + ")",
+			wantErr: true,
+		},
+		{
+			name:    "invalid id",
+			body:    "@[Кто-то](" + strings.Repeat("-", 36) + ")",
+			wantErr: true,
+		},
+		{
+			name:    "blank body",
+			body:    " \n",
+			wantErr: true,
+		},
+		{
+			name:    "too long body",
+			body:    strings.Repeat("a", maxNoteBodyLength+1),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, mentions, err := service.parseMentions(uuid.New(), tt.body)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseMentions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if body != tt.wantBody {
+				t.Errorf("body = %q, want %q", body, tt.wantBody)
+			}
+			if !reflect.DeepEqual(mentions, tt.wantMentions) {
+				t.Errorf("mentions = %v, want %v", mentions, tt.wantMentions)
+			}
+		})
+	}
+}
+
+func TestResolveNoteMentions(t *testing.T) {
+	ivan := uuid.MustParse("6f1c2b1e-3d4a-4b5c-8d9e-0a1b2c3d4e5f")
+	deleted := uuid.MustParse("7a2d3c4b-5e6f-4a7b-9c8d-1e2f3a4b5c6d")
+	note := models.Note{ID: uuid.New(), Body: "Ужин с @[Ваней](" + ivan.String() + ") и @[Аней](" + deleted.String() + ")"}
+	other := models.Note{ID: uuid.New(), Body: "Без упоминаний"}
+
+	repo := fakeNoteRepo{mentions: []models.NoteMention{
+		{NoteID: note.ID, FriendID: ivan, FirstName: "Иван", LastName: "[Петров]"},
+	}}
+	notes, err := resolveNoteMentions(repo, []models.Note{note, other})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "Ужин с @[Иван Петров](" + ivan.String() + ") и @[Аней](" + deleted.String() + ")"; notes[0].Body != want {
+		t.Errorf("body = %q, want %q", notes[0].Body, want)
+	}
+	if len(notes[0].Mentions) != 1 || notes[0].Mentions[0].FriendID != ivan {
+		t.Errorf("mentions = %+v, want %s", notes[0].Mentions, ivan)
+	}
+	if notes[1].Mentions == nil || len(notes[1].Mentions) != 0 {
+		t.Errorf("mentions of a note without them = %#v, want an empty list", notes[1].Mentions)
+	}
+
+	notes, err = resolveNoteMentions(repo, nil)
+	if err != nil || notes == nil {
+		t.Errorf("resolveNoteMentions() of no notes = %v, %v, want an empty list", notes, err)
+	}
+}
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lunovoy/friendly/internal/models"
+	"github.com/lunovoy/friendly/internal/repository"
+)
+
+var notificationChannels = map[string]bool{
+	"telegram": true,
+}
+
+// maxReminderOffsetMinutes is 30 days, the longest reminder offset for
+// birthdays and important dates.
+const maxReminderOffsetMinutes = 30 * 24 * 60
+
+type NotificationPreferenceService struct {
+	repo repository.NotificationPreference
+}
+
+func NewNotificationPreferenceService(repo repository.NotificationPreference) *NotificationPreferenceService {
+	return &NotificationPreferenceService{
+		repo: repo,
+	}
+}
+
+func (s *NotificationPreferenceService) Get(userID uuid.UUID) (models.NotificationPreference, error) {
+	preference, err := s.repo.GetByUserID(userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.NotificationPreference{
+			Channels:        []string{"telegram"},
+			Timezone:        "UTC",
+			Birthdays:       true,
+			Events:          true,
+			Digests:         true,
+			KeepInTouch:     true,
+			BirthdayMinutes: []int64{0},
+			UserID:          userID,
+		}, nil
+	}
+	return preference, err
+}
+
+func (s *NotificationPreferenceService) Update(userID uuid.UUID, update models.NotificationPreferenceUpdate) error {
+	preference, err := s.Get(userID)
+	if err != nil {
+		return err
+	}
+
+	if update.Channels != nil {
+		for _, channel := range update.Channels {
+			if !notificationChannels[channel] {
+				return fmt.Errorf("channel %s is not supported", channel)
+			}
+		}
+		preference.Channels = update.Channels
+	}
+	if update.QuietHoursStart != nil {
+		preference.QuietHoursStart = *update.QuietHoursStart
+	}
+	if update.QuietHoursEnd != nil {
+		preference.QuietHoursEnd = *update.QuietHoursEnd
+	}
+	for _, clock := range []string{preference.QuietHoursStart, preference.QuietHoursEnd} {
+		if clock == "" {
+			continue
+		}
+		if _, err := time.Parse("15:04", clock); err != nil {
+			return errors.New("quiet hours must be in HH:MM format")
+		}
+	}
+	if update.Timezone != nil {
+		if _, err := time.LoadLocation(*update.Timezone); err != nil {
+			return fmt.Errorf("timezone is not valid: %w", err)
+		}
+		preference.Timezone = *update.Timezone
+	}
+	if update.Birthdays != nil {
+		preference.Birthdays = *update.Birthdays
+	}
+	if update.Events != nil {
+		preference.Events = *update.Events
+	}
+	if update.Digests != nil {
+		preference.Digests = *update.Digests
+	}
+	if update.KeepInTouch != nil {
+		preference.KeepInTouch = *update.KeepInTouch
+	}
+	if update.BirthdayMinutes != nil {
+		for _, minutes := range update.BirthdayMinutes {
+			if minutes < 0 || minutes > maxReminderOffsetMinutes {
+				return errors.New("birthday reminder minutes must be between 0 and 43200")
+			}
+		}
+		preference.BirthdayMinutes = update.BirthdayMinutes
+	}
+
+	return s.repo.Save(userID, preference)
+}
+
+func isCategoryEnabled(preference models.NotificationPreference, category string) bool {
+	switch category {
+	case models.NotificationCategoryBirthdays:
+		return preference.Birthdays
+	case models.NotificationCategoryEvents:
+		return preference.Events
+	case models.NotificationCategoryDigests:
+		return preference.Digests
+	case models.NotificationCategoryKeepInTouch:
+		return preference.KeepInTouch
+	}
+	return true
+}
+
+func isChannelEnabled(preference models.NotificationPreference, channel string) bool {
+	for _, enabled := range preference.Channels {
+		if enabled == channel {
+			return true
+		}
+	}
+	return false
+}
+
+// quietHoursEnd returns the end of the quiet window when now falls inside it.
+// Windows crossing midnight (e.g. 22:00-08:00) are supported.
+func quietHoursEnd(preference models.NotificationPreference, now time.Time) (time.Time, bool, error) {
+	if preference.QuietHoursStart == "" || preference.QuietHoursEnd == "" || preference.QuietHoursStart == preference.QuietHoursEnd {
+		return time.Time{}, false, nil
+	}
+
+	loc, err := time.LoadLocation(preference.Timezone)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	startClock, err := time.Parse("15:04", preference.QuietHoursStart)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	endClock, err := time.Parse("15:04", preference.QuietHoursEnd)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+
+	local := now.In(loc)
+	start := time.Date(local.Year(), local.Month(), local.Day(), startClock.Hour(), startClock.Minute(), 0, 0, loc)
+	end := time.Date(local.Year(), local.Month(), local.Day(), endClock.Hour(), endClock.Minute(), 0, 0, loc)
+
+	if start.Before(end) {
+		if !local.Before(start) && local.Before(end) {
+			return end, true, nil
+		}
+		return time.Time{}, false, nil
+	}
+
+	if !local.Before(start) {
+		return end.AddDate(0, 0, 1), true, nil
+	}
+	if local.Before(end) {
+		return end, true, nil
+	}
+	return time.Time{}, false, nil
+}
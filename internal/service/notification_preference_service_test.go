@@ -0,0 +1,123 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lunovoy/friendly/internal/models"
+)
+
+func TestQuietHoursEnd(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		start, end string
+		now        time.Time
+		wantEnd    time.Time
+		wantQuiet  bool
+	}{
+		{
+			name:  "no quiet hours",
+			start: "",
+			end:   "",
+			now:   time.Date(2024, time.March, 10, 20, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "empty window",
+			start: "22:00",
+			end:   "22:00",
+			now:   time.Date(2024, time.March, 10, 19, 30, 0, 0, time.UTC),
+		},
+		{
+			name:      "before midnight of a window crossing it",
+			start:     "22:00",
+			end:       "08:00",
+			now:       time.Date(2024, time.March, 10, 20, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2024, time.March, 11, 8, 0, 0, 0, moscow),
+			wantQuiet: true,
+		},
+		{
+			name:      "after midnight of a window crossing it",
+			start:     "22:00",
+			end:       "08:00",
+			now:       time.Date(2024, time.March, 11, 2, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2024, time.March, 11, 8, 0, 0, 0, moscow),
+			wantQuiet: true,
+		},
+		{
+			name:  "outside a window crossing midnight",
+			start: "22:00",
+			end:   "08:00",
+			now:   time.Date(2024, time.March, 11, 6, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "within a daytime window",
+			start:     "13:00",
+			end:       "15:00",
+			now:       time.Date(2024, time.March, 11, 11, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2024, time.March, 11, 15, 0, 0, 0, moscow),
+			wantQuiet: true,
+		},
+		{
+			name:  "end of a daytime window",
+			start: "13:00",
+			end:   "15:00",
+			now:   time.Date(2024, time.March, 11, 12, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			preference := models.NotificationPreference{QuietHoursStart: tt.start, QuietHoursEnd: tt.end, Timezone: "Europe/Moscow"}
+			end, quiet, err := quietHoursEnd(preference, tt.now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if quiet != tt.wantQuiet || !end.Equal(tt.wantEnd) {
+				t.Errorf("quietHoursEnd() = %s, %v, want %s, %v", end, quiet, tt.wantEnd, tt.wantQuiet)
+			}
+		})
+	}
+
+	preference := models.NotificationPreference{QuietHoursStart: "22:00", QuietHoursEnd: "08:00", Timezone: "Mars/Olympus"}
+	if _, _, err := quietHoursEnd(preference, time.Now()); err == nil {
+		t.Error("quietHoursEnd() with unknown timezone succeeded, want error")
+	}
+}
+
+func TestIsCategoryEnabled(t *testing.T) {
+	preference := models.NotificationPreference{Birthdays: true, Digests: true}
+
+	tests := []struct {
+		category string
+		want     bool
+	}{
+		{models.NotificationCategoryBirthdays, true},
+		{models.NotificationCategoryEvents, false},
+		{models.NotificationCategoryDigests, true},
+		{"unknown", true},
+	}
+	for _, tt := range tests {
+		if got := isCategoryEnabled(preference, tt.category); got != tt.want {
+			t.Errorf("isCategoryEnabled(%s) = %v, want %v", tt.category, got, tt.want)
+		}
+	}
+}
+
+func TestIsChannelEnabled(t *testing.T) {
+	preference := models.NotificationPreference{Channels: []string{"telegram"}}
+
+	if !isChannelEnabled(preference, "telegram") {
+		t.Error("isChannelEnabled(telegram) = false, want true")
+	}
+	if isChannelEnabled(preference, "email") {
+		t.Error("isChannelEnabled(email) = true, want false")
+	}
+	if isChannelEnabled(models.NotificationPreference{}, "telegram") {
+		t.Error("isChannelEnabled() without channels = true, want false")
+	}
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lunovoy/friendly/internal/models"
+	"github.com/lunovoy/friendly/internal/repository"
+)
+
+const telegramAPIURL = "https://api.telegram.org"
+
+type Notifier interface {
+	Channel() string
+	Notify(userID uuid.UUID, notification models.Notification) error
+}
+
+type TelegramNotifier struct {
+	token  string
+	repo   repository.TgChat
+	client *http.Client
+}
+
+func NewTelegramNotifier(token string, repo repository.TgChat) *TelegramNotifier {
+	return &TelegramNotifier{
+		token:  token,
+		repo:   repo,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (n *TelegramNotifier) Channel() string {
+	return "telegram"
+}
+
+type telegramButton struct {
+	Text         string `json:"text"`
+	CallbackData string `json:"callback_data"`
+}
+
+type telegramReplyMarkup struct {
+	InlineKeyboard [][]telegramButton `json:"inline_keyboard"`
+}
+
+type telegramMessage struct {
+	ChatID      int64                `json:"chat_id"`
+	Text        string               `json:"text"`
+	ReplyMarkup *telegramReplyMarkup `json:"reply_markup,omitempty"`
+}
+
+var telegramActionTitles = map[string]string{
+	models.NotificationActionAcknowledge: "Готово",
+	models.NotificationActionSnooze:      "Отложить",
+	models.NotificationActionDismiss:     "Скрыть",
+}
+
+// Notify sends the notification to the user's telegram chat. Action buttons
+// carry "reminder:<action>:<delivery_id>" callbacks so the bot applies them
+// through the same delivery actions as the HTTP API.
+func (n *TelegramNotifier) Notify(userID uuid.UUID, notification models.Notification) error {
+	chat, err := n.repo.GetByUserID(userID)
+	if err != nil {
+		return fmt.Errorf("telegram chat not found: %w", err)
+	}
+
+	message := telegramMessage{
+		ChatID: chat.ChatID,
+		Text:   fmt.Sprintf("%s\n%s", notification.Title, notification.Text),
+	}
+	if notification.DeliveryID != uuid.Nil && len(notification.Actions) != 0 {
+		var buttons []telegramButton
+		for _, action := range notification.Actions {
+			buttons = append(buttons, telegramButton{
+				Text:         telegramActionTitles[action],
+				CallbackData: fmt.Sprintf("reminder:%s:%s", action, notification.DeliveryID),
+			})
+		}
+		message.ReplyMarkup = &telegramReplyMarkup{
+			InlineKeyboard: [][]telegramButton{buttons},
+		}
+	}
+
+	body, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	resp, err := n.client.Post(fmt.Sprintf("%s/bot%s/sendMessage", telegramAPIURL, n.token), "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("telegram responded with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
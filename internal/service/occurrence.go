@@ -0,0 +1,198 @@
+package service
+
+import (
+	"time"
+
+	"github.com/lunovoy/friendly/internal/models"
+)
+
+// eventOccurrences returns start times of event occurrences within [from, to].
+func eventOccurrences(event models.Event, from, to time.Time) []time.Time {
+	if !event.StartDate.Valid || to.Before(from) {
+		return nil
+	}
+	start := event.StartDate.Time
+
+	var occurrences []time.Time
+	switch event.Frequency {
+	case "everyday", "weekdays":
+		occurrence := start
+		if start.Before(from) {
+			occurrence = start.AddDate(0, 0, int(from.Sub(start).Hours()/24))
+		}
+		for ; !occurrence.After(to); occurrence = occurrence.AddDate(0, 0, 1) {
+			if occurrence.Before(from) {
+				continue
+			}
+			if event.Frequency == "weekdays" && (occurrence.Weekday() == time.Saturday || occurrence.Weekday() == time.Sunday) {
+				continue
+			}
+			occurrences = append(occurrences, occurrence)
+		}
+	case "weekly":
+		occurrence := start
+		if start.Before(from) {
+			occurrence = start.AddDate(0, 0, 7*int(from.Sub(start).Hours()/(24*7)))
+		}
+		for ; !occurrence.After(to); occurrence = occurrence.AddDate(0, 0, 7) {
+			if !occurrence.Before(from) {
+				occurrences = append(occurrences, occurrence)
+			}
+		}
+	case "monthlyDate", "monthlyDay":
+		months := 0
+		if start.Before(from) {
+			months = monthsBetween(start, from) - 1
+		}
+		for ; ; months++ {
+			var occurrence time.Time
+			if event.Frequency == "monthlyDate" {
+				occurrence = addMonthsClamped(start, months)
+			} else {
+				occurrence = sameWeekdayOfMonth(start, months)
+			}
+			if occurrence.After(to) {
+				break
+			}
+			if !occurrence.Before(from) && !occurrence.Before(start) {
+				occurrences = append(occurrences, occurrence)
+			}
+		}
+	case "annually":
+		years := 0
+		if start.Before(from) {
+			years = from.Year() - start.Year() - 1
+		}
+		for ; ; years++ {
+			occurrence := addMonthsClamped(start, 12*years)
+			if occurrence.After(to) {
+				break
+			}
+			if !occurrence.Before(from) && !occurrence.Before(start) {
+				occurrences = append(occurrences, occurrence)
+			}
+		}
+	default:
+		if !start.Before(from) && !start.After(to) {
+			occurrences = append(occurrences, start)
+		}
+	}
+
+	return occurrences
+}
+
+func monthsBetween(from, to time.Time) int {
+	return (to.Year()-from.Year())*12 + int(to.Month()) - int(from.Month())
+}
+
+// addMonthsClamped adds months keeping the day of month, falling back to the
+// last day when the target month is shorter (Jan 31 -> Feb 28, Feb 29 -> Feb 28).
+func addMonthsClamped(t time.Time, months int) time.Time {
+	firstOfMonth := time.Date(t.Year(), t.Month(), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location()).AddDate(0, months, 0)
+	day := t.Day()
+	if lastDay := firstOfMonth.AddDate(0, 1, -1).Day(); day > lastDay {
+		day = lastDay
+	}
+	return firstOfMonth.AddDate(0, 0, day-1)
+}
+
+// sameWeekdayOfMonth keeps the position of the weekday within the month
+// (e.g. 4th Tuesday), using the last such weekday when the month has fewer.
+func sameWeekdayOfMonth(t time.Time, months int) time.Time {
+	firstOfMonth := time.Date(t.Year(), t.Month(), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location()).AddDate(0, months, 0)
+	week := (t.Day() - 1) / 7
+	offset := (int(t.Weekday()) - int(firstOfMonth.Weekday()) + 7) % 7
+	occurrence := firstOfMonth.AddDate(0, 0, offset+7*week)
+	if occurrence.Month() != firstOfMonth.Month() {
+		occurrence = occurrence.AddDate(0, 0, -7)
+	}
+	return occurrence
+}
+
+// nextAnniversary returns the first anniversary of date falling on or after
+// the day of from, in the location of from.
+func nextAnniversary(date, from time.Time) time.Time {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, from.Location())
+	today := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location())
+
+	occurrence := addMonthsClamped(day, 12*(today.Year()-day.Year()))
+	if occurrence.Before(today) {
+		occurrence = addMonthsClamped(day, 12*(today.Year()-day.Year()+1))
+	}
+	return occurrence
+}
+
+// anniversaryHour is the local time birthdays and other yearly dates start
+// at, so that reminders without offset don't fire at midnight.
+const anniversaryHour = 9
+
+// anniversaryOccurrences returns anniversaries of date within [from, to],
+// in the location of from.
+func anniversaryOccurrences(date, from, to time.Time) []time.Time {
+	var occurrences []time.Time
+	for day := nextAnniversary(date, from.AddDate(0, 0, -1)); ; day = nextAnniversary(date, day.AddDate(0, 0, 1)) {
+		occurrence := day.Add(anniversaryHour * time.Hour)
+		if occurrence.After(to) {
+			break
+		}
+		if !occurrence.Before(from) && occurrence.Year() > date.Year() {
+			occurrences = append(occurrences, occurrence)
+		}
+	}
+	return occurrences
+}
+
+// friendDateOccurrences returns occurrences of an important date within
+// [from, to], in the location of from. Dates without a year are anchored
+// to year 0, a leap year, so Feb 29 is kept.
+func friendDateOccurrences(date models.FriendDate, from, to time.Time) []time.Time {
+	year := 0
+	if date.Year != nil {
+		year = *date.Year
+	}
+	anchor := time.Date(year, time.Month(date.Month), date.Day, 0, 0, 0, 0, from.Location())
+
+	if date.Recurrence == models.FriendDateRecurrenceOnce {
+		occurrence := anchor.Add(anniversaryHour * time.Hour)
+		if date.Year == nil || occurrence.Before(from) || occurrence.After(to) {
+			return nil
+		}
+		return []time.Time{occurrence}
+	}
+
+	return anniversaryOccurrences(anchor, from, to)
+}
+
+// friendDateYears returns how many years have passed since the date at the
+// given occurrence, zero when the year is unknown.
+func friendDateYears(date models.FriendDate, occurrence time.Time) int {
+	if date.Year == nil {
+		return 0
+	}
+	return occurrence.Year() - *date.Year
+}
+
+// lastEventOccurrence returns the latest occurrence of event starting at or
+// before now. The lookback covers the longest gap between two occurrences.
+func lastEventOccurrence(event models.Event, now time.Time) (time.Time, bool) {
+	lookback := 0
+	switch event.Frequency {
+	case "everyday", "weekdays", "weekly":
+		lookback = 7
+	case "monthlyDate", "monthlyDay":
+		lookback = 62
+	case "annually":
+		lookback = 366
+	default:
+		if event.StartDate.Valid && !event.StartDate.Time.After(now) {
+			return event.StartDate.Time, true
+		}
+		return time.Time{}, false
+	}
+
+	occurrences := eventOccurrences(event, now.AddDate(0, 0, -lookback), now)
+	if len(occurrences) == 0 {
+		return time.Time{}, false
+	}
+	return occurrences[len(occurrences)-1], true
+}
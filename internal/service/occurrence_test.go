@@ -0,0 +1,295 @@
+package service
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lunovoy/friendly/internal/models"
+)
+
+func date(year int, month time.Month, day, hour int) time.Time {
+	return time.Date(year, month, day, hour, 0, 0, 0, time.UTC)
+}
+
+func TestEventOccurrences(t *testing.T) {
+	tests := []struct {
+		name      string
+		frequency string
+		start     time.Time
+		from, to  time.Time
+		want      []time.Time
+	}{
+		{
+			name:  "once within range",
+			start: date(2024, time.March, 10, 10),
+			from:  date(2024, time.March, 1, 0),
+			to:    date(2024, time.March, 31, 0),
+			want:  []time.Time{date(2024, time.March, 10, 10)},
+		},
+		{
+			name:  "once out of range",
+			start: date(2024, time.March, 10, 10),
+			from:  date(2024, time.April, 1, 0),
+			to:    date(2024, time.April, 30, 0),
+		},
+		{
+			name:      "everyday",
+			frequency: "everyday",
+			start:     date(2024, time.March, 1, 10),
+			from:      date(2024, time.March, 5, 0),
+			to:        date(2024, time.March, 7, 23),
+			want:      []time.Time{date(2024, time.March, 5, 10), date(2024, time.March, 6, 10), date(2024, time.March, 7, 10)},
+		},
+		{
+			name:      "weekdays skip the weekend",
+			frequency: "weekdays",
+			start:     date(2024, time.March, 1, 10),
+			from:      date(2024, time.March, 1, 0),
+			to:        date(2024, time.March, 5, 23),
+			want:      []time.Time{date(2024, time.March, 1, 10), date(2024, time.March, 4, 10), date(2024, time.March, 5, 10)},
+		},
+		{
+			name:      "weekly",
+			frequency: "weekly",
+			start:     date(2024, time.January, 1, 10),
+			from:      date(2024, time.January, 20, 0),
+			to:        date(2024, time.February, 10, 0),
+			want:      []time.Time{date(2024, time.January, 22, 10), date(2024, time.January, 29, 10), date(2024, time.February, 5, 10)},
+		},
+		{
+			name:      "monthly by date clamps to the last day",
+			frequency: "monthlyDate",
+			start:     date(2024, time.January, 31, 10),
+			from:      date(2024, time.January, 1, 0),
+			to:        date(2024, time.April, 30, 23),
+			want:      []time.Time{date(2024, time.January, 31, 10), date(2024, time.February, 29, 10), date(2024, time.March, 31, 10), date(2024, time.April, 30, 10)},
+		},
+		{
+			name:      "monthly by weekday",
+			frequency: "monthlyDay",
+			start:     date(2024, time.January, 23, 10),
+			from:      date(2024, time.February, 1, 0),
+			to:        date(2024, time.March, 31, 0),
+			want:      []time.Time{date(2024, time.February, 27, 10), date(2024, time.March, 26, 10)},
+		},
+		{
+			name:      "annually from a leap day",
+			frequency: "annually",
+			start:     date(2020, time.February, 29, 10),
+			from:      date(2023, time.January, 1, 0),
+			to:        date(2024, time.December, 31, 0),
+			want:      []time.Time{date(2023, time.February, 28, 10), date(2024, time.February, 29, 10)},
+		},
+		{
+			name:      "not started yet",
+			frequency: "everyday",
+			start:     date(2024, time.May, 1, 10),
+			from:      date(2024, time.April, 1, 0),
+			to:        date(2024, time.April, 30, 0),
+		},
+		{
+			name:      "empty range",
+			frequency: "everyday",
+			start:     date(2024, time.January, 1, 10),
+			from:      date(2024, time.April, 2, 0),
+			to:        date(2024, time.April, 1, 0),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := models.Event{StartDate: sql.NullTime{Time: tt.start, Valid: true}, Frequency: tt.frequency}
+			if got := eventOccurrences(event, tt.from, tt.to); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("eventOccurrences() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if got := eventOccurrences(models.Event{Frequency: "everyday"}, date(2024, time.January, 1, 0), date(2024, time.January, 2, 0)); got != nil {
+		t.Errorf("eventOccurrences() without start date = %v, want none", got)
+	}
+}
+
+func TestAddMonthsClamped(t *testing.T) {
+	tests := []struct {
+		t      time.Time
+		months int
+		want   time.Time
+	}{
+		{date(2024, time.January, 31, 10), 1, date(2024, time.February, 29, 10)},
+		{date(2023, time.January, 31, 10), 1, date(2023, time.February, 28, 10)},
+		{date(2024, time.February, 29, 10), 12, date(2025, time.February, 28, 10)},
+		{date(2024, time.March, 31, 10), -1, date(2024, time.February, 29, 10)},
+		{date(2024, time.December, 15, 10), 1, date(2025, time.January, 15, 10)},
+	}
+	for _, tt := range tests {
+		if got := addMonthsClamped(tt.t, tt.months); !got.Equal(tt.want) {
+			t.Errorf("addMonthsClamped(%s, %d) = %s, want %s", tt.t, tt.months, got, tt.want)
+		}
+	}
+}
+
+func TestSameWeekdayOfMonth(t *testing.T) {
+	tests := []struct {
+		t      time.Time
+		months int
+		want   time.Time
+	}{
+		{date(2024, time.January, 23, 10), 1, date(2024, time.February, 27, 10)},
+		{date(2024, time.January, 1, 10), 2, date(2024, time.March, 4, 10)},
+		{date(2024, time.January, 29, 10), 1, date(2024, time.February, 26, 10)},
+	}
+	for _, tt := range tests {
+		if got := sameWeekdayOfMonth(tt.t, tt.months); !got.Equal(tt.want) {
+			t.Errorf("sameWeekdayOfMonth(%s, %d) = %s, want %s", tt.t, tt.months, got, tt.want)
+		}
+	}
+}
+
+func TestNextAnniversary(t *testing.T) {
+	tests := []struct {
+		date, from time.Time
+		want       time.Time
+	}{
+		{date(1990, time.March, 10, 0), date(2024, time.March, 10, 15), date(2024, time.March, 10, 0)},
+		{date(1990, time.March, 10, 0), date(2024, time.March, 11, 0), date(2025, time.March, 10, 0)},
+		{date(1992, time.February, 29, 0), date(2023, time.January, 1, 0), date(2023, time.February, 28, 0)},
+		{date(1992, time.February, 29, 0), date(2024, time.January, 1, 0), date(2024, time.February, 29, 0)},
+	}
+	for _, tt := range tests {
+		if got := nextAnniversary(tt.date, tt.from); !got.Equal(tt.want) {
+			t.Errorf("nextAnniversary(%s, %s) = %s, want %s", tt.date, tt.from, got, tt.want)
+		}
+	}
+}
+
+func TestAnniversaryOccurrences(t *testing.T) {
+	tests := []struct {
+		name           string
+		date, from, to time.Time
+		want           []time.Time
+	}{
+		{
+			name: "every year within range",
+			date: date(1990, time.March, 10, 0),
+			from: date(2024, time.January, 1, 0),
+			to:   date(2025, time.December, 31, 0),
+			want: []time.Time{date(2024, time.March, 10, anniversaryHour), date(2025, time.March, 10, anniversaryHour)},
+		},
+		{
+			name: "range bounds are inclusive",
+			date: date(1990, time.March, 10, 0),
+			from: date(2024, time.March, 10, anniversaryHour),
+			to:   date(2025, time.March, 10, anniversaryHour),
+			want: []time.Time{date(2024, time.March, 10, anniversaryHour), date(2025, time.March, 10, anniversaryHour)},
+		},
+		{
+			name: "started after the anniversary time",
+			date: date(1990, time.March, 10, 0),
+			from: date(2024, time.March, 10, anniversaryHour+1),
+			to:   date(2024, time.December, 31, 0),
+		},
+		{
+			name: "not in the year of the date",
+			date: date(2024, time.March, 10, 0),
+			from: date(2024, time.January, 1, 0),
+			to:   date(2024, time.December, 31, 0),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := anniversaryOccurrences(tt.date, tt.from, tt.to); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("anniversaryOccurrences() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFriendDateOccurrences(t *testing.T) {
+	year := func(year int) *int { return &year }
+
+	tests := []struct {
+		name     string
+		date     models.FriendDate
+		from, to time.Time
+		want     []time.Time
+	}{
+		{
+			name: "leap day without year",
+			date: models.FriendDate{Month: 2, Day: 29, Recurrence: models.FriendDateRecurrenceAnnually},
+			from: date(2023, time.January, 1, 0),
+			to:   date(2024, time.December, 31, 0),
+			want: []time.Time{date(2023, time.February, 28, anniversaryHour), date(2024, time.February, 29, anniversaryHour)},
+		},
+		{
+			name: "annually after the year",
+			date: models.FriendDate{Month: 6, Day: 15, Year: year(2020), Recurrence: models.FriendDateRecurrenceAnnually},
+			from: date(2020, time.January, 1, 0),
+			to:   date(2021, time.December, 31, 0),
+			want: []time.Time{date(2021, time.June, 15, anniversaryHour)},
+		},
+		{
+			name: "once within range",
+			date: models.FriendDate{Month: 6, Day: 15, Year: year(2024), Recurrence: models.FriendDateRecurrenceOnce},
+			from: date(2024, time.January, 1, 0),
+			to:   date(2024, time.December, 31, 0),
+			want: []time.Time{date(2024, time.June, 15, anniversaryHour)},
+		},
+		{
+			name: "once out of range",
+			date: models.FriendDate{Month: 6, Day: 15, Year: year(2024), Recurrence: models.FriendDateRecurrenceOnce},
+			from: date(2025, time.January, 1, 0),
+			to:   date(2025, time.December, 31, 0),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := friendDateOccurrences(tt.date, tt.from, tt.to); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("friendDateOccurrences() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFriendDateYears(t *testing.T) {
+	year := 2010
+	if got := friendDateYears(models.FriendDate{Year: &year}, date(2024, time.June, 15, anniversaryHour)); got != 14 {
+		t.Errorf("friendDateYears() = %d, want 14", got)
+	}
+	if got := friendDateYears(models.FriendDate{}, date(2024, time.June, 15, anniversaryHour)); got != 0 {
+		t.Errorf("friendDateYears() without year = %d, want 0", got)
+	}
+}
+
+func TestLastEventOccurrence(t *testing.T) {
+	now := date(2024, time.March, 10, 12)
+
+	tests := []struct {
+		name      string
+		frequency string
+		start     time.Time
+		want      time.Time
+		wantOK    bool
+	}{
+		{name: "once in the past", start: date(2024, time.March, 1, 10), want: date(2024, time.March, 1, 10), wantOK: true},
+		{name: "once in the future", start: date(2024, time.March, 11, 10)},
+		{name: "weekly", frequency: "weekly", start: date(2024, time.January, 1, 10), want: date(2024, time.March, 4, 10), wantOK: true},
+		{name: "monthly by date", frequency: "monthlyDate", start: date(2024, time.January, 31, 10), want: date(2024, time.February, 29, 10), wantOK: true},
+		{name: "annually", frequency: "annually", start: date(2020, time.June, 15, 10), want: date(2023, time.June, 15, 10), wantOK: true},
+		{name: "not started yet", frequency: "everyday", start: date(2024, time.March, 11, 10)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := models.Event{StartDate: sql.NullTime{Time: tt.start, Valid: true}, Frequency: tt.frequency}
+			got, ok := lastEventOccurrence(event, now)
+			if ok != tt.wantOK || !got.Equal(tt.want) {
+				t.Errorf("lastEventOccurrence() = %s, %v, want %s, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
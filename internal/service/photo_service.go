@@ -0,0 +1,118 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/lunovoy/friendly/internal/models"
+	"github.com/lunovoy/friendly/internal/repository"
+)
+
+const maxPhotoCaptionLength = 1000
+
+type PhotoService struct {
+	repo      repository.Photo
+	eventRepo repository.Event
+}
+
+func NewPhotoService(repo repository.Photo, eventRepo repository.Event) *PhotoService {
+	return &PhotoService{
+		repo:      repo,
+		eventRepo: eventRepo,
+	}
+}
+
+func (s *PhotoService) Create(userID, friendID uuid.UUID, photo models.Photo) (uuid.UUID, error) {
+	if photo.ImageID == uuid.Nil {
+		return uuid.Nil, errors.New("image id must not be empty")
+	}
+	if photo.TakenAt != nil && photo.TakenAt.IsZero() {
+		photo.TakenAt = nil
+	}
+	if err := s.validate(userID, photo); err != nil {
+		return uuid.Nil, err
+	}
+	photo.FriendID = friendID
+
+	return s.repo.Create(userID, photo)
+}
+
+func (s *PhotoService) GetAllByFriendID(userID, friendID uuid.UUID) ([]models.Photo, error) {
+	return s.repo.GetAllByFriendID(userID, friendID)
+}
+
+func (s *PhotoService) GetAllByEventID(userID, eventID uuid.UUID) ([]models.Photo, error) {
+	return s.repo.GetAllByEventID(userID, eventID)
+}
+
+func (s *PhotoService) GetByID(userID, friendID, photoID uuid.UUID) (models.Photo, error) {
+	return s.repo.GetByID(userID, friendID, photoID)
+}
+
+func (s *PhotoService) Update(userID, friendID, photoID uuid.UUID, update models.PhotoUpdate) error {
+	photo, err := s.repo.GetByID(userID, friendID, photoID)
+	if err != nil {
+		return err
+	}
+
+	if update.Caption != nil {
+		photo.Caption = *update.Caption
+	}
+	if update.TakenAt != nil {
+		photo.TakenAt = update.TakenAt
+		if update.TakenAt.IsZero() {
+			photo.TakenAt = nil
+		}
+	}
+	if update.EventID != nil {
+		photo.EventID = uuid.NullUUID{UUID: *update.EventID, Valid: *update.EventID != uuid.Nil}
+	}
+	if err := s.validate(userID, photo); err != nil {
+		return err
+	}
+
+	return s.repo.Update(userID, photoID, photo)
+}
+
+func (s *PhotoService) DeleteByID(userID, photoID uuid.UUID) error {
+	return s.repo.DeleteByID(userID, photoID)
+}
+
+// Reorder requires every photo of the friend to be listed exactly once.
+func (s *PhotoService) Reorder(userID, friendID uuid.UUID, photoIDs []uuid.UUID) error {
+	photos, err := s.repo.GetAllByFriendID(userID, friendID)
+	if err != nil {
+		return err
+	}
+	if len(photoIDs) != len(photos) {
+		return fmt.Errorf("all %d photos of the friend must be listed", len(photos))
+	}
+
+	known := make(map[uuid.UUID]bool, len(photos))
+	for _, photo := range photos {
+		known[photo.ID] = true
+	}
+	for _, photoID := range photoIDs {
+		if !known[photoID] {
+			return fmt.Errorf("photo %s is not in the gallery or listed twice", photoID)
+		}
+		delete(known, photoID)
+	}
+
+	return s.repo.Reorder(userID, friendID, photoIDs)
+}
+
+func (s *PhotoService) validate(userID uuid.UUID, photo models.Photo) error {
+	if utf8.RuneCountInString(photo.Caption) > maxPhotoCaptionLength {
+		return errors.New("caption is too long")
+	}
+	if photo.EventID.Valid {
+		if _, err := s.eventRepo.GetByID(userID, photo.EventID.UUID); err != nil {
+			return fmt.Errorf("event not found: %w", err)
+		}
+	}
+
+	return nil
+}
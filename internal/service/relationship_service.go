@@ -0,0 +1,205 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lunovoy/friendly/internal/models"
+	"github.com/lunovoy/friendly/internal/repository"
+)
+
+// relationshipKinds maps supported kinds to whether they are directed.
+var relationshipKinds = map[string]bool{
+	models.RelationshipKindSpouse:       false,
+	models.RelationshipKindParent:       true,
+	models.RelationshipKindChild:        true,
+	models.RelationshipKindSibling:      false,
+	models.RelationshipKindColleague:    false,
+	models.RelationshipKindIntroducedBy: true,
+}
+
+type RelationshipService struct {
+	repo           repository.Relationship
+	friendRepo     repository.Friend
+	friendlistRepo repository.Friendlist
+}
+
+func NewRelationshipService(repo repository.Relationship, friendRepo repository.Friend, friendlistRepo repository.Friendlist) *RelationshipService {
+	return &RelationshipService{
+		repo:           repo,
+		friendRepo:     friendRepo,
+		friendlistRepo: friendlistRepo,
+	}
+}
+
+func (s *RelationshipService) Create(userID, friendID uuid.UUID, relationship models.Relationship) (uuid.UUID, error) {
+	if _, err := s.friendRepo.GetByID(userID, relationship.RelatedFriendID); err != nil {
+		return uuid.Nil, fmt.Errorf("related friend not found: %w", err)
+	}
+
+	relationship.FriendID = friendID
+	relationship, err := normalizeRelationship(relationship)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	existing, err := s.repo.GetAllByFriendID(userID, relationship.FriendID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	for _, other := range existing {
+		if other.Kind == relationship.Kind && other.FriendID == relationship.FriendID && other.RelatedFriendID == relationship.RelatedFriendID {
+			return uuid.Nil, errors.New("relationship already exists")
+		}
+	}
+
+	return s.repo.Create(userID, relationship)
+}
+
+func (s *RelationshipService) GetAllByFriendID(userID, friendID uuid.UUID) ([]models.Relationship, error) {
+	relationships, err := s.repo.GetAllByFriendID(userID, friendID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range relationships {
+		relationships[i].Directed = relationshipKinds[relationships[i].Kind]
+	}
+
+	return relationships, nil
+}
+
+func (s *RelationshipService) GetByID(userID, friendID, relationshipID uuid.UUID) (models.Relationship, error) {
+	relationship, err := s.repo.GetByID(userID, friendID, relationshipID)
+	if err != nil {
+		return models.Relationship{}, err
+	}
+	relationship.Directed = relationshipKinds[relationship.Kind]
+
+	return relationship, nil
+}
+
+// Update changes the kind as seen from the friend, e.g. child turns the
+// friend into the child of the other one.
+func (s *RelationshipService) Update(userID, friendID, relationshipID uuid.UUID, update models.RelationshipUpdate) error {
+	relationship, err := s.repo.GetByID(userID, friendID, relationshipID)
+	if err != nil {
+		return err
+	}
+
+	if update.Kind != nil {
+		other := relationship.RelatedFriendID
+		if other == friendID {
+			other = relationship.FriendID
+		}
+		relationship.Kind = *update.Kind
+		relationship.FriendID = friendID
+		relationship.RelatedFriendID = other
+	}
+
+	relationship, err = normalizeRelationship(relationship)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.Update(userID, relationshipID, relationship)
+}
+
+func (s *RelationshipService) DeleteByID(userID, relationshipID uuid.UUID) error {
+	return s.repo.DeleteByID(userID, relationshipID)
+}
+
+// GetGraph returns friends as nodes and relationships between them as edges.
+// Filters keep friends of the friendlist and with the tag, edges to
+// filtered out friends are dropped.
+func (s *RelationshipService) GetGraph(userID uuid.UUID, friendlistID, tagID *uuid.UUID) (models.Graph, error) {
+	friends, err := s.friendRepo.GetAll(userID)
+	if err != nil {
+		return models.Graph{}, err
+	}
+
+	var inFriendlist map[uuid.UUID]bool
+	if friendlistID != nil {
+		friendlist, err := NewFriendlistService(s.friendlistRepo, s.friendRepo).GetByIDWithFriends(userID, *friendlistID)
+		if err != nil {
+			return models.Graph{}, fmt.Errorf("friendlist not found: %w", err)
+		}
+		inFriendlist = make(map[uuid.UUID]bool, len(friendlist.Friends))
+		for _, friend := range friendlist.Friends {
+			inFriendlist[friend.ID] = true
+		}
+	}
+
+	graph := models.Graph{
+		Nodes: make([]models.GraphNode, 0, len(friends)),
+		Edges: make([]models.GraphEdge, 0),
+	}
+	nodes := make(map[uuid.UUID]bool, len(friends))
+	for _, friend := range friends {
+		if inFriendlist != nil && !inFriendlist[friend.Friend.ID] {
+			continue
+		}
+		if tagID != nil && !hasTag(friend.Tags, *tagID) {
+			continue
+		}
+		nodes[friend.Friend.ID] = true
+		graph.Nodes = append(graph.Nodes, models.GraphNode{
+			ID:        friend.Friend.ID,
+			FirstName: friend.Friend.FirstName,
+			LastName:  friend.Friend.LastName,
+			ImageID:   friend.Friend.ImageID,
+		})
+	}
+
+	relationships, err := s.repo.GetAll(userID)
+	if err != nil {
+		return models.Graph{}, err
+	}
+	for _, relationship := range relationships {
+		if !nodes[relationship.FriendID] || !nodes[relationship.RelatedFriendID] {
+			continue
+		}
+		graph.Edges = append(graph.Edges, models.GraphEdge{
+			ID:       relationship.ID,
+			Kind:     relationship.Kind,
+			Source:   relationship.FriendID,
+			Target:   relationship.RelatedFriendID,
+			Directed: relationshipKinds[relationship.Kind],
+		})
+	}
+
+	return graph, nil
+}
+
+// normalizeRelationship stores child as parent with the friends swapped and
+// orders friends of undirected kinds, so the same relationship can't be
+// added twice from both sides.
+func normalizeRelationship(relationship models.Relationship) (models.Relationship, error) {
+	directed, ok := relationshipKinds[relationship.Kind]
+	if !ok {
+		return models.Relationship{}, fmt.Errorf("kind %s is not supported", relationship.Kind)
+	}
+	if relationship.FriendID == relationship.RelatedFriendID {
+		return models.Relationship{}, errors.New("friend can't be related to itself")
+	}
+
+	if relationship.Kind == models.RelationshipKindChild {
+		relationship.Kind = models.RelationshipKindParent
+		relationship.FriendID, relationship.RelatedFriendID = relationship.RelatedFriendID, relationship.FriendID
+	}
+	if !directed && relationship.FriendID.String() > relationship.RelatedFriendID.String() {
+		relationship.FriendID, relationship.RelatedFriendID = relationship.RelatedFriendID, relationship.FriendID
+	}
+	relationship.Directed = directed
+
+	return relationship, nil
+}
+
+func hasTag(tags []models.Tag, tagID uuid.UUID) bool {
+	for _, tag := range tags {
+		if tag.ID == tagID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/lunovoy/friendly/internal/models"
+)
+
+func TestNormalizeRelationship(t *testing.T) {
+	low := uuid.MustParse("11111111-1111-1111-1111-111111111111")
+	high := uuid.MustParse("22222222-2222-2222-2222-222222222222")
+
+	tests := []struct {
+		name         string
+		relationship models.Relationship
+		want         models.Relationship
+		wantErr      bool
+	}{
+		{
+			name:         "parent is kept",
+			relationship: models.Relationship{Kind: models.RelationshipKindParent, FriendID: high, RelatedFriendID: low},
+			want:         models.Relationship{Kind: models.RelationshipKindParent, FriendID: high, RelatedFriendID: low, Directed: true},
+		},
+		{
+			name:         "child is stored as parent",
+			relationship: models.Relationship{Kind: models.RelationshipKindChild, FriendID: low, RelatedFriendID: high},
+			want:         models.Relationship{Kind: models.RelationshipKindParent, FriendID: high, RelatedFriendID: low, Directed: true},
+		},
+		{
+			name:         "undirected friends are ordered",
+			relationship: models.Relationship{Kind: models.RelationshipKindSpouse, FriendID: high, RelatedFriendID: low},
+			want:         models.Relationship{Kind: models.RelationshipKindSpouse, FriendID: low, RelatedFriendID: high},
+		},
+		{
+			name:         "unknown kind",
+			relationship: models.Relationship{Kind: "neighbour", FriendID: low, RelatedFriendID: high},
+			wantErr:      true,
+		},
+		{
+			name:         "related to itself",
+			relationship: models.Relationship{Kind: models.RelationshipKindSibling, FriendID: low, RelatedFriendID: low},
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := normalizeRelationship(tt.relationship)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("normalizeRelationship() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("normalizeRelationship() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
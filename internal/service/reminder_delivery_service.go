@@ -0,0 +1,87 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lunovoy/friendly/internal/models"
+	"github.com/lunovoy/friendly/internal/repository"
+)
+
+const defaultSnoozeMinutes = 10
+
+var deliveryStatuses = map[string]bool{
+	models.DeliveryStatusPending:      true,
+	models.DeliveryStatusSent:         true,
+	models.DeliveryStatusAcknowledged: true,
+	models.DeliveryStatusSnoozed:      true,
+	models.DeliveryStatusDismissed:    true,
+	models.DeliveryStatusFailed:       true,
+}
+
+type ReminderDeliveryService struct {
+	repo repository.ReminderDelivery
+}
+
+func NewReminderDeliveryService(repo repository.ReminderDelivery) *ReminderDeliveryService {
+	return &ReminderDeliveryService{
+		repo: repo,
+	}
+}
+
+func (s *ReminderDeliveryService) GetAll(userID uuid.UUID, status string) ([]models.ReminderDelivery, error) {
+	if status != "" && !deliveryStatuses[status] {
+		return nil, errors.New("status is not valid")
+	}
+	return s.repo.GetAll(userID, status)
+}
+
+func (s *ReminderDeliveryService) GetByID(userID, deliveryID uuid.UUID) (models.ReminderDelivery, error) {
+	return s.repo.GetByID(userID, deliveryID)
+}
+
+func (s *ReminderDeliveryService) Acknowledge(userID, deliveryID uuid.UUID) error {
+	if err := s.checkActionable(userID, deliveryID); err != nil {
+		return err
+	}
+	return s.repo.UpdateStatus(userID, deliveryID, models.DeliveryStatusAcknowledged, sql.NullTime{})
+}
+
+func (s *ReminderDeliveryService) Snooze(userID, deliveryID uuid.UUID, snooze models.ReminderDeliverySnooze) error {
+	if err := s.checkActionable(userID, deliveryID); err != nil {
+		return err
+	}
+
+	until := time.Now().Add(defaultSnoozeMinutes * time.Minute)
+	if snooze.Until != nil {
+		until = *snooze.Until
+	} else if snooze.Minutes > 0 {
+		until = time.Now().Add(time.Duration(snooze.Minutes) * time.Minute)
+	}
+	if !until.After(time.Now()) {
+		return errors.New("snooze time must be in the future")
+	}
+
+	return s.repo.UpdateStatus(userID, deliveryID, models.DeliveryStatusSnoozed, sql.NullTime{Time: until, Valid: true})
+}
+
+func (s *ReminderDeliveryService) Dismiss(userID, deliveryID uuid.UUID) error {
+	if err := s.checkActionable(userID, deliveryID); err != nil {
+		return err
+	}
+	return s.repo.UpdateStatus(userID, deliveryID, models.DeliveryStatusDismissed, sql.NullTime{})
+}
+
+func (s *ReminderDeliveryService) checkActionable(userID, deliveryID uuid.UUID) error {
+	delivery, err := s.repo.GetByID(userID, deliveryID)
+	if err != nil {
+		return err
+	}
+	if delivery.Status == models.DeliveryStatusAcknowledged || delivery.Status == models.DeliveryStatusDismissed {
+		return fmt.Errorf("delivery is already %s", delivery.Status)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/lunovoy/friendly/internal/models"
+	"github.com/lunovoy/friendly/internal/repository"
+)
+
+const (
+	maxSearchQueryLength = 200
+	maxSearchLimit       = 100
+)
+
+type SearchService struct {
+	repo repository.Search
+}
+
+func NewSearchService(repo repository.Search) *SearchService {
+	return &SearchService{
+		repo: repo,
+	}
+}
+
+func (s *SearchService) Search(userID uuid.UUID, query string, limit int) ([]models.SearchResult, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, errors.New("query must not be empty")
+	}
+	if len([]rune(query)) > maxSearchQueryLength {
+		return nil, errors.New("query must not be longer than 200 characters")
+	}
+	if limit < 1 || limit > maxSearchLimit {
+		return nil, errors.New("limit must be between 1 and 100")
+	}
+
+	results, err := s.repo.Search(userID, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	if results == nil {
+		results = []models.SearchResult{}
+	}
+
+	return results, nil
+}
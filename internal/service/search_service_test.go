@@ -0,0 +1,53 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/lunovoy/friendly/internal/models"
+)
+
+type fakeSearchRepo struct {
+	query string
+}
+
+func (r *fakeSearchRepo) Search(userID uuid.UUID, query string, limit int) ([]models.SearchResult, error) {
+	r.query = query
+	return nil, nil
+}
+
+func TestSearchServiceSearch(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		limit     int
+		wantQuery string
+		wantErr   bool
+	}{
+		{name: "trims the query", query: "  Иван ", limit: 20, wantQuery: "Иван"},
+		{name: "blank query", query: " ", limit: 20, wantErr: true},
+		{name: "long query", query: strings.Repeat("я", maxSearchQueryLength+1), limit: 20, wantErr: true},
+		{name: "zero limit", query: "Иван", limit: 0, wantErr: true},
+		{name: "large limit", query: "Иван", limit: maxSearchLimit + 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeSearchRepo{}
+			results, err := NewSearchService(repo).Search(uuid.New(), tt.query, tt.limit)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Search() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if repo.query != tt.wantQuery {
+				t.Errorf("repository query = %q, want %q", repo.query, tt.wantQuery)
+			}
+			if results == nil {
+				t.Error("Search() = nil, want an empty list")
+			}
+		})
+	}
+}
@@ -1,9 +1,13 @@
 package service
 
 import (
+	"context"
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/lunovoy/friendly/internal/models"
 	"github.com/lunovoy/friendly/internal/repository"
+	"github.com/lunovoy/friendly/internal/storage"
 )
 
 type Authorization interface {
@@ -24,6 +28,7 @@ type Tag interface {
 	GetByID(userID, tagID uuid.UUID) (models.Tag, error)
 	Update(userID, tagID uuid.UUID, tag models.Tag) error
 	DeleteByID(userID, tagID uuid.UUID) error
+	List(userID uuid.UUID, params models.ListParams) ([]models.Tag, string, error)
 }
 
 type Friendlist interface {
@@ -41,6 +46,11 @@ type Friendlist interface {
 	AddFriendToFriendlist(friendlistID, friendID uuid.UUID) error
 	DeleteFriendFromFriendlist(friendlistID, friendID uuid.UUID) error
 	DeleteByID(userID, friendlistID uuid.UUID) error
+	GetTemplateFields(friendlistID uuid.UUID) ([]models.AdditionalInfoField, error)
+	GetAllTemplateFields(userID uuid.UUID) (map[uuid.UUID][]models.AdditionalInfoField, error)
+	AddFieldToTemplate(friendlistID, fieldID uuid.UUID) error
+	DeleteFieldFromTemplate(friendlistID, fieldID uuid.UUID) error
+	List(userID uuid.UUID, filter models.FriendlistFilter, params models.ListParams) ([]models.Friendlist, string, error)
 }
 
 type Friend interface {
@@ -49,9 +59,16 @@ type Friend interface {
 	GetByID(userID, friendID uuid.UUID) (models.FriendWorkInfoTags, error)
 	Update(userID, friendID uuid.UUID, friend models.UpdateFriendWorkInfoInput) error
 	DeleteByID(userID, friendID uuid.UUID) error
-	AddTagToFriend(friendID, tagID uuid.UUID) error
+	AddTagToFriend(userID, friendID, tagID uuid.UUID) error
 	AddTagsToFriend(userID, friendID uuid.UUID, tagIDs []models.AdditionTag) ([]uuid.UUID, error)
-	DeleteTagFromFriend(friendID, tagID uuid.UUID) error
+	DeleteTagFromFriend(userID, friendID, tagID uuid.UUID) error
+	GetVCard(userID, friendID uuid.UUID) (string, error)
+	List(userID uuid.UUID, filter models.FriendFilter, params models.ListParams) ([]models.FriendWorkInfoTags, string, error)
+	FindDuplicates(userID uuid.UUID) ([]models.DuplicateCandidate, error)
+	Merge(userID uuid.UUID, merge models.FriendMerge) error
+	GetHistory(userID, friendID uuid.UUID) ([]models.FriendChange, error)
+	GetVersion(userID, friendID uuid.UUID, at time.Time) (models.FriendWorkInfoTags, error)
+	Revert(userID, friendID uuid.UUID, at time.Time) error
 }
 
 type Event interface {
@@ -66,6 +83,7 @@ type Event interface {
 	Update(userID, eventID uuid.UUID, event models.EventUpdate) error
 	UpdateFull(userID, eventID uuid.UUID, event models.EventFullUpdate) error
 	DeleteByID(userID, eventID uuid.UUID) error
+	List(userID uuid.UUID, filter models.EventFilter, params models.ListParams) ([]models.Event, string, error)
 }
 
 type Reminder interface {
@@ -78,7 +96,146 @@ type Reminder interface {
 	DeleteByID(userID, reminderID uuid.UUID) error
 }
 
+type ReminderDelivery interface {
+	GetAll(userID uuid.UUID, status string) ([]models.ReminderDelivery, error)
+	GetByID(userID, deliveryID uuid.UUID) (models.ReminderDelivery, error)
+	Acknowledge(userID, deliveryID uuid.UUID) error
+	Snooze(userID, deliveryID uuid.UUID, snooze models.ReminderDeliverySnooze) error
+	Dismiss(userID, deliveryID uuid.UUID) error
+}
+
 type AdditionalInfoField interface {
+	Create(userID uuid.UUID, field models.AdditionalInfoField) (uuid.UUID, error)
+	GetAll(userID uuid.UUID) ([]models.AdditionalInfoField, error)
+	GetByID(userID, fieldID uuid.UUID) (models.AdditionalInfoField, error)
+	Update(userID, fieldID uuid.UUID, update models.AdditionalInfoFieldUpdate) error
+	DeleteByID(userID, fieldID uuid.UUID) error
+	GetAllByFriendID(friendID uuid.UUID) ([]models.FriendAdditionalInfoField, error)
+	SetValue(userID, friendID, fieldID uuid.UUID, value any) error
+	DeleteFromFriend(userID, friendID, fieldID uuid.UUID) error
+}
+
+type Digest interface {
+	GetSetting(userID uuid.UUID) (models.DigestSetting, error)
+	UpdateSetting(userID uuid.UUID, update models.DigestSettingUpdate) error
+	Compose(userID uuid.UUID, now time.Time) (models.Digest, error)
+}
+
+type FriendDate interface {
+	Create(userID, friendID uuid.UUID, date models.FriendDate) (uuid.UUID, error)
+	GetAllByFriendID(userID, friendID uuid.UUID) ([]models.FriendDate, error)
+	GetByID(userID, friendID, dateID uuid.UUID) (models.FriendDate, error)
+	Update(userID, friendID, dateID uuid.UUID, update models.FriendDateUpdate) error
+	DeleteByID(userID, dateID uuid.UUID) error
+}
+
+type GiftIdea interface {
+	Create(userID, friendID uuid.UUID, idea models.GiftIdea) (uuid.UUID, error)
+	GetAllByFriendID(userID, friendID uuid.UUID) ([]models.GiftIdea, error)
+	GetByID(userID, friendID, ideaID uuid.UUID) (models.GiftIdea, error)
+	Update(userID, friendID, ideaID uuid.UUID, update models.GiftIdeaUpdate) error
+	DeleteByID(userID, ideaID uuid.UUID) error
+}
+
+type Note interface {
+	Create(userID uuid.UUID, note models.Note) (uuid.UUID, error)
+	GetAll(userID uuid.UUID, filter models.NoteFilter) ([]models.Note, error)
+	GetByID(userID, noteID uuid.UUID) (models.Note, error)
+	Update(userID, noteID uuid.UUID, update models.NoteUpdate) error
+	DeleteByID(userID, noteID uuid.UUID) error
+}
+
+type Attachment interface {
+	Create(userID uuid.UUID, attachment models.Attachment) (uuid.UUID, error)
+	GetAll(userID uuid.UUID, filter models.AttachmentFilter) ([]models.Attachment, error)
+	GetByID(userID, attachmentID uuid.UUID) (models.Attachment, error)
+	DeleteByID(userID, attachmentID uuid.UUID) error
+	GetUsage(userID uuid.UUID) (models.StorageUsage, error)
+}
+
+type Photo interface {
+	Create(userID, friendID uuid.UUID, photo models.Photo) (uuid.UUID, error)
+	GetAllByFriendID(userID, friendID uuid.UUID) ([]models.Photo, error)
+	GetAllByEventID(userID, eventID uuid.UUID) ([]models.Photo, error)
+	GetByID(userID, friendID, photoID uuid.UUID) (models.Photo, error)
+	Update(userID, friendID, photoID uuid.UUID, update models.PhotoUpdate) error
+	DeleteByID(userID, photoID uuid.UUID) error
+	Reorder(userID, friendID uuid.UUID, photoIDs []uuid.UUID) error
+}
+
+type Image interface {
+	Create(userID uuid.UUID, image models.Image) error
+	GetByID(userID, imageID uuid.UUID) (models.Image, error)
+	GetShared(userID, imageID uuid.UUID) (models.Image, error)
+	DeleteByID(ctx context.Context, userID, imageID uuid.UUID) error
+	Release(ctx context.Context, userID, imageID uuid.UUID) error
+	DeleteUnreferenced(ctx context.Context, imageIDs []uuid.UUID) error
+}
+
+type FriendlistShare interface {
+	Share(ownerID, friendlistID uuid.UUID, mail string) (uuid.UUID, error)
+	GetAllByFriendlistID(friendlistID uuid.UUID) ([]models.FriendlistShare, error)
+	Unshare(friendlistID, userID uuid.UUID) error
+}
+
+type ContactPoint interface {
+	Create(userID, friendID uuid.UUID, contact models.ContactPoint) (uuid.UUID, error)
+	GetAllByFriendID(userID, friendID uuid.UUID) ([]models.ContactPoint, error)
+	GetByID(userID, friendID, contactID uuid.UUID) (models.ContactPoint, error)
+	Update(userID, friendID, contactID uuid.UUID, update models.ContactPointUpdate) error
+	DeleteByID(userID, contactID uuid.UUID) error
+}
+
+type Employment interface {
+	Create(userID, friendID uuid.UUID, employment models.Employment) (uuid.UUID, error)
+	GetAllByFriendID(userID, friendID uuid.UUID) ([]models.Employment, error)
+	GetByID(userID, friendID, employmentID uuid.UUID) (models.Employment, error)
+	Update(userID, friendID, employmentID uuid.UUID, update models.EmploymentUpdate) error
+	DeleteByID(userID, friendID, employmentID uuid.UUID) error
+}
+
+type Relationship interface {
+	Create(userID, friendID uuid.UUID, relationship models.Relationship) (uuid.UUID, error)
+	GetAllByFriendID(userID, friendID uuid.UUID) ([]models.Relationship, error)
+	GetByID(userID, friendID, relationshipID uuid.UUID) (models.Relationship, error)
+	Update(userID, friendID, relationshipID uuid.UUID, update models.RelationshipUpdate) error
+	DeleteByID(userID, relationshipID uuid.UUID) error
+	GetGraph(userID uuid.UUID, friendlistID, tagID *uuid.UUID) (models.Graph, error)
+}
+
+type Search interface {
+	Search(userID uuid.UUID, query string, limit int) ([]models.SearchResult, error)
+}
+
+type Trash interface {
+	GetAll(userID uuid.UUID) ([]models.TrashItem, error)
+	Restore(userID uuid.UUID, kind string, id uuid.UUID) error
+}
+
+type Interaction interface {
+	Create(userID, friendID uuid.UUID, interaction models.Interaction) (uuid.UUID, error)
+	GetAllByFriendID(userID, friendID uuid.UUID) ([]models.Interaction, error)
+	GetByID(userID, friendID, interactionID uuid.UUID) (models.Interaction, error)
+	Update(userID, friendID, interactionID uuid.UUID, update models.InteractionUpdate) error
+	DeleteByID(userID, interactionID uuid.UUID) error
+}
+
+type Timeline interface {
+	GetTimeline(userID, friendID uuid.UUID, from, to time.Time) ([]models.TimelineItem, error)
+}
+
+type KeepInTouch interface {
+	GetOverdue(userID uuid.UUID, now time.Time) ([]models.OverdueFriend, error)
+	MarkContacted(userID, friendID uuid.UUID, at time.Time) error
+}
+
+type Calendar interface {
+	GetOccurrences(userID uuid.UUID, from, to time.Time) ([]models.Occurrence, error)
+}
+
+type NotificationPreference interface {
+	Get(userID uuid.UUID) (models.NotificationPreference, error)
+	Update(userID uuid.UUID, update models.NotificationPreferenceUpdate) error
 }
 
 type Service struct {
@@ -89,16 +246,56 @@ type Service struct {
 	Friend
 	Event
 	Reminder
+	ReminderDelivery
+	AdditionalInfoField
+	FriendDate
+	GiftIdea
+	Note
+	Attachment
+	Photo
+	Image
+	FriendlistShare
+	ContactPoint
+	Employment
+	Relationship
+	Search
+	Trash
+	Digest
+	NotificationPreference
+	Calendar
+	KeepInTouch
+	Interaction
+	Timeline
 }
 
-func NewService(repo *repository.Repository) *Service {
+func NewService(repo *repository.Repository, storage storage.Storage) *Service {
 	return &Service{
-		Authorization: NewAuthService(repo.Authorization),
-		User:          NewUserService(repo.User),
-		Tag:           NewTagService(repo.Tag),
-		Friendlist:    NewFriendlistService(repo.Friendlist),
-		Friend:        NewFriendService(repo.Friend),
-		Event:         NewEventService(repo.Event),
-		Reminder:      NewReminderService(repo.Reminder),
+		Authorization:          NewAuthService(repo.Authorization),
+		User:                   NewUserService(repo.User),
+		Tag:                    NewTagService(repo.Tag),
+		Friendlist:             NewFriendlistService(repo.Friendlist, repo.Friend),
+		Friend:                 NewFriendService(repo.Friend, repo.ReminderDelivery, repo.FriendChange, repo.AdditionalInfoField, repo.ContactPoint, repo.Employment),
+		Event:                  NewEventService(repo.Event),
+		Reminder:               NewReminderService(repo.Reminder),
+		ReminderDelivery:       NewReminderDeliveryService(repo.ReminderDelivery),
+		AdditionalInfoField:    NewAdditionalInfoFieldService(repo.AdditionalInfoField, repo.FriendChange),
+		FriendDate:             NewFriendDateService(repo.FriendDate, repo.ReminderDelivery),
+		GiftIdea:               NewGiftIdeaService(repo.GiftIdea, repo.Event),
+		Note:                   NewNoteService(repo.Note, repo.Friend),
+		Attachment:             NewAttachmentService(repo.Attachment),
+		Photo:                  NewPhotoService(repo.Photo, repo.Event),
+		Image:                  NewImageService(repo.Image, storage),
+		FriendlistShare:        NewFriendlistShareService(repo.FriendlistShare, repo.Authorization),
+		ContactPoint:           NewContactPointService(repo.ContactPoint),
+		Employment:             NewEmploymentService(repo.Employment),
+		Relationship:           NewRelationshipService(repo.Relationship, repo.Friend, repo.Friendlist),
+		Search:                 NewSearchService(repo.Search),
+		Trash:                  NewTrashService(repo.Trash),
+		Digest:                 NewDigestService(repo.Digest, repo.Friend, repo.Event, repo.FriendDate, repo.GiftIdea, repo.Interaction),
+		NotificationPreference: NewNotificationPreferenceService(repo.NotificationPreference),
+		KeepInTouch:            NewKeepInTouchService(repo.Friend, repo.Event, repo.Interaction),
+		Interaction:            NewInteractionService(repo.Interaction, repo.Event),
+		Timeline:               NewTimelineService(repo.Interaction, repo.Event, repo.FriendChange, repo.Employment, repo.Note),
+		Calendar:               NewCalendarService(repo.Friend, repo.Event, repo.FriendDate, repo.NotificationPreference),
 	}
 }
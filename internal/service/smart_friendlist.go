@@ -0,0 +1,73 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/lunovoy/friendly/internal/models"
+	"github.com/lunovoy/friendly/internal/rule"
+)
+
+func validateFriendlistRule(expr *string) error {
+	if expr == nil || *expr == "" {
+		return nil
+	}
+	if _, err := rule.Parse(*expr); err != nil {
+		return fmt.Errorf("rule is not valid: %w", err)
+	}
+	return nil
+}
+
+// matchFriendlistRule returns friends matching the rule of smart friendlist.
+func matchFriendlistRule(expr string, friends []models.FriendWorkInfoTags, now time.Time) ([]models.Friend, error) {
+	parsed, err := rule.Parse(expr)
+	if err != nil {
+		return nil, fmt.Errorf("rule is not valid: %w", err)
+	}
+
+	var matched []models.Friend
+	for _, friend := range friends {
+		if parsed.Match(ruleSubject(friend, now)) {
+			matched = append(matched, friend.Friend)
+		}
+	}
+	return matched, nil
+}
+
+func ruleSubject(friend models.FriendWorkInfoTags, now time.Time) rule.Subject {
+	subject := rule.Subject{
+		Text: map[string][]string{
+			"first_name":  {friend.Friend.FirstName},
+			"last_name":   {friend.Friend.LastName},
+			"city":        {friend.WorkInfo.City},
+			"country":     {friend.WorkInfo.Country},
+			"company":     {friend.WorkInfo.Company},
+			"position":    {friend.WorkInfo.Position},
+			"profession":  {friend.WorkInfo.Profession},
+			"language":    {friend.WorkInfo.Language},
+			"nationality": {friend.WorkInfo.Nationality},
+		},
+		Numbers: map[string]int{},
+		Dates:   map[string]time.Time{},
+		Now:     now,
+	}
+	for _, tag := range friend.Tags {
+		subject.Text["tag"] = append(subject.Text["tag"], tag.Title)
+	}
+
+	if friend.Friend.DOB.Valid {
+		dob := friend.Friend.DOB.Time
+		age := now.Year() - dob.Year()
+		if now.Month() < dob.Month() || now.Month() == dob.Month() && now.Day() < dob.Day() {
+			age--
+		}
+		subject.Numbers["age"] = age
+		subject.Numbers["dob_month"] = int(dob.Month())
+		subject.Dates["birthday"] = dob
+	}
+	if friend.Friend.LastContactedAt.Valid {
+		subject.Dates["contacted"] = friend.Friend.LastContactedAt.Time
+	}
+
+	return subject
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lunovoy/friendly/internal/models"
+)
+
+func TestMatchFriendlistRule(t *testing.T) {
+	now := date(2024, time.March, 10, 12)
+	friends := []models.FriendWorkInfoTags{
+		{
+			Friend:   models.Friend{FirstName: "Иван", DOB: sql.NullTime{Time: date(1990, time.March, 20, 0), Valid: true}},
+			WorkInfo: models.WorkInfo{City: "Moscow", Company: "Ромашка"},
+			Tags:     []models.Tag{{Title: "work"}},
+		},
+		{
+			Friend:   models.Friend{FirstName: "Анна", DOB: sql.NullTime{Time: date(1990, time.March, 1, 0), Valid: true}},
+			WorkInfo: models.WorkInfo{City: "Berlin"},
+			Tags:     []models.Tag{{Title: "family"}, {Title: "work"}},
+		},
+		{
+			Friend: models.Friend{FirstName: "Олег", LastContactedAt: sql.NullTime{Time: date(2024, time.March, 8, 18), Valid: true}},
+		},
+	}
+
+	tests := []struct {
+		rule string
+		want []string
+	}{
+		{"tag = work", []string{"Иван", "Анна"}},
+		{"tag = work and city != Moscow", []string{"Анна"}},
+		{"age = 33", []string{"Иван"}},
+		{"age = 34", []string{"Анна"}},
+		{"birthday within 14 days", []string{"Иван"}},
+		{"contacted within 7 days", []string{"Олег"}},
+		{"company contains ромаш", []string{"Иван"}},
+		{"dob_month = 4", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			matched, err := matchFriendlistRule(tt.rule, friends, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var names []string
+			for _, friend := range matched {
+				names = append(names, friend.FirstName)
+			}
+			if !reflect.DeepEqual(names, tt.want) {
+				t.Errorf("matched %v, want %v", names, tt.want)
+			}
+		})
+	}
+
+	if _, err := matchFriendlistRule("age >", friends, now); err == nil {
+		t.Error("matchFriendlistRule() of an invalid rule succeeded, want error")
+	}
+}
+
+func TestValidateFriendlistRule(t *testing.T) {
+	empty, valid, invalid := "", "city = Moscow", "city <"
+
+	tests := []struct {
+		expr    *string
+		wantErr bool
+	}{
+		{nil, false},
+		{&empty, false},
+		{&valid, false},
+		{&invalid, true},
+	}
+	for _, tt := range tests {
+		if err := validateFriendlistRule(tt.expr); (err != nil) != tt.wantErr {
+			t.Errorf("validateFriendlistRule() error = %v, wantErr %v", err, tt.wantErr)
+		}
+	}
+}
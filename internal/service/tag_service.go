@@ -35,3 +35,10 @@ func (s *TagService) Update(userID, tagID uuid.UUID, tag models.Tag) error {
 func (s *TagService) DeleteByID(userID, tagID uuid.UUID) error {
 	return s.repo.DeleteByID(userID, tagID)
 }
+
+func (s *TagService) List(userID uuid.UUID, params models.ListParams) ([]models.Tag, string, error) {
+	if err := validateListParams(params); err != nil {
+		return nil, "", err
+	}
+	return s.repo.List(userID, params)
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lunovoy/friendly/internal/models"
+	"github.com/lunovoy/friendly/internal/repository"
+)
+
+// maxTimelineRange bounds expansion of recurring events on the timeline.
+const maxTimelineRange = 5 * 366 * 24 * time.Hour
+
+type TimelineService struct {
+	interactionRepo repository.Interaction
+	eventRepo       repository.Event
+	changeRepo      repository.FriendChange
+	employmentRepo  repository.Employment
+	noteRepo        repository.Note
+}
+
+func NewTimelineService(interactionRepo repository.Interaction, eventRepo repository.Event, changeRepo repository.FriendChange, employmentRepo repository.Employment, noteRepo repository.Note) *TimelineService {
+	return &TimelineService{
+		interactionRepo: interactionRepo,
+		eventRepo:       eventRepo,
+		changeRepo:      changeRepo,
+		employmentRepo:  employmentRepo,
+		noteRepo:        noteRepo,
+	}
+}
+
+// GetTimeline merges interactions, past event occurrences, profile changes,
+// career changes and notes about or mentioning a friend within [from, to],
+// the latest first.
+func (s *TimelineService) GetTimeline(userID, friendID uuid.UUID, from, to time.Time) ([]models.TimelineItem, error) {
+	if to.Before(from) {
+		return nil, errors.New("to must not be before from")
+	}
+	if to.Sub(from) > maxTimelineRange {
+		return nil, errors.New("range must not exceed five years")
+	}
+
+	timeline := make([]models.TimelineItem, 0)
+	inRange := func(date time.Time) bool {
+		return !date.Before(from) && !date.After(to)
+	}
+
+	interactions, err := s.interactionRepo.GetAllByFriendID(userID, friendID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range interactions {
+		if inRange(interactions[i].OccurredAt) {
+			timeline = append(timeline, models.TimelineItem{
+				Kind:        models.TimelineKindInteraction,
+				Date:        interactions[i].OccurredAt,
+				Interaction: &interactions[i],
+			})
+		}
+	}
+
+	events, err := s.eventRepo.GetEventsByFriendID(userID, friendID)
+	if err != nil {
+		return nil, err
+	}
+	past := to
+	if now := time.Now(); now.Before(past) {
+		past = now
+	}
+	for i := range events {
+		for _, occurrence := range eventOccurrences(events[i], from, past) {
+			timeline = append(timeline, models.TimelineItem{
+				Kind:  models.TimelineKindEvent,
+				Date:  occurrence,
+				Event: &events[i],
+			})
+		}
+	}
+
+	changes, err := s.changeRepo.GetAllByFriendID(userID, friendID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range changes {
+		if inRange(changes[i].ChangedAt) {
+			timeline = append(timeline, models.TimelineItem{
+				Kind:   models.TimelineKindChange,
+				Date:   changes[i].ChangedAt,
+				Change: &changes[i],
+			})
+		}
+	}
+
+	employments, err := s.employmentRepo.GetAllByFriendID(userID, friendID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range employments {
+		if employments[i].StartedAt != nil && inRange(*employments[i].StartedAt) {
+			timeline = append(timeline, models.TimelineItem{
+				Kind:       models.TimelineKindEmploymentStarted,
+				Date:       *employments[i].StartedAt,
+				Employment: &employments[i],
+			})
+		}
+		if employments[i].EndedAt != nil && inRange(*employments[i].EndedAt) {
+			timeline = append(timeline, models.TimelineItem{
+				Kind:       models.TimelineKindEmploymentEnded,
+				Date:       *employments[i].EndedAt,
+				Employment: &employments[i],
+			})
+		}
+	}
+
+	notes, err := s.noteRepo.GetAllByFriendID(userID, friendID)
+	if err != nil {
+		return nil, err
+	}
+	if notes, err = resolveNoteMentions(s.noteRepo, notes); err != nil {
+		return nil, err
+	}
+	for i := range notes {
+		if inRange(notes[i].CreatedAt) {
+			timeline = append(timeline, models.TimelineItem{
+				Kind: models.TimelineKindNote,
+				Date: notes[i].CreatedAt,
+				Note: &notes[i],
+			})
+		}
+	}
+
+	sort.SliceStable(timeline, func(i, j int) bool {
+		return timeline[i].Date.After(timeline[j].Date)
+	})
+
+	return timeline, nil
+}
@@ -0,0 +1,28 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestTimelineServiceRange(t *testing.T) {
+	from := date(2024, time.January, 1, 0)
+	tests := []struct {
+		name string
+		to   time.Time
+	}{
+		{name: "to before from", to: from.Add(-time.Hour)},
+		{name: "range over five years", to: from.Add(maxTimelineRange + time.Hour)},
+	}
+
+	s := NewTimelineService(nil, nil, nil, nil, nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := s.GetTimeline(uuid.New(), uuid.New(), from, tt.to); err == nil {
+				t.Error("GetTimeline() error = nil, want error")
+			}
+		})
+	}
+}
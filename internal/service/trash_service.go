@@ -0,0 +1,58 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lunovoy/friendly/internal/models"
+	"github.com/lunovoy/friendly/internal/repository"
+)
+
+// defaultTrashRetention is used when the retention is not configured.
+const defaultTrashRetention = 30 * 24 * time.Hour
+
+var trashKinds = map[string]bool{
+	models.TrashKindFriend:     true,
+	models.TrashKindEvent:      true,
+	models.TrashKindFriendlist: true,
+	models.TrashKindTag:        true,
+}
+
+type TrashService struct {
+	repo repository.Trash
+}
+
+func NewTrashService(repo repository.Trash) *TrashService {
+	return &TrashService{
+		repo: repo,
+	}
+}
+
+func (s *TrashService) GetAll(userID uuid.UUID) ([]models.TrashItem, error) {
+	items, err := s.repo.GetAll(userID)
+	if err != nil {
+		return nil, err
+	}
+	if items == nil {
+		items = []models.TrashItem{}
+	}
+	return items, nil
+}
+
+func (s *TrashService) Restore(userID uuid.UUID, kind string, id uuid.UUID) error {
+	if !trashKinds[kind] {
+		return fmt.Errorf("kind %s is not supported", kind)
+	}
+	return s.repo.Restore(userID, kind, id)
+}
+
+// Purge deletes for good everything trashed longer than the retention ago,
+// the images of purged friends, their photos and friendlists are returned to
+// be released.
+func (s *TrashService) Purge(now time.Time, retention time.Duration) (int64, []uuid.UUID, error) {
+	if retention <= 0 {
+		retention = defaultTrashRetention
+	}
+	return s.repo.Purge(now.Add(-retention))
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lunovoy/friendly/internal/models"
+)
+
+type fakeTrashRepo struct {
+	restored string
+	before   time.Time
+	imageIDs []uuid.UUID
+}
+
+func (r *fakeTrashRepo) GetAll(userID uuid.UUID) ([]models.TrashItem, error) {
+	return nil, nil
+}
+
+func (r *fakeTrashRepo) Restore(userID uuid.UUID, kind string, id uuid.UUID) error {
+	r.restored = kind
+	return nil
+}
+
+func (r *fakeTrashRepo) Purge(before time.Time) (int64, []uuid.UUID, error) {
+	r.before = before
+	return int64(len(r.imageIDs)), r.imageIDs, nil
+}
+
+func TestTrashServicePurge(t *testing.T) {
+	now := date(2024, time.March, 10, 12)
+
+	tests := []struct {
+		name      string
+		retention time.Duration
+		want      time.Time
+	}{
+		{"configured retention", 7 * 24 * time.Hour, date(2024, time.March, 3, 12)},
+		{"default retention", 0, date(2024, time.February, 9, 12)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeTrashRepo{imageIDs: []uuid.UUID{uuid.New()}}
+			_, imageIDs, err := NewTrashService(repo).Purge(now, tt.retention)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !repo.before.Equal(tt.want) {
+				t.Errorf("purged before %s, want %s", repo.before, tt.want)
+			}
+			if len(imageIDs) != 1 || imageIDs[0] != repo.imageIDs[0] {
+				t.Errorf("image ids = %v, want %v", imageIDs, repo.imageIDs)
+			}
+		})
+	}
+}
+
+func TestTrashServiceRestore(t *testing.T) {
+	repo := &fakeTrashRepo{}
+	service := NewTrashService(repo)
+
+	if err := service.Restore(uuid.New(), models.TrashKindFriendlist, uuid.New()); err != nil {
+		t.Fatal(err)
+	}
+	if repo.restored != models.TrashKindFriendlist {
+		t.Errorf("restored %q, want %q", repo.restored, models.TrashKindFriendlist)
+	}
+	if err := service.Restore(uuid.New(), "note", uuid.New()); err == nil {
+		t.Error("Restore() of an unsupported kind succeeded, want error")
+	}
+
+	items, err := service.GetAll(uuid.New())
+	if err != nil || items == nil {
+		t.Errorf("GetAll() = %v, %v, want an empty list", items, err)
+	}
+}
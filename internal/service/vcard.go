@@ -0,0 +1,118 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lunovoy/friendly/internal/models"
+)
+
+// vCardLineLength is the octet limit after which vCard lines are folded.
+const vCardLineLength = 75
+
+var vCardEscaper = strings.NewReplacer(`\`, `\\`, ",", `\,`, ";", `\;`, "\r\n", `\n`, "\n", `\n`)
+
+// renderVCard exports the friend as vCard 3.0. Labeled contact points are
+// grouped with X-ABLABEL, that is how most address books keep custom labels.
+func renderVCard(friend models.FriendWorkInfoTags) string {
+	var lines []string
+	add := func(line string) {
+		lines = append(lines, line)
+	}
+
+	add("BEGIN:VCARD")
+	add("VERSION:3.0")
+	add(fmt.Sprintf("N:%s;%s;;;", vCardEscaper.Replace(friend.Friend.LastName), vCardEscaper.Replace(friend.Friend.FirstName)))
+	add("FN:" + vCardEscaper.Replace(strings.TrimSpace(friend.Friend.FirstName+" "+friend.Friend.LastName)))
+	if friend.Friend.DOB.Valid {
+		add("BDAY:" + friend.Friend.DOB.Time.Format("2006-01-02"))
+	}
+	if friend.WorkInfo.Company != "" {
+		add("ORG:" + vCardEscaper.Replace(friend.WorkInfo.Company))
+	}
+	if friend.WorkInfo.Position != "" {
+		add("TITLE:" + vCardEscaper.Replace(friend.WorkInfo.Position))
+	}
+	if friend.WorkInfo.Profession != "" {
+		add("ROLE:" + vCardEscaper.Replace(friend.WorkInfo.Profession))
+	}
+	if friend.WorkInfo.City != "" || friend.WorkInfo.Country != "" {
+		add(fmt.Sprintf("ADR;TYPE=WORK:;;;%s;;;%s", vCardEscaper.Replace(friend.WorkInfo.City), vCardEscaper.Replace(friend.WorkInfo.Country)))
+	}
+
+	group := 0
+	for _, contact := range friend.ContactPoints {
+		line := vCardContactLine(contact)
+		if line == "" {
+			continue
+		}
+		if contact.Label == "" {
+			add(line)
+			continue
+		}
+		group++
+		add(fmt.Sprintf("item%d.%s", group, line))
+		add(fmt.Sprintf("item%d.X-ABLABEL:%s", group, vCardEscaper.Replace(contact.Label)))
+	}
+
+	for _, tag := range friend.Tags {
+		add("CATEGORIES:" + vCardEscaper.Replace(tag.Title))
+	}
+	add("END:VCARD")
+
+	var builder strings.Builder
+	for _, line := range lines {
+		builder.WriteString(foldVCardLine(line))
+		builder.WriteString("\r\n")
+	}
+
+	return builder.String()
+}
+
+func vCardContactLine(contact models.ContactPoint) string {
+	pref := ""
+	if contact.IsPrimary {
+		pref = ",PREF"
+	}
+
+	switch contact.Kind {
+	case models.ContactPointKindPhone:
+		return fmt.Sprintf("TEL;TYPE=CELL%s:%s", pref, contact.Value)
+	case models.ContactPointKindEmail:
+		return fmt.Sprintf("EMAIL;TYPE=INTERNET%s:%s", pref, contact.Value)
+	case models.ContactPointKindTelegram:
+		return fmt.Sprintf("IMPP;X-SERVICE-TYPE=Telegram:https://t.me/%s", contact.Value)
+	case models.ContactPointKindWhatsApp:
+		return fmt.Sprintf("IMPP;X-SERVICE-TYPE=WhatsApp:https://wa.me/%s", strings.TrimPrefix(contact.Value, "+"))
+	case models.ContactPointKindSignal:
+		return fmt.Sprintf("IMPP;X-SERVICE-TYPE=Signal:sgnl:%s", contact.Value)
+	case models.ContactPointKindSocial:
+		return "URL:" + contact.Value
+	case models.ContactPointKindAddress:
+		return fmt.Sprintf("ADR;TYPE=HOME%s:;;%s;;;;", pref, vCardEscaper.Replace(contact.Value))
+	}
+
+	return ""
+}
+
+// foldVCardLine splits the line into chunks of at most vCardLineLength octets
+// without breaking UTF-8 sequences, continuation lines start with a space.
+func foldVCardLine(line string) string {
+	if len(line) <= vCardLineLength {
+		return line
+	}
+
+	var builder strings.Builder
+	length := 0
+	for _, r := range line {
+		size := len(string(r))
+		if length+size > vCardLineLength {
+			builder.WriteString("\r\n ")
+			length = 1
+		}
+		builder.WriteRune(r)
+		length += size
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lunovoy/friendly/internal/models"
+)
+
+func TestRenderVCard(t *testing.T) {
+	friend := models.FriendWorkInfoTags{
+		Friend: models.Friend{
+			FirstName: "Иван",
+			LastName:  "Петров",
+			DOB:       sql.NullTime{Time: date(1990, time.March, 10, 0), Valid: true},
+		},
+		WorkInfo: models.WorkInfo{
+			Company:  "Ромашка, Inc",
+			Position: "CTO",
+			City:     "Москва",
+			Country:  "Россия",
+		},
+		ContactPoints: []models.ContactPoint{
+			{Kind: models.ContactPointKindPhone, Value: "+79991234567", IsPrimary: true},
+			{Kind: models.ContactPointKindEmail, Value: "ivan@example.com", Label: "Работа"},
+			{Kind: models.ContactPointKindTelegram, Value: "ivan_petrov"},
+			{Kind: models.ContactPointKindWhatsApp, Value: "+79991234567"},
+			{Kind: "pager", Value: "123"},
+		},
+		Tags: []models.Tag{{Title: "Друзья; семья"}},
+	}
+
+	want := strings.Join([]string{
+		"BEGIN:VCARD",
+		"VERSION:3.0",
+		"N:Петров;Иван;;;",
+		"FN:Иван Петров",
+		"BDAY:1990-03-10",
+		`ORG:Ромашка\, Inc`,
+		"TITLE:CTO",
+		"ADR;TYPE=WORK:;;;Москва;;;Россия",
+		"TEL;TYPE=CELL,PREF:+79991234567",
+		"item1.EMAIL;TYPE=INTERNET:ivan@example.com",
+		"item1.X-ABLABEL:Работа",
+		"IMPP;X-SERVICE-TYPE=Telegram:https://t.me/ivan_petrov",
+		"IMPP;X-SERVICE-TYPE=WhatsApp:https://wa.me/79991234567",
+		`CATEGORIES:Друзья\; семья`,
+		"END:VCARD",
+		"",
+	}, "\r\n")
+	if got := renderVCard(friend); got != want {
+		t.Errorf("renderVCard() = %q, want %q", got, want)
+	}
+}
+
+func TestFoldVCardLine(t *testing.T) {
+	tests := []struct {
+		name, line, want string
+	}{
+		{"short", "FN:Иван Петров", "FN:Иван Петров"},
+		{"ascii", strings.Repeat("a", 80), strings.Repeat("a", 75) + "\r\n " + strings.Repeat("a", 5)},
+		{"multibyte runes are kept whole", strings.Repeat("ж", 40), strings.Repeat("ж", 37) + "\r\n " + strings.Repeat("ж", 3)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := foldVCardLine(tt.line)
+			if got != tt.want {
+				t.Errorf("foldVCardLine() = %q, want %q", got, tt.want)
+			}
+			for _, line := range strings.Split(got, "\r\n") {
+				if len(line) > vCardLineLength {
+					t.Errorf("folded line %q is longer than %d octets", line, vCardLineLength)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package storage
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestLocal(t *testing.T) {
+	testStorage(t, NewLocal(t.TempDir()))
+}
+
+func TestLocalInvalidKeys(t *testing.T) {
+	s := NewLocal(t.TempDir())
+	for _, key := range []string{"", "/etc/passwd", "../outside", "images/../../outside"} {
+		if err := s.Put(context.Background(), key, strings.NewReader("data"), ""); err == nil {
+			t.Errorf("put %q is accepted", key)
+		}
+	}
+}
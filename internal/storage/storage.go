@@ -0,0 +1,51 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// ErrNotFound is returned when there is no object with the key.
+var ErrNotFound = errors.New("object not found")
+
+// Storage keeps uploaded files as objects addressed by slash separated keys,
+// e.g. images/<id>.jpg.
+type Storage interface {
+	Put(ctx context.Context, key string, r io.Reader, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Stat(ctx context.Context, key string) (Object, error)
+	// Delete does not fail when there is no object with the key.
+	Delete(ctx context.Context, key string) error
+	List(ctx context.Context, prefix string) ([]Object, error)
+}
+
+type Object struct {
+	Key     string
+	Size    int64
+	ModTime time.Time
+}
+
+const (
+	BackendLocal = "local"
+	BackendS3    = "s3"
+)
+
+type Config struct {
+	Backend string
+	Dir     string
+	S3      S3Config
+}
+
+func New(cfg Config) (Storage, error) {
+	switch cfg.Backend {
+	case "", BackendLocal:
+		return NewLocal(cfg.Dir), nil
+	case BackendS3:
+		return NewS3(cfg.S3)
+	default:
+		return nil, fmt.Errorf("storage backend %s is not supported", cfg.Backend)
+	}
+}
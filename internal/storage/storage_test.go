@@ -0,0 +1,111 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	if _, err := New(Config{Backend: "ftp"}); err == nil {
+		t.Error("unknown backend is accepted")
+	}
+	if _, err := New(Config{Backend: BackendS3, S3: S3Config{Endpoint: "localhost:9000", Bucket: "b", AccessKey: "k", SecretKey: "s"}}); err == nil {
+		t.Error("endpoint without scheme is accepted")
+	}
+	if _, err := New(Config{Backend: BackendS3, S3: S3Config{Endpoint: "http://localhost:9000", Bucket: "b"}}); err == nil {
+		t.Error("s3 without keys is accepted")
+	}
+}
+
+// prefixed keeps objects of a test run apart in a shared bucket.
+type prefixed struct {
+	Storage
+	prefix string
+}
+
+func (p *prefixed) Put(ctx context.Context, key string, r io.Reader, contentType string) error {
+	return p.Storage.Put(ctx, p.prefix+key, r, contentType)
+}
+
+func (p *prefixed) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	return p.Storage.Get(ctx, p.prefix+key)
+}
+
+func (p *prefixed) Stat(ctx context.Context, key string) (Object, error) {
+	object, err := p.Storage.Stat(ctx, p.prefix+key)
+	object.Key = strings.TrimPrefix(object.Key, p.prefix)
+	return object, err
+}
+
+func (p *prefixed) Delete(ctx context.Context, key string) error {
+	return p.Storage.Delete(ctx, p.prefix+key)
+}
+
+func (p *prefixed) List(ctx context.Context, prefix string) ([]Object, error) {
+	objects, err := p.Storage.List(ctx, p.prefix+prefix)
+	for i := range objects {
+		objects[i].Key = strings.TrimPrefix(objects[i].Key, p.prefix)
+	}
+	return objects, err
+}
+
+// testStorage checks the behaviour every backend shares.
+func testStorage(t *testing.T, s Storage) {
+	t.Helper()
+	ctx := context.Background()
+
+	keys := []string{"images/a.jpg", "images/b.png", "images/variants/a/64.jpg", "attachments/c"}
+	for _, key := range keys {
+		if err := s.Put(ctx, key, strings.NewReader("data of "+key), "application/octet-stream"); err != nil {
+			t.Fatalf("put %s: %s", key, err)
+		}
+	}
+
+	file, err := s.Get(ctx, "images/b.png")
+	if err != nil {
+		t.Fatalf("get: %s", err)
+	}
+	data, err := io.ReadAll(file)
+	file.Close()
+	if err != nil || !bytes.Equal(data, []byte("data of images/b.png")) {
+		t.Errorf("get = %q, %v", data, err)
+	}
+
+	object, err := s.Stat(ctx, "images/a.jpg")
+	if err != nil || object.Size != int64(len("data of images/a.jpg")) {
+		t.Errorf("stat = %+v, %v", object, err)
+	}
+
+	objects, err := s.List(ctx, "images/")
+	if err != nil {
+		t.Fatalf("list: %s", err)
+	}
+	var listed []string
+	for _, object := range objects {
+		listed = append(listed, object.Key)
+	}
+	sort.Strings(listed)
+	if want := []string{"images/a.jpg", "images/b.png", "images/variants/a/64.jpg"}; strings.Join(listed, ",") != strings.Join(want, ",") {
+		t.Errorf("list = %v, want %v", listed, want)
+	}
+
+	for _, key := range keys {
+		if err := s.Delete(ctx, key); err != nil {
+			t.Errorf("delete %s: %s", key, err)
+		}
+	}
+	if err := s.Delete(ctx, "images/a.jpg"); err != nil {
+		t.Errorf("delete of a missing object: %s", err)
+	}
+	if _, err := s.Get(ctx, "images/a.jpg"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("get of a deleted object = %v, want ErrNotFound", err)
+	}
+	if _, err := s.Stat(ctx, "images/a.jpg"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("stat of a deleted object = %v, want ErrNotFound", err)
+	}
+}